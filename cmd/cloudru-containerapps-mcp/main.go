@@ -1,9 +1,14 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"flag"
 	"fmt"
 	"log"
 	"os"
+	"os/signal"
+	"syscall"
 
 	"github.com/Nick1994209/cloudru-containerapps-mcp/internal/application"
 	"github.com/Nick1994209/cloudru-containerapps-mcp/internal/application/cloudru"
@@ -15,20 +20,41 @@ import (
 )
 
 func main() {
+	// Parse command line flags before loading the configuration, so --version does not log configuration warnings
+	showVersion := flag.Bool("version", false, "Print version information and exit")
+	transport := flag.String("transport", config.TransportStdio, "MCP transport: stdio, sse or http (env "+config.EnvMCPTransport+")")
+	address := flag.String("addr", config.DefaultMCPAddress, "Listen address for sse and http transports (env "+config.EnvMCPAddress+")")
+	basePath := flag.String("base-path", "", "Base path for sse and http transports, http defaults to /mcp (env "+config.EnvMCPBasePath+")")
+	flag.Parse()
+
 	// Check for version flag
-	if len(os.Args) > 1 && os.Args[1] == "--version" {
+	if *showVersion {
 		fmt.Println(version.GetVersionInfo())
 		return
 	}
 
-	// Load configuration
+	// Load configuration, flags which are set explicitly override environment variables
 	cfg := config.LoadConfig()
+	flag.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "transport":
+			cfg.Transport.Type = *transport
+		case "addr":
+			cfg.Transport.Address = *address
+		case "base-path":
+			cfg.Transport.BasePath = *basePath
+		}
+	})
 
 	// Create infrastructure layer
-	dockerInfrastructure := application.NewDockerApplication(cfg)
-	containerAppsService := cloudru.NewContainerAppsApplication(cfg)
-	dockerRegistryService := cloudru.NewArtifactRegistryApplication(cfg)
-	jobsService := cloudru.NewJobsApplication(cfg)
+	// One authenticated client is shared by all services, so the access token is requested once
+	client := cloudru.NewAuthenticatedClient(cfg)
+	dockerInfrastructure := application.NewDockerApplication(cfg, client)
+	containerAppsService := cloudru.NewContainerAppsApplication(cfg, client)
+	dockerRegistryService := cloudru.NewArtifactRegistryApplication(cfg, client)
+	jobsService := cloudru.NewJobsApplication(cfg, client)
+	operationsService := cloudru.NewOperationsApplication(cfg, client)
+	secretsService := cloudru.NewSecretsApplication(cfg, client)
 
 	// Create application layer
 	descriptionService := application.NewDescriptionApplication()
@@ -38,15 +64,27 @@ func main() {
 	log.Println(descriptionService.GetDescription())
 
 	// Create presentation layer
-	mcpServer := presentation.NewMCPServer(descriptionService, dockerInfrastructure, containerAppsService, dockerRegistryService, jobsService)
+	mcpServer := presentation.NewMCPServer(descriptionService, dockerInfrastructure, containerAppsService, dockerRegistryService, jobsService, operationsService, secretsService)
+
+	// Abort in-flight tool calls when the client cancels them
+	requestCanceller := presentation.NewRequestCanceller()
 
 	// Create a new MCP server
+	serverOptions := []server.ServerOption{
+		server.WithToolCapabilities(true),
+		server.WithResourceCapabilities(false, false),
+		server.WithPromptCapabilities(false),
+		// Log tailing pushes new lines as logging notifications
+		server.WithLogging(),
+		server.WithRecovery(),
+	}
+	serverOptions = append(serverOptions, requestCanceller.ServerOptions()...)
 	s := server.NewMCPServer(
 		"Cloud.ru Container Apps MCP",
 		version.GetVersion(),
-		server.WithToolCapabilities(true),
-		server.WithRecovery(),
+		serverOptions...,
 	)
+	requestCanceller.Register(s)
 
 	// Register tools with the MCP server
 	mcpServer.RegisterDescriptionTool(s)
@@ -60,9 +98,12 @@ func main() {
 	mcpServer.RegisterStartContainerAppTool(s)
 	mcpServer.RegisterStopContainerAppTool(s)
 	mcpServer.RegisterGetContainerAppLogsTool(s)
-	// mcpServer.RegisterGetContainerAppSystemLogsTool(s)
+	mcpServer.RegisterTailContainerAppLogsTool(s)
+	mcpServer.RegisterGetContainerAppSystemLogsTool(s)
 	mcpServer.RegisterGetListDockerRegistriesTool(s)
 	mcpServer.RegisterCreateDockerRegistryTool(s)
+	mcpServer.RegisterGetListSecretsTool(s)
+	mcpServer.RegisterCreateSecretTool(s)
 	// mcpServer.RegisterGetRegistryImagesTool(s)
 	mcpServer.RegisterGetListJobsTool(s)
 	mcpServer.RegisterGetJobTool(s)
@@ -71,9 +112,29 @@ func main() {
 	mcpServer.RegisterDeleteJobTool(s)
 	mcpServer.RegisterExecuteJobTool(s)
 	mcpServer.RegisterGetListExecutionsTool(s)
+	mcpServer.RegisterGetJobExecutionTool(s)
+	mcpServer.RegisterGetJobExecutionLogsTool(s)
+	mcpServer.RegisterGetOperationTool(s)
+	mcpServer.RegisterWaitOperationTool(s)
+	mcpServer.RegisterDeployTool(s)
+	mcpServer.RegisterApplyTool(s)
+	mcpServer.RegisterDiffTool(s)
+	mcpServer.RegisterExportContainerAppTool(s)
+	mcpServer.RegisterExportJobTool(s)
+	mcpServer.RegisterCloneContainerAppTool(s)
+	mcpServer.RegisterCloneJobTool(s)
+
+	// Register read-only resources and workflow prompts with the MCP server
+	mcpServer.RegisterResources(s)
+	mcpServer.RegisterPrompts(s)
+
+	// Stop the server gracefully on SIGINT and SIGTERM
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
 
-	// Start the server
-	if err := server.ServeStdio(s); err != nil {
-		fmt.Printf("Server error: %v\n", err)
+	// Start the server, a stop signal is a clean shutdown
+	if err := presentation.Serve(ctx, s, cfg.Transport); err != nil && !errors.Is(err, context.Canceled) {
+		stop()
+		log.Fatalf("Server error: %v", err)
 	}
 }
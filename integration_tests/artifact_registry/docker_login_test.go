@@ -1,9 +1,11 @@
 package main
 
 import (
+	"context"
 	"log"
 
 	"github.com/Nick1994209/cloudru-containerapps-mcp/internal/application"
+	"github.com/Nick1994209/cloudru-containerapps-mcp/internal/application/cloudru"
 	"github.com/Nick1994209/cloudru-containerapps-mcp/internal/config"
 )
 
@@ -15,10 +17,10 @@ func runDockerLoginTest() {
 }
 
 func testDockerLogin(cfg *config.Config, registryName string) {
-	dockerApp := application.NewDockerApplication(cfg)
+	dockerApp := application.NewDockerApplication(cfg, cloudru.NewAuthenticatedClient(cfg))
 
 	log.Printf("Testing Docker login with registry: %s...", registryName)
-	registry, err := dockerApp.Login(registryName)
+	registry, err := dockerApp.Login(context.Background(), registryName)
 	if err != nil {
 		log.Printf("Docker login error: %v", err)
 	} else {
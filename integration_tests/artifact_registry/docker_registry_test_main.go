@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"log"
 
 	"github.com/Nick1994209/cloudru-containerapps-mcp/internal/application/cloudru"
@@ -15,10 +16,10 @@ func main() {
 }
 
 func getListDockerRegistries(cfg *config.Config) {
-	dr := cloudru.NewArtifactRegistryApplication(cfg)
+	dr := cloudru.NewArtifactRegistryApplication(cfg, cloudru.NewAuthenticatedClient(cfg))
 
 	log.Println("Testing GetListDockerRegistries...")
-	registries, err := dr.GetListDockerRegistries(cfg.ProjectID)
+	registries, err := dr.GetListDockerRegistries(context.Background(), cfg.ProjectID)
 	if err != nil {
 		log.Printf("GetListDockerRegistries error: %v", err)
 	} else {
@@ -28,10 +29,11 @@ func getListDockerRegistries(cfg *config.Config) {
 }
 
 func createDockerRegistry(cfg *config.Config, name string, isPublic bool) {
-	dr := cloudru.NewArtifactRegistryApplication(cfg)
+	dr := cloudru.NewArtifactRegistryApplication(cfg, cloudru.NewAuthenticatedClient(cfg))
 
 	log.Printf("Testing CreateDockerRegistry with name: %s, isPublic: %v...", name, isPublic)
 	registry, err := dr.CreateDockerRegistry(
+		context.Background(),
 		cfg.ProjectID,
 		name,
 		isPublic,
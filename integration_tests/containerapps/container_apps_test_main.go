@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"os"
@@ -87,23 +88,23 @@ func main() {
 }
 
 func getListContainerApps(cfg *config.Config) {
-	ca := cloudru.NewContainerAppsApplication(cfg)
+	ca := cloudru.NewContainerAppsApplication(cfg, cloudru.NewAuthenticatedClient(cfg))
 
 	log.Println("Testing GetListContainerApps...")
-	cas, err := ca.GetListContainerApps(cfg.ProjectID)
+	cas, err := ca.GetListContainerApps(context.Background(), cfg.ProjectID, domain.ListOptions{FetchAll: true})
 	if err != nil {
 		log.Printf("GetListContainerApps error: %v", err)
 	} else {
-		log.Printf("GetListContainerApps success: found %d container apps", len(cas))
-		log.Printf("Container apps: %+v", cas)
+		log.Printf("GetListContainerApps success: found %d container apps", len(cas.Data))
+		log.Printf("Container apps: %+v", cas.Data)
 	}
 }
 
 func getContainerApp(cfg *config.Config, name string) {
-	ca := cloudru.NewContainerAppsApplication(cfg)
+	ca := cloudru.NewContainerAppsApplication(cfg, cloudru.NewAuthenticatedClient(cfg))
 
 	log.Println("Testing GetContainerApp...")
-	cas_, err := ca.GetContainerApp(cfg.ProjectID, name)
+	cas_, err := ca.GetContainerApp(context.Background(), cfg.ProjectID, name)
 	if err != nil {
 		log.Printf("GetContainerApp error: %v", err)
 	} else {
@@ -112,10 +113,10 @@ func getContainerApp(cfg *config.Config, name string) {
 }
 
 func getContainerAppForVerification(cfg *config.Config, name string) *domain.ContainerApp {
-	ca := cloudru.NewContainerAppsApplication(cfg)
+	ca := cloudru.NewContainerAppsApplication(cfg, cloudru.NewAuthenticatedClient(cfg))
 
 	log.Println("Getting ContainerApp for verification...")
-	containerApp, err := ca.GetContainerApp(cfg.ProjectID, name)
+	containerApp, err := ca.GetContainerApp(context.Background(), cfg.ProjectID, name)
 	if err != nil {
 		log.Fatalf("GetContainerApp for verification error: %v", err)
 	}
@@ -124,10 +125,10 @@ func getContainerAppForVerification(cfg *config.Config, name string) *domain.Con
 }
 
 func deleteContainerApp(cfg *config.Config, name string) {
-	ca := cloudru.NewContainerAppsApplication(cfg)
+	ca := cloudru.NewContainerAppsApplication(cfg, cloudru.NewAuthenticatedClient(cfg))
 
 	log.Println("Testing DeleteContainerApp...")
-	_, err := ca.DeleteContainerApp(cfg.ProjectID, name)
+	_, err := ca.DeleteContainerApp(context.Background(), cfg.ProjectID, name)
 	if err != nil {
 		log.Printf("deleteContainerApp error: %v", err)
 	} else {
@@ -136,7 +137,7 @@ func deleteContainerApp(cfg *config.Config, name string) {
 }
 
 func createContainerApp(cfg *config.Config, name, image string, port int, autoDeploymentsEnabled bool) *domain.ContainerApp {
-	ca := cloudru.NewContainerAppsApplication(cfg)
+	ca := cloudru.NewContainerAppsApplication(cfg, cloudru.NewAuthenticatedClient(cfg))
 
 	// Test CreateContainerApp
 	request := domain.CreateContainerAppRequest{
@@ -152,14 +153,14 @@ func createContainerApp(cfg *config.Config, name, image string, port int, autoDe
 		CPU:                    "0.1",                  // cpu
 	}
 
-	operation, err := ca.CreateContainerApp(request)
+	operation, err := ca.CreateContainerApp(context.Background(), request)
 	log.Print(operation)
 	if err != nil {
 		log.Fatalf("CreateContainerApp error: %v", err.Error())
 	}
 
 	// For testing purposes, we need to get the actual container app
-	containerApp, err := ca.GetContainerApp(cfg.ProjectID, name)
+	containerApp, err := ca.GetContainerApp(context.Background(), cfg.ProjectID, name)
 	if err != nil {
 		log.Fatalf("GetContainerApp error: %v", err.Error())
 	}
@@ -189,7 +190,7 @@ func verifyAutoDeployments(containerApp *domain.ContainerApp, expectedEnabled bo
 }
 
 func patchContainerApp(cfg *config.Config, name string) {
-	ca := cloudru.NewContainerAppsApplication(cfg)
+	ca := cloudru.NewContainerAppsApplication(cfg, cloudru.NewAuthenticatedClient(cfg))
 
 	// Test PatchContainerApp with various updates
 	log.Println("Testing PatchContainerApp...")
@@ -216,7 +217,7 @@ func patchContainerApp(cfg *config.Config, name string) {
 	}
 
 	// Perform the patch
-	operation, err := ca.PatchContainerApp(cfg.ProjectID, name, request)
+	operation, err := ca.PatchContainerApp(context.Background(), cfg.ProjectID, name, request)
 	if err != nil {
 		log.Fatalf("PatchContainerApp error: %v", err)
 	}
@@ -224,7 +225,7 @@ func patchContainerApp(cfg *config.Config, name string) {
 	log.Printf("PatchContainerApp operation completed: %+v", operation)
 
 	// Get the updated container app for verification
-	patchedContainerApp, err := ca.GetContainerApp(cfg.ProjectID, name)
+	patchedContainerApp, err := ca.GetContainerApp(context.Background(), cfg.ProjectID, name)
 	if err != nil {
 		log.Fatalf("GetContainerApp error: %v", err)
 	}
@@ -1,9 +1,11 @@
 package main
 
 import (
+	"context"
 	"log"
 
 	"github.com/Nick1994209/cloudru-containerapps-mcp/internal/application"
+	"github.com/Nick1994209/cloudru-containerapps-mcp/internal/application/cloudru"
 	"github.com/Nick1994209/cloudru-containerapps-mcp/internal/config"
 	"github.com/Nick1994209/cloudru-containerapps-mcp/internal/domain"
 )
@@ -25,10 +27,10 @@ func main() {
 }
 
 func testDockerLogin(cfg *config.Config, registryName string) {
-	dockerApp := application.NewDockerApplication(cfg)
+	dockerApp := application.NewDockerApplication(cfg, cloudru.NewAuthenticatedClient(cfg))
 
 	log.Printf("Testing Docker login with registry: %s...", registryName)
-	loginTarget, err := dockerApp.Login(registryName)
+	loginTarget, err := dockerApp.Login(context.Background(), registryName)
 	if err != nil {
 		log.Printf("Docker login error: %v", err)
 	} else {
@@ -37,7 +39,7 @@ func testDockerLogin(cfg *config.Config, registryName string) {
 }
 
 func testShowBuildAndPushCommands(cfg *config.Config) {
-	dockerApp := application.NewDockerApplication(cfg)
+	dockerApp := application.NewDockerApplication(cfg, cloudru.NewAuthenticatedClient(cfg))
 
 	image := domain.DockerImage{
 		RegistryName:     "nvkorolkov",
@@ -49,7 +51,7 @@ func testShowBuildAndPushCommands(cfg *config.Config) {
 	}
 
 	log.Println("Testing ShowBuildAndPushCommands...")
-	buildCmd, pushCmd, err := dockerApp.ShowBuildAndPushCommands(image)
+	buildCmd, pushCmd, err := dockerApp.ShowBuildAndPushCommands(context.Background(), image)
 	if err != nil {
 		log.Printf("ShowBuildAndPushCommands error: %v", err)
 	} else {
@@ -60,7 +62,7 @@ func testShowBuildAndPushCommands(cfg *config.Config) {
 }
 
 func testBuildAndPush(cfg *config.Config) {
-	dockerApp := application.NewDockerApplication(cfg)
+	dockerApp := application.NewDockerApplication(cfg, cloudru.NewAuthenticatedClient(cfg))
 
 	image := domain.DockerImage{
 		RegistryName:     "nvkorolkov",
@@ -72,7 +74,7 @@ func testBuildAndPush(cfg *config.Config) {
 	}
 
 	log.Println("Testing BuildAndPush...")
-	imageTag, err := dockerApp.BuildAndPush(image)
+	imageTag, err := dockerApp.BuildAndPush(context.Background(), image)
 	if err != nil {
 		log.Printf("BuildAndPush error: %v", err)
 	} else {
@@ -81,10 +83,10 @@ func testBuildAndPush(cfg *config.Config) {
 }
 
 func testGetRegistryImages(cfg *config.Config, registryName string) {
-	dockerApp := application.NewDockerApplication(cfg)
+	dockerApp := application.NewDockerApplication(cfg, cloudru.NewAuthenticatedClient(cfg))
 
 	log.Printf("Testing GetRegistryImages with registry: %s...", registryName)
-	images, err := dockerApp.GetRegistryImages(registryName)
+	images, err := dockerApp.GetRegistryImages(context.Background(), registryName)
 	if err != nil {
 		log.Printf("GetRegistryImages error: %v", err)
 	} else {
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"os"
@@ -320,13 +321,13 @@ func runPatchTest(cfg *config.Config, test PatchTestCase) {
 	log.Printf("Waiting for container app to be ready: %s", test.ContainerName)
 	waitForContainerAppReady(cfg, test.ContainerName, 30, 5*time.Second)
 
-	ca := cloudru.NewContainerAppsApplication(cfg)
+	ca := cloudru.NewContainerAppsApplication(cfg, cloudru.NewAuthenticatedClient(cfg))
 
 	// Update request with container name
 	test.Request.ContainerAppName = test.ContainerName
 
 	// Execute patch
-	operation, err := ca.PatchContainerApp(cfg.ProjectID, test.ContainerName, test.Request)
+	operation, err := ca.PatchContainerApp(context.Background(), cfg.ProjectID, test.ContainerName, test.Request)
 	if err != nil {
 		log.Fatalf("Patch failed: %v", err)
 	}
@@ -353,7 +354,7 @@ func runPatchTest(cfg *config.Config, test PatchTestCase) {
 
 // createTestContainerApp creates a test container app for patching
 func createTestContainerApp(cfg *config.Config, name string) {
-	ca := cloudru.NewContainerAppsApplication(cfg)
+	ca := cloudru.NewContainerAppsApplication(cfg, cloudru.NewAuthenticatedClient(cfg))
 
 	request := domain.CreateContainerAppRequest{
 		ProjectID:              cfg.ProjectID,
@@ -373,7 +374,7 @@ func createTestContainerApp(cfg *config.Config, name string) {
 		Protocol:               "http_1",
 	}
 
-	operation, err := ca.CreateContainerApp(request)
+	operation, err := ca.CreateContainerApp(context.Background(), request)
 	if err != nil {
 		log.Fatalf("Failed to create test container app: %v", err)
 	}
@@ -383,9 +384,9 @@ func createTestContainerApp(cfg *config.Config, name string) {
 
 // deleteTestContainerApp deletes the test container app
 func deleteTestContainerApp(cfg *config.Config, name string) {
-	ca := cloudru.NewContainerAppsApplication(cfg)
+	ca := cloudru.NewContainerAppsApplication(cfg, cloudru.NewAuthenticatedClient(cfg))
 
-	_, err := ca.DeleteContainerApp(cfg.ProjectID, name)
+	_, err := ca.DeleteContainerApp(context.Background(), cfg.ProjectID, name)
 	if err != nil {
 		log.Printf("Warning: Failed to delete test container app: %v", err)
 	} else {
@@ -395,9 +396,9 @@ func deleteTestContainerApp(cfg *config.Config, name string) {
 
 // getContainerApp retrieves a container app for verification
 func getContainerApp(cfg *config.Config, name string) *domain.ContainerApp {
-	ca := cloudru.NewContainerAppsApplication(cfg)
+	ca := cloudru.NewContainerAppsApplication(cfg, cloudru.NewAuthenticatedClient(cfg))
 
-	containerApp, err := ca.GetContainerApp(cfg.ProjectID, name)
+	containerApp, err := ca.GetContainerApp(context.Background(), cfg.ProjectID, name)
 	if err != nil {
 		log.Fatalf("Failed to get container app: %v", err)
 	}
@@ -407,10 +408,10 @@ func getContainerApp(cfg *config.Config, name string) *domain.ContainerApp {
 
 // waitForContainerAppReady waits for the container app to be ready (not in "for_publish" status)
 func waitForContainerAppReady(cfg *config.Config, name string, maxRetries int, retryInterval time.Duration) {
-	ca := cloudru.NewContainerAppsApplication(cfg)
+	ca := cloudru.NewContainerAppsApplication(cfg, cloudru.NewAuthenticatedClient(cfg))
 
 	for i := 0; i < maxRetries; i++ {
-		containerApp, err := ca.GetContainerApp(cfg.ProjectID, name)
+		containerApp, err := ca.GetContainerApp(context.Background(), cfg.ProjectID, name)
 		if err != nil {
 			log.Printf("Attempt %d/%d: Failed to get container app: %v", i+1, maxRetries, err)
 		} else {
@@ -432,10 +433,10 @@ func waitForContainerAppReady(cfg *config.Config, name string, maxRetries int, r
 
 // waitForContainerAppNotProcessing waits for the container app to not be in processing status
 func waitForContainerAppNotProcessing(cfg *config.Config, name string, maxRetries int, retryInterval time.Duration) {
-	ca := cloudru.NewContainerAppsApplication(cfg)
+	ca := cloudru.NewContainerAppsApplication(cfg, cloudru.NewAuthenticatedClient(cfg))
 
 	for i := 0; i < maxRetries; i++ {
-		containerApp, err := ca.GetContainerApp(cfg.ProjectID, name)
+		containerApp, err := ca.GetContainerApp(context.Background(), cfg.ProjectID, name)
 		if err != nil {
 			log.Printf("Attempt %d/%d: Failed to get container app: %v", i+1, maxRetries, err)
 		} else {
@@ -470,10 +471,10 @@ func boolPtr(b bool) *bool {
 
 // cleanupAllTestPatchContainerApps deletes all containerapps starting with "test-patch"
 func cleanupAllTestPatchContainerApps(cfg *config.Config) {
-	ca := cloudru.NewContainerAppsApplication(cfg)
+	ca := cloudru.NewContainerAppsApplication(cfg, cloudru.NewAuthenticatedClient(cfg))
 
 	// Get all containerapps
-	containerApps, err := ca.GetListContainerApps(cfg.ProjectID)
+	containerApps, err := ca.GetListContainerApps(context.Background(), cfg.ProjectID, domain.ListOptions{FetchAll: true})
 	if err != nil {
 		log.Printf("Warning: Failed to list containerapps for cleanup: %v", err)
 		return
@@ -481,10 +482,10 @@ func cleanupAllTestPatchContainerApps(cfg *config.Config) {
 
 	// Find and delete all containerapps starting with "test-patch"
 	deletedCount := 0
-	for _, app := range containerApps {
+	for _, app := range containerApps.Data {
 		if len(app.Name) >= 10 && app.Name[:10] == "test-patch" {
 			log.Printf("Deleting test container app: %s", app.Name)
-			_, err := ca.DeleteContainerApp(cfg.ProjectID, app.Name)
+			_, err := ca.DeleteContainerApp(context.Background(), cfg.ProjectID, app.Name)
 			if err != nil {
 				log.Printf("Warning: Failed to delete test container app %s: %v", app.Name, err)
 			} else {
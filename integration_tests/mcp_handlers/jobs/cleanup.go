@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strings"
@@ -8,26 +9,27 @@ import (
 
 	"github.com/Nick1994209/cloudru-containerapps-mcp/internal/application/cloudru"
 	"github.com/Nick1994209/cloudru-containerapps-mcp/internal/config"
+	"github.com/Nick1994209/cloudru-containerapps-mcp/internal/domain"
 )
 
 // cleanupTestJobs deletes all jobs that start with "test-job-"
 func cleanupTestJobs(cfg *config.Config) {
 	log.Println("\n--- Cleanup: Deleting Test Jobs ---")
 
-	jobs := cloudru.NewJobsApplication(cfg)
+	jobs := cloudru.NewJobsApplication(cfg, cloudru.NewAuthenticatedClient(cfg))
 
 	// Add a small delay to ensure any previous operations are complete
 	time.Sleep(2 * time.Second)
 
 	// List all jobs
-	jobList, err := jobs.GetListJobs(cfg.ProjectID, "")
+	jobList, err := jobs.GetListJobs(context.Background(), cfg.ProjectID, domain.ListOptions{FetchAll: true})
 	if err != nil {
 		log.Printf("Warning: Failed to list jobs for cleanup: %v", err)
 		log.Println("✓ Cleanup completed (skipped due to list failure)")
 		return
 	}
 
-	if len(jobList) == 0 {
+	if len(jobList.Data) == 0 {
 		log.Println("No jobs found for cleanup")
 		log.Println("✓ Cleanup completed")
 		return
@@ -35,14 +37,14 @@ func cleanupTestJobs(cfg *config.Config) {
 
 	// Find and delete all test jobs
 	deletedCount := 0
-	for _, job := range jobList {
+	for _, job := range jobList.Data {
 		if strings.HasPrefix(job.Name, "test-job-") {
 			log.Printf("Deleting test job: %s (ID: %s)", job.Name, job.ID)
 			deleteRequest := map[string]interface{}{
 				"projectId": cfg.ProjectID,
 				"jobName":   job.Name,
 			}
-			_, err := jobs.DeleteJob(cfg.ProjectID, job.Name)
+			_, err := jobs.DeleteJob(context.Background(), cfg.ProjectID, job.Name)
 			if err != nil {
 				logErrorWithRequestBody(fmt.Sprintf("Warning: Failed to delete job %s", job.Name), err, deleteRequest)
 			} else {
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"log"
 	"time"
 
@@ -13,7 +14,7 @@ import (
 func testCreateJob(cfg *config.Config) []TestJob {
 	log.Println("\n--- Test: Create Job ---")
 
-	jobs := cloudru.NewJobsApplication(cfg)
+	jobs := cloudru.NewJobsApplication(cfg, cloudru.NewAuthenticatedClient(cfg))
 	var createdJobs []TestJob
 
 	// Add a small delay to ensure any previous operations are complete
@@ -32,7 +33,7 @@ func testCreateJob(cfg *config.Config) []TestJob {
 		JobExecutionTimeout: 60,
 	}
 
-	operation, err := jobs.CreateJob(minimalRequest)
+	operation, err := jobs.CreateJob(context.Background(), minimalRequest)
 	if err != nil {
 		logErrorWithRequestBody("Warning: Create job with minimal parameters failed", err, minimalRequest)
 	} else {
@@ -62,7 +63,7 @@ func testCreateJob(cfg *config.Config) []TestJob {
 		JobRunImmediately:       true,
 	}
 
-	operation, err = jobs.CreateJob(fullRequest)
+	operation, err = jobs.CreateJob(context.Background(), fullRequest)
 	if err != nil {
 		logErrorWithRequestBody("Warning: Create job with all parameters failed", err, fullRequest)
 	} else {
@@ -89,7 +90,7 @@ func testCreateJob(cfg *config.Config) []TestJob {
 		JobExecutionTimeout: 60,
 	}
 
-	operation, err = jobs.CreateJob(cmdRequest)
+	operation, err = jobs.CreateJob(context.Background(), cmdRequest)
 	if err != nil {
 		logErrorWithRequestBody("Warning: Create job with command and args failed", err, cmdRequest)
 	} else {
@@ -115,7 +116,7 @@ func testCreateJob(cfg *config.Config) []TestJob {
 		JobExecutionTimeout:     60,
 	}
 
-	operation, err = jobs.CreateJob(envRequest)
+	operation, err = jobs.CreateJob(context.Background(), envRequest)
 	if err != nil {
 		logErrorWithRequestBody("Warning: Create job with environment variables failed", err, envRequest)
 	} else {
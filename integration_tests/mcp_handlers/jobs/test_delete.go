@@ -1,18 +1,20 @@
 package main
 
 import (
+	"context"
 	"log"
 	"time"
 
 	"github.com/Nick1994209/cloudru-containerapps-mcp/internal/application/cloudru"
 	"github.com/Nick1994209/cloudru-containerapps-mcp/internal/config"
+	"github.com/Nick1994209/cloudru-containerapps-mcp/internal/domain"
 )
 
 // testDeleteJob tests deleting a job
 func testDeleteJob(cfg *config.Config, testJobs []TestJob) {
 	log.Println("\n--- Test: Delete Job ---")
 
-	jobs := cloudru.NewJobsApplication(cfg)
+	jobs := cloudru.NewJobsApplication(cfg, cloudru.NewAuthenticatedClient(cfg))
 
 	// Add a small delay to ensure any previous operations are complete
 	time.Sleep(2 * time.Second)
@@ -28,15 +30,15 @@ func testDeleteJob(cfg *config.Config, testJobs []TestJob) {
 		log.Printf("\nDeleting job: %s", testJob.Name)
 
 		// Get the job ID by listing jobs
-		jobList, err := jobs.GetListJobs(cfg.ProjectID, "")
-		if err != nil || len(jobList) == 0 {
+		jobList, err := jobs.GetListJobs(context.Background(), cfg.ProjectID, domain.ListOptions{FetchAll: true})
+		if err != nil || len(jobList.Data) == 0 {
 			log.Printf("Warning: Failed to list jobs to find job ID: %v", err)
 			continue
 		}
 
 		// Find the job by name
 		var jobID string
-		for _, job := range jobList {
+		for _, job := range jobList.Data {
 			if job.Name == testJob.Name {
 				jobID = job.ID
 				break
@@ -56,7 +58,7 @@ func testDeleteJob(cfg *config.Config, testJobs []TestJob) {
 			"projectId": cfg.ProjectID,
 			"jobName":   testJob.Name,
 		}
-		operation, err := jobs.DeleteJob(cfg.ProjectID, testJob.Name)
+		operation, err := jobs.DeleteJob(context.Background(), cfg.ProjectID, testJob.Name)
 		if err != nil {
 			logErrorWithRequestBody("Warning: Delete job failed", err, deleteRequest)
 		} else {
@@ -73,7 +75,7 @@ func testDeleteJob(cfg *config.Config, testJobs []TestJob) {
 			"projectId": cfg.ProjectID,
 			"jobName":   testJob.Name,
 		}
-		_, err = jobs.GetJob(cfg.ProjectID, testJob.Name)
+		_, err = jobs.GetJob(context.Background(), cfg.ProjectID, testJob.Name)
 		if err != nil {
 			logErrorWithRequestBody("Job successfully deleted (GetJob returned error as expected)", err, getRequest)
 		} else {
@@ -86,7 +88,7 @@ func testDeleteJob(cfg *config.Config, testJobs []TestJob) {
 			"projectId": cfg.ProjectID,
 			"jobName":   testJob.Name,
 		}
-		_, err = jobs.DeleteJob(cfg.ProjectID, testJob.Name)
+		_, err = jobs.DeleteJob(context.Background(), cfg.ProjectID, testJob.Name)
 		if err != nil {
 			logErrorWithRequestBody("Expected error when deleting already deleted job", err, deleteRequest2)
 		} else {
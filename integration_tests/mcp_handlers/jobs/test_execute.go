@@ -1,18 +1,20 @@
 package main
 
 import (
+	"context"
 	"log"
 	"time"
 
 	"github.com/Nick1994209/cloudru-containerapps-mcp/internal/application/cloudru"
 	"github.com/Nick1994209/cloudru-containerapps-mcp/internal/config"
+	"github.com/Nick1994209/cloudru-containerapps-mcp/internal/domain"
 )
 
 // testExecuteJob tests executing a job
 func testExecuteJob(cfg *config.Config, testJob TestJob) {
 	log.Println("\n--- Test: Execute Job ---")
 
-	jobs := cloudru.NewJobsApplication(cfg)
+	jobs := cloudru.NewJobsApplication(cfg, cloudru.NewAuthenticatedClient(cfg))
 
 	// Add a small delay to ensure any previous operations are complete
 	time.Sleep(2 * time.Second)
@@ -20,7 +22,7 @@ func testExecuteJob(cfg *config.Config, testJob TestJob) {
 	log.Printf("Attempting to execute job with name: %s", testJob.Name)
 
 	// Execute job with empty params
-	jobExecution, err := jobs.ExecuteJob(cfg.ProjectID, testJob.Name, map[string]interface{}{})
+	jobExecution, err := jobs.ExecuteJob(context.Background(), cfg.ProjectID, testJob.Name, map[string]interface{}{})
 	if err != nil {
 		log.Printf("Warning: Execute job failed: %v", err)
 		log.Println("✓ Execute job test completed (with potential expected error if job execution is not allowed)")
@@ -40,7 +42,7 @@ func testExecuteJob(cfg *config.Config, testJob TestJob) {
 func testListJobExecutions(cfg *config.Config, testJob TestJob) {
 	log.Println("\n--- Test: List Job Executions ---")
 
-	jobs := cloudru.NewJobsApplication(cfg)
+	jobs := cloudru.NewJobsApplication(cfg, cloudru.NewAuthenticatedClient(cfg))
 
 	// Add a small delay to ensure any previous operations are complete
 	time.Sleep(2 * time.Second)
@@ -48,17 +50,17 @@ func testListJobExecutions(cfg *config.Config, testJob TestJob) {
 	log.Printf("Attempting to list executions for job: %s", testJob.Name)
 
 	// List job executions
-	executions, err := jobs.GetListExecutions(cfg.ProjectID, testJob.Name, "")
+	executions, err := jobs.GetListExecutions(context.Background(), cfg.ProjectID, testJob.Name, domain.ListOptions{})
 	if err != nil {
 		log.Printf("Warning: List job executions failed: %v", err)
 		log.Println("✓ List job executions test completed (with potential expected error if no executions exist)")
 		return
 	}
 
-	log.Printf("Successfully retrieved %d executions for job %s", len(executions), testJob.Name)
+	log.Printf("Successfully retrieved %d executions for job %s", len(executions.Data), testJob.Name)
 
 	// Print first few executions for verification
-	for i, execution := range executions {
+	for i, execution := range executions.Data {
 		if i >= 3 { // Only print first 3 executions
 			break
 		}
@@ -1,18 +1,20 @@
 package main
 
 import (
+	"context"
 	"log"
 	"time"
 
 	"github.com/Nick1994209/cloudru-containerapps-mcp/internal/application/cloudru"
 	"github.com/Nick1994209/cloudru-containerapps-mcp/internal/config"
+	"github.com/Nick1994209/cloudru-containerapps-mcp/internal/domain"
 )
 
 // testGetJob tests getting a specific job
 func testGetJob(cfg *config.Config, testJob TestJob) {
 	log.Println("\n--- Test: Get Job ---")
 
-	jobs := cloudru.NewJobsApplication(cfg)
+	jobs := cloudru.NewJobsApplication(cfg, cloudru.NewAuthenticatedClient(cfg))
 
 	// Add a small delay to ensure any previous operations are complete
 	time.Sleep(2 * time.Second)
@@ -20,8 +22,8 @@ func testGetJob(cfg *config.Config, testJob TestJob) {
 	log.Printf("Attempting to get job with name: %s", testJob.Name)
 
 	// First, try to get a list of jobs to find the job ID
-	jobList, err := jobs.GetListJobs(cfg.ProjectID, "")
-	if err != nil || len(jobList) == 0 {
+	jobList, err := jobs.GetListJobs(context.Background(), cfg.ProjectID, domain.ListOptions{FetchAll: true})
+	if err != nil || len(jobList.Data) == 0 {
 		log.Printf("Warning: No jobs found or failed to list jobs: %v", err)
 		log.Println("✓ Get job test completed (skipped due to no jobs available)")
 		return
@@ -29,7 +31,7 @@ func testGetJob(cfg *config.Config, testJob TestJob) {
 
 	// Find the job by name
 	var jobID string
-	for _, job := range jobList {
+	for _, job := range jobList.Data {
 		if job.Name == testJob.Name {
 			jobID = job.ID
 			break
@@ -49,7 +51,7 @@ func testGetJob(cfg *config.Config, testJob TestJob) {
 		"projectId": cfg.ProjectID,
 		"jobName":   testJob.Name,
 	}
-	job, err := jobs.GetJob(cfg.ProjectID, testJob.Name)
+	job, err := jobs.GetJob(context.Background(), cfg.ProjectID, testJob.Name)
 	if err != nil {
 		logErrorWithRequestBody("Warning: Get job failed", err, getRequest)
 		log.Println("✓ Get job test completed (with potential expected error if job doesn't exist)")
@@ -1,34 +1,36 @@
 package main
 
 import (
+	"context"
 	"log"
 	"time"
 
 	"github.com/Nick1994209/cloudru-containerapps-mcp/internal/application/cloudru"
 	"github.com/Nick1994209/cloudru-containerapps-mcp/internal/config"
+	"github.com/Nick1994209/cloudru-containerapps-mcp/internal/domain"
 )
 
 // testListJobs tests listing jobs
 func testListJobs(cfg *config.Config, createdJobs []TestJob) {
 	log.Println("\n--- Test: List Jobs ---")
 
-	jobs := cloudru.NewJobsApplication(cfg)
+	jobs := cloudru.NewJobsApplication(cfg, cloudru.NewAuthenticatedClient(cfg))
 
 	// Add a small delay to ensure any previous operations are complete
 	time.Sleep(2 * time.Second)
 
 	// List jobs
-	jobList, err := jobs.GetListJobs(cfg.ProjectID, "")
+	jobList, err := jobs.GetListJobs(context.Background(), cfg.ProjectID, domain.ListOptions{FetchAll: true})
 	if err != nil {
 		log.Printf("Warning: List jobs failed: %v", err)
 		log.Println("✓ List jobs test completed (with potential expected error if no jobs exist)")
 		return
 	}
 
-	log.Printf("Successfully retrieved %d jobs", len(jobList))
+	log.Printf("Successfully retrieved %d jobs", len(jobList.Data))
 
 	// Print first few jobs for verification
-	for i, job := range jobList {
+	for i, job := range jobList.Data {
 		if i >= 3 { // Only print first 3 jobs
 			break
 		}
@@ -41,7 +43,7 @@ func testListJobs(cfg *config.Config, createdJobs []TestJob) {
 		foundCount := 0
 		for _, createdJob := range createdJobs {
 			found := false
-			for _, job := range jobList {
+			for _, job := range jobList.Data {
 				if job.ID == createdJob.ID {
 					found = true
 					foundCount++
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"log"
 	"time"
 
@@ -13,7 +14,7 @@ import (
 func testPatchJob(cfg *config.Config, testJob TestJob) {
 	log.Println("\n--- Test: Patch Job ---")
 
-	jobs := cloudru.NewJobsApplication(cfg)
+	jobs := cloudru.NewJobsApplication(cfg, cloudru.NewAuthenticatedClient(cfg))
 
 	// Add a small delay to ensure any previous operations are complete
 	time.Sleep(2 * time.Second)
@@ -32,7 +33,7 @@ func testPatchJob(cfg *config.Config, testJob TestJob) {
 		JobImage:  &newImage,
 	}
 
-	operation, err := jobs.PatchJob(cfg.ProjectID, testJob.Name, patchRequest1)
+	operation, err := jobs.PatchJob(context.Background(), cfg.ProjectID, testJob.Name, patchRequest1)
 	if err != nil {
 		logErrorWithRequestBody("Warning: Patch job with new image failed", err, patchRequest1)
 	} else {
@@ -52,7 +53,7 @@ func testPatchJob(cfg *config.Config, testJob TestJob) {
 		JobCPU:    &newCPU,
 	}
 
-	operation, err = jobs.PatchJob(cfg.ProjectID, testJob.Name, patchRequest2)
+	operation, err = jobs.PatchJob(context.Background(), cfg.ProjectID, testJob.Name, patchRequest2)
 	if err != nil {
 		logErrorWithRequestBody("Warning: Patch job with new CPU failed", err, patchRequest2)
 	} else {
@@ -72,7 +73,7 @@ func testPatchJob(cfg *config.Config, testJob TestJob) {
 		JobDescription: &newDescription,
 	}
 
-	operation, err = jobs.PatchJob(cfg.ProjectID, testJob.Name, patchRequest3)
+	operation, err = jobs.PatchJob(context.Background(), cfg.ProjectID, testJob.Name, patchRequest3)
 	if err != nil {
 		logErrorWithRequestBody("Warning: Patch job with new description failed", err, patchRequest3)
 	} else {
@@ -92,7 +93,7 @@ func testPatchJob(cfg *config.Config, testJob TestJob) {
 		JobEnvironmentVariables: &newEnvVars,
 	}
 
-	operation, err = jobs.PatchJob(cfg.ProjectID, testJob.Name, patchRequest4)
+	operation, err = jobs.PatchJob(context.Background(), cfg.ProjectID, testJob.Name, patchRequest4)
 	if err != nil {
 		logErrorWithRequestBody("Warning: Patch job with new environment variables failed", err, patchRequest4)
 	} else {
@@ -112,7 +113,7 @@ func testPatchJob(cfg *config.Config, testJob TestJob) {
 		JobCommand: newCommand,
 	}
 
-	operation, err = jobs.PatchJob(cfg.ProjectID, testJob.Name, patchRequest5)
+	operation, err = jobs.PatchJob(context.Background(), cfg.ProjectID, testJob.Name, patchRequest5)
 	if err != nil {
 		logErrorWithRequestBody("Warning: Patch job with new command failed", err, patchRequest5)
 	} else {
@@ -132,7 +133,7 @@ func testPatchJob(cfg *config.Config, testJob TestJob) {
 		JobArgs:   newArgs,
 	}
 
-	operation, err = jobs.PatchJob(cfg.ProjectID, testJob.Name, patchRequest6)
+	operation, err = jobs.PatchJob(context.Background(), cfg.ProjectID, testJob.Name, patchRequest6)
 	if err != nil {
 		logErrorWithRequestBody("Warning: Patch job with new args failed", err, patchRequest6)
 	} else {
@@ -152,7 +153,7 @@ func testPatchJob(cfg *config.Config, testJob TestJob) {
 		JobRetryCount: &newRetryCount,
 	}
 
-	operation, err = jobs.PatchJob(cfg.ProjectID, testJob.Name, patchRequest7)
+	operation, err = jobs.PatchJob(context.Background(), cfg.ProjectID, testJob.Name, patchRequest7)
 	if err != nil {
 		logErrorWithRequestBody("Warning: Patch job with new retry count failed", err, patchRequest7)
 	} else {
@@ -172,7 +173,7 @@ func testPatchJob(cfg *config.Config, testJob TestJob) {
 		JobExecutionTimeout: &newExecutionTimeout,
 	}
 
-	operation, err = jobs.PatchJob(cfg.ProjectID, testJob.Name, patchRequest8)
+	operation, err = jobs.PatchJob(context.Background(), cfg.ProjectID, testJob.Name, patchRequest8)
 	if err != nil {
 		logErrorWithRequestBody("Warning: Patch job with new execution timeout failed", err, patchRequest8)
 	} else {
@@ -192,7 +193,7 @@ func testPatchJob(cfg *config.Config, testJob TestJob) {
 		JobPrivileged: &newPrivileged,
 	}
 
-	operation, err = jobs.PatchJob(cfg.ProjectID, testJob.Name, patchRequest9)
+	operation, err = jobs.PatchJob(context.Background(), cfg.ProjectID, testJob.Name, patchRequest9)
 	if err != nil {
 		logErrorWithRequestBody("Warning: Patch job with new privileged setting failed", err, patchRequest9)
 	} else {
@@ -216,7 +217,7 @@ func testPatchJob(cfg *config.Config, testJob TestJob) {
 		JobDescription: &multiDescription,
 	}
 
-	operation, err = jobs.PatchJob(cfg.ProjectID, testJob.Name, multiRequest)
+	operation, err = jobs.PatchJob(context.Background(), cfg.ProjectID, testJob.Name, multiRequest)
 	if err != nil {
 		logErrorWithRequestBody("Warning: Patch job with multiple parameters failed", err, multiRequest)
 	} else {
@@ -236,7 +237,7 @@ func testPatchJob(cfg *config.Config, testJob TestJob) {
 		JobRunImmediately: &newRunImmediately,
 	}
 
-	operation, err = jobs.PatchJob(cfg.ProjectID, testJob.Name, patchRequest11)
+	operation, err = jobs.PatchJob(context.Background(), cfg.ProjectID, testJob.Name, patchRequest11)
 	if err != nil {
 		logErrorWithRequestBody("Warning: Patch job with run immediately failed", err, patchRequest11)
 	} else {
@@ -1,12 +1,9 @@
 package cloudru
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
-	"io"
-	"log"
-	"net/http"
-	"strings"
 
 	"github.com/Nick1994209/cloudru-containerapps-mcp/internal/config"
 	"github.com/Nick1994209/cloudru-containerapps-mcp/internal/domain"
@@ -14,52 +11,25 @@ import (
 
 // ArtifactRegistryApplication implements the ArtifactRegistryService interface
 type ArtifactRegistryApplication struct {
-	authService domain.AuthService
-	cfg         *config.Config
+	client *Client
+	cfg    *config.Config
 }
 
 // NewArtifactRegistryApplication creates a new ArtifactRegistryApplication
-func NewArtifactRegistryApplication(cfg *config.Config) domain.ArtifactRegistryService {
+func NewArtifactRegistryApplication(cfg *config.Config, client *Client) domain.ArtifactRegistryService {
 	return &ArtifactRegistryApplication{
-		authService: NewAuthApplication(cfg),
-		cfg:         cfg,
+		client: client,
+		cfg:    cfg,
 	}
 }
 
 // GetListDockerRegistries gets a list of Docker Registries from Cloud.ru API
-func (d *ArtifactRegistryApplication) GetListDockerRegistries(projectID string) ([]domain.DockerRegistry, error) {
-	token, err := d.authService.GetAccessToken()
-	if err != nil {
-		return nil, fmt.Errorf("failed to get access token: %w", err)
-	}
-
+func (d *ArtifactRegistryApplication) GetListDockerRegistries(ctx context.Context, projectID string) ([]domain.DockerRegistry, error) {
 	// Make request to Docker Registries API
 	url := fmt.Sprintf("%s/v1/registries?projectId=%s", d.cfg.API.ArtifactAPI, projectID)
-	req, err := http.NewRequest("GET", url, nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
-	}
-
-	req.Header.Set("Authorization", "Bearer "+token)
-	req.Header.Set("Content-Type", "application/json")
-
-	client := &http.Client{}
-	resp, err := client.Do(req)
+	body, err := d.client.Do(ctx, "GET", url, nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to make request: %w", err)
-	}
-	defer resp.Body.Close()
-
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read response body: %w", err)
-	}
-
-	// Log the response for debugging
-	log.Printf("GetListDockerRegistries response - Status: %d, Body length: %d, Body: %s", resp.StatusCode, len(body), string(body))
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(body))
+		return nil, err
 	}
 
 	// Check if body is empty
@@ -88,12 +58,7 @@ func (d *ArtifactRegistryApplication) GetListDockerRegistries(projectID string)
 }
 
 // CreateDockerRegistry creates a new Docker Registry in Cloud.ru
-func (d *ArtifactRegistryApplication) CreateDockerRegistry(projectID string, registryName string, isPublic bool) (*domain.DockerRegistry, error) {
-	token, err := d.authService.GetAccessToken()
-	if err != nil {
-		return nil, fmt.Errorf("failed to get access token: %w", err)
-	}
-
+func (d *ArtifactRegistryApplication) CreateDockerRegistry(ctx context.Context, projectID string, registryName string, isPublic bool) (*domain.DockerRegistry, error) {
 	// Prepare the request payload
 	payload := map[string]interface{}{
 		"projectId":    projectID,
@@ -110,36 +75,23 @@ func (d *ArtifactRegistryApplication) CreateDockerRegistry(projectID string, reg
 
 	// Make request to Docker Registries API
 	url := fmt.Sprintf("%s/v1/registries", d.cfg.API.ArtifactAPI)
-	req, err := http.NewRequest("POST", url, strings.NewReader(string(jsonPayload)))
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+	// Record the request instead of sending it in dry-run mode
+	if err := checkDryRun(ctx, "POST", url, jsonPayload, nil); err != nil {
+		return nil, err
 	}
-
-	req.Header.Set("Authorization", "Bearer "+token)
-	req.Header.Set("Content-Type", "application/json")
-
-	client := &http.Client{}
-	resp, err := client.Do(req)
+	body, err := d.client.Do(ctx, "POST", url, jsonPayload)
 	if err != nil {
-		return nil, fmt.Errorf("failed to make request: %w", err)
-	}
-	defer resp.Body.Close()
-
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read response body: %w", err)
-	}
-
-	// Log the response for debugging
-	log.Printf("CreateDockerRegistry response - Status: %d, Body length: %d, Body: %s", resp.StatusCode, len(body), string(body))
-
-	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(body))
+		return nil, err
 	}
 
 	// Check if body is empty
 	if len(body) == 0 {
-		return nil, fmt.Errorf("API returned empty response body with status %d", resp.StatusCode)
+		return nil, fmt.Errorf("API returned empty response body")
+	}
+
+	// The API may create the registry asynchronously and return its operation
+	if operation := responseOperation(body); operation != nil {
+		return &domain.DockerRegistry{ID: operation.ResourceID, Name: registryName, IsPublic: isPublic, Operation: operation}, nil
 	}
 
 	// Parse response
@@ -1,12 +1,10 @@
 package cloudru
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
-	"io"
 	"log"
-	"net/http"
-	"strings"
 	"sync"
 	"time"
 
@@ -18,6 +16,7 @@ import (
 type AuthApplication struct {
 	creds       domain.Credentials
 	cfg         *config.Config
+	client      *Client
 	cachedToken string
 	tokenExpiry time.Time
 	mu          sync.RWMutex
@@ -30,7 +29,8 @@ func NewAuthApplication(cfg *config.Config) domain.AuthService {
 			KeyID:     cfg.KeyID,
 			KeySecret: cfg.KeySecret,
 		},
-		cfg: cfg,
+		cfg:    cfg,
+		client: NewClient(cfg, nil),
 	}
 }
 
@@ -39,7 +39,7 @@ func NewAuthApplication(cfg *config.Config) domain.AuthService {
 // - CLOUDRU_KEY_ID: Service account key ID
 // - CLOUDRU_KEY_SECRET: Service account key secret
 // The token is cached for 5 minutes to reduce API calls
-func (a *AuthApplication) GetAccessToken() (string, error) {
+func (a *AuthApplication) GetAccessToken(ctx context.Context) (string, error) {
 	// Check if we have a valid cached token
 	a.mu.RLock()
 	if a.cachedToken != "" && time.Now().Before(a.tokenExpiry) {
@@ -56,37 +56,22 @@ func (a *AuthApplication) GetAccessToken() (string, error) {
 
 	url := fmt.Sprintf("%s/api/v1/auth/token", a.cfg.API.IAMAPI)
 
-	payload := strings.NewReader(fmt.Sprintf(`{"keyId": "%s","secret": "%s"}`, a.creds.KeyID, a.creds.KeySecret))
-
-	req, err := http.NewRequest("POST", url, payload)
-	if err != nil {
-		return "", fmt.Errorf("failed to create request: %w", err)
-	}
-
-	req.Header.Set("Content-Type", "application/json")
-
-	client := &http.Client{}
-	resp, err := client.Do(req)
+	payload, err := json.Marshal(map[string]string{
+		"keyId":  a.creds.KeyID,
+		"secret": a.creds.KeySecret,
+	})
 	if err != nil {
-		return "", fmt.Errorf("failed to make request: %w", err)
+		return "", fmt.Errorf("failed to marshal authentication payload: %w", err)
 	}
-	defer resp.Body.Close()
 
-	body, err := io.ReadAll(resp.Body)
+	body, err := a.client.Do(ctx, "POST", url, payload)
 	if err != nil {
-		return "", fmt.Errorf("failed to read authentication response body: %w", err)
-	}
-
-	// Log the response for debugging
-	log.Printf("GetAccessToken response - Status: %d", resp.StatusCode)
-
-	if resp.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("authentication failed with status %d: %s", resp.StatusCode, string(body))
+		return "", fmt.Errorf("authentication failed: %w", err)
 	}
 
 	// Check if body is empty
 	if len(body) == 0 {
-		return "", fmt.Errorf("authentication API returned empty response body with status %d", resp.StatusCode)
+		return "", fmt.Errorf("authentication API returned empty response body")
 	}
 
 	// Parse response to get token
@@ -0,0 +1,183 @@
+package cloudru
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Nick1994209/cloudru-containerapps-mcp/internal/config"
+	"github.com/Nick1994209/cloudru-containerapps-mcp/internal/domain"
+	"github.com/Nick1994209/cloudru-containerapps-mcp/internal/version"
+)
+
+// APIError is returned by Client when Cloud.ru API responds with a non-2xx status
+type APIError struct {
+	Method     string
+	URL        string
+	StatusCode int
+	Code       string
+	Message    string
+	RequestID  string
+	RetryAfter string
+	Body       string
+}
+
+// Error implements the error interface
+func (e *APIError) Error() string {
+	var details []string
+	if e.Code != "" {
+		details = append(details, "code: "+e.Code)
+	}
+	if e.RequestID != "" {
+		details = append(details, "request id: "+e.RequestID)
+	}
+
+	message := fmt.Sprintf("API request failed with status %d", e.StatusCode)
+	if len(details) > 0 {
+		message = fmt.Sprintf("%s (%s)", message, strings.Join(details, ", "))
+	}
+	return fmt.Sprintf("%s: %s", message, e.Body)
+}
+
+// Is makes errors.Is(err, domain.ErrNotFound) true for 404 responses
+func (e *APIError) Is(target error) bool {
+	return target == domain.ErrNotFound && e.StatusCode == http.StatusNotFound
+}
+
+// IsNotFound reports whether err is an APIError with 404 status
+func IsNotFound(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound
+}
+
+// Client is a shared HTTP client for Cloud.ru APIs. It authenticates requests,
+// sets common headers, retries transient failures, logs responses and converts failed responses to APIError
+type Client struct {
+	httpClient  *http.Client
+	authService domain.AuthService
+	retryPolicy RetryPolicy
+	userAgent   string
+}
+
+// NewClient creates a new Client. If authService is nil, requests are sent without Authorization header
+func NewClient(cfg *config.Config, authService domain.AuthService) *Client {
+	return &Client{
+		httpClient: &http.Client{
+			Timeout: cfg.API.Timeout,
+		},
+		authService: authService,
+		retryPolicy: NewRetryPolicy(cfg.API.Retry),
+		userAgent:   "cloudru-containerapps-mcp/" + version.GetVersion(),
+	}
+}
+
+// NewAuthenticatedClient creates a Client which authenticates requests with the access keys of cfg.
+// One client is shared by all services, so they share the connection pool and the cached access token
+func NewAuthenticatedClient(cfg *config.Config) *Client {
+	return NewClient(cfg, NewAuthApplication(cfg))
+}
+
+// Do sends a request with optional JSON body to url and returns the response body of a successful (2xx) response.
+// Transient failures are retried according to the client retry policy
+func (c *Client) Do(ctx context.Context, method, url string, body []byte) ([]byte, error) {
+	for attempt := 1; ; attempt++ {
+		responseBody, err := c.doOnce(ctx, method, url, body)
+		if err == nil {
+			return responseBody, nil
+		}
+		if !c.retryPolicy.shouldRetry(ctx, method, attempt, err) {
+			return nil, err
+		}
+
+		var retryAfter string
+		var apiErr *APIError
+		if errors.As(err, &apiErr) {
+			retryAfter = apiErr.RetryAfter
+		}
+		delay := c.retryPolicy.delay(attempt, retryAfter)
+		log.Printf("%s %s - attempt %d/%d failed, retrying in %s: %v", method, url, attempt, c.retryPolicy.MaxAttempts, delay, err)
+
+		if sleepErr := sleepContext(ctx, delay); sleepErr != nil {
+			return nil, err
+		}
+	}
+}
+
+// doOnce performs a single HTTP request attempt
+func (c *Client) doOnce(ctx context.Context, method, url string, body []byte) ([]byte, error) {
+	var bodyReader io.Reader
+	if body != nil {
+		bodyReader = bytes.NewReader(body)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, url, bodyReader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	if c.authService != nil {
+		token, err := c.authService.GetAccessToken(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get access token: %w", err)
+		}
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", c.userAgent)
+
+	startedAt := time.Now()
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to make request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	responseBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	// Log the response for debugging
+	log.Printf("%s %s - Status: %d, Body length: %d, Duration: %s", method, req.URL.Path, resp.StatusCode, len(responseBody), time.Since(startedAt).Round(time.Millisecond))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, newAPIError(req, resp, responseBody)
+	}
+
+	return responseBody, nil
+}
+
+// newAPIError builds an APIError from a failed response, extracting Cloud.ru error details when the body contains them
+func newAPIError(req *http.Request, resp *http.Response, body []byte) *APIError {
+	apiErr := &APIError{
+		Method:     req.Method,
+		URL:        req.URL.String(),
+		StatusCode: resp.StatusCode,
+		RequestID:  resp.Header.Get("X-Request-Id"),
+		RetryAfter: resp.Header.Get("Retry-After"),
+		Body:       string(body),
+	}
+
+	var errorBody struct {
+		Code      json.RawMessage `json:"code"`
+		Message   string          `json:"message"`
+		RequestID string          `json:"requestId"`
+	}
+	if err := json.Unmarshal(body, &errorBody); err == nil {
+		apiErr.Code = strings.Trim(string(errorBody.Code), `"`)
+		apiErr.Message = errorBody.Message
+		if apiErr.RequestID == "" {
+			apiErr.RequestID = errorBody.RequestID
+		}
+	}
+
+	return apiErr
+}
@@ -0,0 +1,187 @@
+package cloudru
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Nick1994209/cloudru-containerapps-mcp/internal/config"
+	"github.com/Nick1994209/cloudru-containerapps-mcp/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestClient creates a Client without authentication and with short retry delays
+func newTestClient(maxAttempts int) *Client {
+	return NewClient(&config.Config{
+		API: config.APIURLs{
+			Timeout: 5 * time.Second,
+			Retry: config.RetryConfig{
+				MaxAttempts: maxAttempts,
+				BaseDelay:   time.Millisecond,
+				MaxDelay:    10 * time.Millisecond,
+			},
+		},
+	}, nil)
+}
+
+// newFlakyServer responds with failStatus for the first failures requests and with 200 afterwards
+func newFlakyServer(t *testing.T, failures int32, failStatus int, header http.Header) (*httptest.Server, *int32) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		call := atomic.AddInt32(&calls, 1)
+		if call <= failures {
+			for key, values := range header {
+				for _, value := range values {
+					w.Header().Add(key, value)
+				}
+			}
+			w.WriteHeader(failStatus)
+			_, _ = w.Write([]byte(`{"code":"unavailable","message":"try later"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"ok":true}`))
+	}))
+	t.Cleanup(server.Close)
+	return server, &calls
+}
+
+func TestClient_Do_RetriesIdempotentRequestOnTransientStatus(t *testing.T) {
+	for _, status := range []int{http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout} {
+		t.Run(http.StatusText(status), func(t *testing.T) {
+			server, calls := newFlakyServer(t, 2, status, nil)
+
+			body, err := newTestClient(3).Do(context.Background(), http.MethodGet, server.URL, nil)
+
+			require.NoError(t, err)
+			assert.Equal(t, `{"ok":true}`, string(body))
+			assert.Equal(t, int32(3), atomic.LoadInt32(calls))
+		})
+	}
+}
+
+func TestClient_Do_GivesUpAfterMaxAttempts(t *testing.T) {
+	server, calls := newFlakyServer(t, 10, http.StatusServiceUnavailable, http.Header{"X-Request-Id": {"req-42"}})
+
+	_, err := newTestClient(3).Do(context.Background(), http.MethodGet, server.URL, nil)
+
+	var apiErr *APIError
+	require.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, http.StatusServiceUnavailable, apiErr.StatusCode)
+	assert.Equal(t, "unavailable", apiErr.Code)
+	assert.Equal(t, "try later", apiErr.Message)
+	assert.Equal(t, "req-42", apiErr.RequestID)
+	assert.Equal(t, int32(3), atomic.LoadInt32(calls))
+}
+
+func TestClient_Do_DoesNotRetryNonIdempotentRequestOnGatewayError(t *testing.T) {
+	for _, method := range []string{http.MethodPost, http.MethodPatch} {
+		t.Run(method, func(t *testing.T) {
+			server, calls := newFlakyServer(t, 1, http.StatusServiceUnavailable, nil)
+
+			_, err := newTestClient(3).Do(context.Background(), method, server.URL, []byte(`{}`))
+
+			require.Error(t, err)
+			assert.Equal(t, int32(1), atomic.LoadInt32(calls))
+		})
+	}
+}
+
+func TestClient_Do_RetriesNonIdempotentRequestOnTooManyRequests(t *testing.T) {
+	server, calls := newFlakyServer(t, 1, http.StatusTooManyRequests, nil)
+
+	_, err := newTestClient(3).Do(context.Background(), http.MethodPost, server.URL, []byte(`{}`))
+
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), atomic.LoadInt32(calls))
+}
+
+func TestClient_Do_DoesNotRetryClientErrors(t *testing.T) {
+	server, calls := newFlakyServer(t, 1, http.StatusNotFound, nil)
+
+	_, err := newTestClient(3).Do(context.Background(), http.MethodGet, server.URL, nil)
+
+	assert.True(t, IsNotFound(err))
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+	assert.Equal(t, int32(1), atomic.LoadInt32(calls))
+}
+
+func TestClient_Do_HonoursRetryAfter(t *testing.T) {
+	server, calls := newFlakyServer(t, 1, http.StatusTooManyRequests, http.Header{"Retry-After": {"0"}})
+
+	startedAt := time.Now()
+	_, err := newTestClient(2).Do(context.Background(), http.MethodGet, server.URL, nil)
+
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), atomic.LoadInt32(calls))
+	assert.Less(t, time.Since(startedAt), time.Second)
+}
+
+func TestClient_Do_StopsRetryingWhenContextIsCancelled(t *testing.T) {
+	server, calls := newFlakyServer(t, 10, http.StatusServiceUnavailable, http.Header{"Retry-After": {"60"}})
+	client := newTestClient(5)
+	client.retryPolicy.MaxDelay = time.Minute
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err := client.Do(ctx, http.MethodGet, server.URL, nil)
+
+	require.Error(t, err)
+	assert.Equal(t, int32(1), atomic.LoadInt32(calls))
+}
+
+func TestClient_Do_SetsHeaders(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Contains(t, r.Header.Get("User-Agent"), "cloudru-containerapps-mcp/")
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		assert.Empty(t, r.Header.Get("Authorization"))
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	_, err := newTestClient(1).Do(context.Background(), http.MethodGet, server.URL, nil)
+	require.NoError(t, err)
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		value    string
+		expected time.Duration
+		ok       bool
+	}{
+		{name: "empty", value: "", ok: false},
+		{name: "seconds", value: "5", expected: 5 * time.Second, ok: true},
+		{name: "negative seconds", value: "-1", ok: false},
+		{name: "http date", value: "Wed, 01 Jan 2025 12:00:30 GMT", expected: 30 * time.Second, ok: true},
+		{name: "past http date", value: "Wed, 01 Jan 2025 11:00:00 GMT", expected: 0, ok: true},
+		{name: "garbage", value: "soon", ok: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wait, ok := parseRetryAfter(tt.value, now)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.expected, wait)
+		})
+	}
+}
+
+func TestRetryPolicy_Delay(t *testing.T) {
+	policy := RetryPolicy{MaxAttempts: 5, BaseDelay: 100 * time.Millisecond, MaxDelay: time.Second}
+
+	for attempt := 1; attempt <= 5; attempt++ {
+		delay := policy.delay(attempt, "")
+		assert.LessOrEqual(t, delay, time.Second)
+		assert.Greater(t, delay, time.Duration(0))
+	}
+
+	assert.Equal(t, time.Second, policy.delay(1, "120"), "Retry-After is capped by MaxDelay")
+	assert.Equal(t, 0*time.Second, policy.delay(1, "0"))
+}
@@ -2,10 +2,11 @@ package cloudru
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
-	"io"
-	"net/http"
+	"net/url"
+	"time"
 
 	"github.com/Nick1994209/cloudru-containerapps-mcp/internal/config"
 	"github.com/Nick1994209/cloudru-containerapps-mcp/internal/domain"
@@ -14,85 +15,51 @@ import (
 
 // ContainerAppsApplication implements the ContainerAppsService interface
 type ContainerAppsApplication struct {
-	authService domain.AuthService
-	cfg         *config.Config
+	client *Client
+	cfg    *config.Config
 }
 
 // NewContainerAppsApplication creates a new ContainerAppsApplication
-func NewContainerAppsApplication(cfg *config.Config) domain.ContainerAppsService {
+func NewContainerAppsApplication(cfg *config.Config, client *Client) domain.ContainerAppsService {
 	return &ContainerAppsApplication{
-		authService: NewAuthApplication(cfg),
-		cfg:         cfg,
+		client: client,
+		cfg:    cfg,
 	}
 }
 
-// makeHTTPRequest makes an HTTP request to the Cloud.ru API
-func (c *ContainerAppsApplication) makeHTTPRequest(method, path string, body []byte) ([]byte, error) {
-	token, err := c.authService.GetAccessToken()
-	if err != nil {
-		return nil, fmt.Errorf("failed to get access token: %w", err)
-	}
-
-	var bodyReader io.Reader
-	if body != nil {
-		bodyReader = bytes.NewBuffer(body)
-	}
-
-	url := c.cfg.API.ContainersAPI + path
-	req, err := http.NewRequest(method, url, bodyReader)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
-	}
-
-	req.Header.Set("Authorization", "Bearer "+token)
-	req.Header.Set("Content-Type", "application/json")
-
-	client := &http.Client{}
-	resp, err := client.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("failed to make request: %w", err)
-	}
-	defer resp.Body.Close()
-
-	responseBody, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read response body: %w", err)
-	}
-
-	// Check if status code is 200
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(responseBody))
-	}
+// GetListContainerApps gets a page of ContainerApps from Cloud.ru API, or all pages in fetch-all mode
+func (c *ContainerAppsApplication) GetListContainerApps(ctx context.Context, projectID string, options domain.ListOptions) (*domain.ContainerAppsPage, error) {
+	result := &domain.ContainerAppsPage{Data: []domain.ContainerApp{}}
+	nextPageToken, err := fetchPages(options, func(pageToken string) (string, error) {
+		// Make request to ContainerApps API
+		path := "/v1/containers?" + listQuery(projectID, options, pageToken)
+		body, err := c.client.Do(ctx, "GET", c.cfg.API.ContainersAPI+path, nil)
+		if err != nil {
+			return "", err
+		}
 
-	return responseBody, nil
-}
+		// Parse response as a wrapper object containing a slice of ContainerApp
+		var response domain.ContainerAppsPage
+		if err := json.Unmarshal(body, &response); err != nil {
+			return "", fmt.Errorf("failed to parse containerapps response: %w body length: %d body: %s", err, len(body), string(body))
+		}
+		result.Data = append(result.Data, response.Data...)
 
-// GetListContainerApps gets a list of ContainerApps from Cloud.ru API
-func (c *ContainerAppsApplication) GetListContainerApps(projectID string) ([]domain.ContainerApp, error) {
-	// Make request to ContainerApps API
-	path := fmt.Sprintf("/v1/containers?projectId=%s", projectID)
-	body, err := c.makeHTTPRequest("GET", path, nil)
+		return response.NextPageToken, nil
+	})
 	if err != nil {
 		return nil, err
 	}
+	result.NextPageToken = nextPageToken
 
-	// Parse response as a wrapper object containing a slice of ContainerApp
-	var response struct {
-		Data []domain.ContainerApp `json:"data"`
-	}
-	if err := json.Unmarshal(body, &response); err != nil {
-		return nil, fmt.Errorf("failed to parse containerapps response: %w body length: %d body: %s", err, len(body), string(body))
-	}
-	containerApps := response.Data
-
-	return containerApps, nil
+	return result, nil
 }
 
 // getContainerAppRaw gets the raw response body from the ContainerApps API
-func (c *ContainerAppsApplication) getContainerAppRaw(projectID string, containerAppName string) ([]byte, error) {
+func (c *ContainerAppsApplication) getContainerAppRaw(ctx context.Context, projectID string, containerAppName string) ([]byte, error) {
 	// Make request to ContainerApps API
 	path := fmt.Sprintf("/v1/containers/%s?projectId=%s", containerAppName, projectID)
-	body, err := c.makeHTTPRequest("GET", path, nil)
+	body, err := c.client.Do(ctx, "GET", c.cfg.API.ContainersAPI+path, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -105,10 +72,15 @@ func (c *ContainerAppsApplication) getContainerAppRaw(projectID string, containe
 	return body, nil
 }
 
+// GetContainerAppRaw gets a specific ContainerApp from Cloud.ru API as is, including fields which are not mapped to domain.ContainerApp
+func (c *ContainerAppsApplication) GetContainerAppRaw(ctx context.Context, projectID string, containerAppName string) ([]byte, error) {
+	return c.getContainerAppRaw(ctx, projectID, containerAppName)
+}
+
 // GetContainerApp gets a specific ContainerApp from Cloud.ru API
-func (c *ContainerAppsApplication) GetContainerApp(projectID string, containerAppName string) (*domain.ContainerApp, error) {
+func (c *ContainerAppsApplication) GetContainerApp(ctx context.Context, projectID string, containerAppName string) (*domain.ContainerApp, error) {
 	// Get the raw response body
-	rawBody, err := c.getContainerAppRaw(projectID, containerAppName)
+	rawBody, err := c.getContainerAppRaw(ctx, projectID, containerAppName)
 	if err != nil {
 		return nil, err
 	}
@@ -123,7 +95,7 @@ func (c *ContainerAppsApplication) GetContainerApp(projectID string, containerAp
 }
 
 // CreateContainerApp creates a new ContainerApp in Cloud.ru
-func (c *ContainerAppsApplication) CreateContainerApp(request domain.CreateContainerAppRequest) (*domain.Operation, error) {
+func (c *ContainerAppsApplication) CreateContainerApp(ctx context.Context, request domain.CreateContainerAppRequest) (*domain.Operation, error) {
 	projectID := request.ProjectID
 	containerAppName := request.ContainerAppName
 	containerAppPort := request.ContainerAppPort
@@ -134,6 +106,7 @@ func (c *ContainerAppsApplication) CreateContainerApp(request domain.CreateConta
 	idleTimeout := request.IdleTimeout
 	timeout := request.Timeout
 	cpu := request.CPU
+	memory := request.Memory
 	minInstanceCount := request.MinInstanceCount
 	maxInstanceCount := request.MaxInstanceCount
 	description := request.Description
@@ -158,10 +131,16 @@ func (c *ContainerAppsApplication) CreateContainerApp(request domain.CreateConta
 	}
 
 	// Parse environment variables
-	envVars := utils.ParseEnvironmentVariables(environmentVariables)
+	envVars, err := utils.ParseEnvironmentVariables(environmentVariables)
+	if err != nil {
+		return nil, err
+	}
 
-	// Map CPU to memory
-	cpu, memory := utils.ParseCPU(cpu)
+	// Validate the CPU/memory pair
+	cpu, memory, err = utils.ParseResources(cpu, memory)
+	if err != nil {
+		return nil, err
+	}
 
 	// Prepare the request payload
 	payload := map[string]interface{}{
@@ -209,6 +188,33 @@ func (c *ContainerAppsApplication) CreateContainerApp(request domain.CreateConta
 		payload["template"].(map[string]interface{})["containers"].([]map[string]interface{})[0]["args"] = args
 	}
 
+	// Add sidecars and init containers if provided
+	template := payload["template"].(map[string]interface{})
+	if request.Sidecars != "" || request.InitContainers != "" {
+		if err := addCreateContainers(template, request.Sidecars, request.InitContainers); err != nil {
+			return nil, err
+		}
+	}
+
+	// Add volumes and volume mounts of the main container if provided
+	if request.Volumes != "" {
+		volumes, err := parseVolumePayloads(request.Volumes)
+		if err != nil {
+			return nil, fmt.Errorf("invalid volumes: %w", err)
+		}
+		template["volumes"] = volumes
+	}
+	if request.VolumeMounts != "" {
+		mounts, err := utils.ParseVolumeMounts(request.VolumeMounts)
+		if err != nil {
+			return nil, fmt.Errorf("invalid volume mounts: %w", err)
+		}
+		templateItems(template, "containers")[0].(map[string]interface{})["volumeMounts"] = mounts
+	}
+	if err := checkVolumeMounts(template); err != nil {
+		return nil, err
+	}
+
 	// Convert payload to JSON
 	jsonPayload, err := json.Marshal(payload)
 	if err != nil {
@@ -217,7 +223,11 @@ func (c *ContainerAppsApplication) CreateContainerApp(request domain.CreateConta
 
 	// Make request to ContainerApps API
 	path := "/v2/containers/"
-	body, err := c.makeHTTPRequest("POST", path, jsonPayload)
+	// Record the request instead of sending it in dry-run mode
+	if err := checkDryRun(ctx, "POST", c.cfg.API.ContainersAPI+path, jsonPayload, nil); err != nil {
+		return nil, err
+	}
+	body, err := c.client.Do(ctx, "POST", c.cfg.API.ContainersAPI+path, jsonPayload)
 	if err != nil {
 		return nil, err
 	}
@@ -236,12 +246,60 @@ func (c *ContainerAppsApplication) CreateContainerApp(request domain.CreateConta
 	return &response, nil
 }
 
+// CreateContainerAppFromObject creates a ContainerApp in the project from a raw object without server-managed fields,
+// e.g. a copy of another ContainerApp
+func (c *ContainerAppsApplication) CreateContainerAppFromObject(ctx context.Context, projectID string, object []byte) (*domain.Operation, error) {
+	var payload map[string]interface{}
+	if err := json.Unmarshal(object, &payload); err != nil {
+		return nil, fmt.Errorf("failed to parse container app object: %w", err)
+	}
+	payload["projectId"] = projectID
+
+	// Convert payload to JSON
+	jsonPayload, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal payload: %w", err)
+	}
+
+	// Make request to ContainerApps API
+	path := "/v2/containers/"
+	// Record the request instead of sending it in dry-run mode
+	if err := checkDryRun(ctx, "POST", c.cfg.API.ContainersAPI+path, jsonPayload, nil); err != nil {
+		return nil, err
+	}
+	body, err := c.client.Do(ctx, "POST", c.cfg.API.ContainersAPI+path, jsonPayload)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check if body is empty
+	if len(body) == 0 {
+		return nil, fmt.Errorf("API returned empty response body")
+	}
+
+	// Create and return an Operation object by parsing the response body
+	var response domain.Operation
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, fmt.Errorf("failed to parse container app operation response for '%v': %w body length: %d body: %s", payload["name"], err, len(body), string(body))
+	}
+
+	return &response, nil
+}
+
 // DeleteContainerApp deletes a ContainerApp from Cloud.ru
-func (c *ContainerAppsApplication) DeleteContainerApp(projectID string, containerAppName string) (*domain.Operation, error) {
+func (c *ContainerAppsApplication) DeleteContainerApp(ctx context.Context, projectID string, containerAppName string) (*domain.Operation, error) {
 	// Make DELETE request to ContainerApps API
 	// According to the API documentation: DELETE https://containers.api.cloud.ru/v2/containers/<containerapp_name>
 	path := fmt.Sprintf("/v2/containers/%s?projectId=%s", containerAppName, projectID)
-	body, err := c.makeHTTPRequest("DELETE", path, nil)
+	// Record the request with the current state instead of sending it in dry-run mode
+	current, err := dryRunCurrent(ctx, c.getContainerAppRaw, projectID, containerAppName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current container app state for '%s': %w", containerAppName, err)
+	}
+	if err := checkDryRun(ctx, "DELETE", c.cfg.API.ContainersAPI+path, nil, current); err != nil {
+		return nil, err
+	}
+	body, err := c.client.Do(ctx, "DELETE", c.cfg.API.ContainersAPI+path, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -256,11 +314,19 @@ func (c *ContainerAppsApplication) DeleteContainerApp(projectID string, containe
 }
 
 // StartContainerApp starts a ContainerApp in Cloud.ru
-func (c *ContainerAppsApplication) StartContainerApp(projectID string, containerAppName string) (*domain.Operation, error) {
+func (c *ContainerAppsApplication) StartContainerApp(ctx context.Context, projectID string, containerAppName string) (*domain.Operation, error) {
 	// Make POST request to ContainerApps API to start the container app
 	// According to the API documentation: POST https://containers.api.cloud.ru/v2/containers/<containerapp_name>:start
 	path := fmt.Sprintf("/v2/containers/%s:start?projectId=%s", containerAppName, projectID)
-	body, err := c.makeHTTPRequest("POST", path, nil)
+	// Record the request with the current state instead of sending it in dry-run mode
+	current, err := dryRunCurrent(ctx, c.getContainerAppRaw, projectID, containerAppName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current container app state for '%s': %w", containerAppName, err)
+	}
+	if err := checkDryRun(ctx, "POST", c.cfg.API.ContainersAPI+path, nil, current); err != nil {
+		return nil, err
+	}
+	body, err := c.client.Do(ctx, "POST", c.cfg.API.ContainersAPI+path, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -275,11 +341,19 @@ func (c *ContainerAppsApplication) StartContainerApp(projectID string, container
 }
 
 // StopContainerApp stops a ContainerApp in Cloud.ru
-func (c *ContainerAppsApplication) StopContainerApp(projectID string, containerAppName string) (*domain.Operation, error) {
+func (c *ContainerAppsApplication) StopContainerApp(ctx context.Context, projectID string, containerAppName string) (*domain.Operation, error) {
 	// Make POST request to ContainerApps API to stop the container app
 	// According to the API documentation: POST https://containers.api.cloud.ru/v2/containers/<containerapp_name>:stop
 	path := fmt.Sprintf("/v2/containers/%s:stop?projectId=%s", containerAppName, projectID)
-	body, err := c.makeHTTPRequest("POST", path, nil)
+	// Record the request with the current state instead of sending it in dry-run mode
+	current, err := dryRunCurrent(ctx, c.getContainerAppRaw, projectID, containerAppName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current container app state for '%s': %w", containerAppName, err)
+	}
+	if err := checkDryRun(ctx, "POST", c.cfg.API.ContainersAPI+path, nil, current); err != nil {
+		return nil, err
+	}
+	body, err := c.client.Do(ctx, "POST", c.cfg.API.ContainersAPI+path, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -292,46 +366,68 @@ func (c *ContainerAppsApplication) StopContainerApp(projectID string, containerA
 	return &response, nil
 }
 
-// GetContainerAppLogs gets logs for a specific ContainerApp from Cloud.ru API
-func (c *ContainerAppsApplication) GetContainerAppLogs(projectID string, containerAppName string) (*domain.ContainerAppLogs, error) {
-	// Make request to ContainerApps API for logs
-	path := fmt.Sprintf("/v2/containers/%s/logs?projectId=%s", containerAppName, projectID)
-	body, err := c.makeHTTPRequest("GET", path, nil)
+// GetContainerAppLogs gets logs for a specific ContainerApp from Cloud.ru API.
+// The filters of the options are applied to the entries returned by the API, the result is in chronological order
+func (c *ContainerAppsApplication) GetContainerAppLogs(ctx context.Context, projectID string, containerAppName string, options domain.LogOptions) (*domain.ContainerAppLogs, error) {
+	// Validate the filters before calling the API, relative time bounds are counted from now
+	now := time.Now()
+	filter, err := newLogFilter(options, now)
 	if err != nil {
 		return nil, err
 	}
 
-	// Parse response as a wrapper object containing a slice of ContainerAppLogEntry
-	var response domain.ContainerAppLogs
-	if err := json.Unmarshal(body, &response); err != nil {
-		return nil, fmt.Errorf("failed to parse container app logs response for '%s': %w body length: %d body: %s", containerAppName, err, len(body), string(body))
+	// Make requests to ContainerApps API for logs, older pages are fetched until since is covered
+	logsURL := fmt.Sprintf("%s/v2/containers/%s/logs", c.cfg.API.ContainersAPI, containerAppName)
+	response, err := fetchLogPages(ctx, c.client, logsURL, projectID, filter.window.since, containerAppName)
+	if err != nil {
+		return nil, err
 	}
 
-	return &response, nil
+	return filterLogs(response, options, now)
 }
 
-// GetContainerAppSystemLogs gets system logs for a specific ContainerApp from Cloud.ru API
-func (c *ContainerAppsApplication) GetContainerAppSystemLogs(projectID string, containerAppName string) (*domain.ContainerAppSystemLogs, error) {
-	// Make request to ContainerApps API for system logs
-	path := fmt.Sprintf("/v2/containers/%s/systemLogs?projectId=%s", containerAppName, projectID)
-	body, err := c.makeHTTPRequest("GET", path, nil)
+// GetContainerAppSystemLogs gets system events of a specific ContainerApp from Cloud.ru API.
+// All pages are fetched, the events are filtered by the options and failures are summarised into findings
+func (c *ContainerAppsApplication) GetContainerAppSystemLogs(ctx context.Context, projectID string, containerAppName string, options domain.SystemLogOptions) (*domain.ContainerAppSystemLogs, error) {
+	var entries []domain.ContainerAppSystemLogEntry
+	nextPageToken, err := fetchPages(domain.ListOptions{FetchAll: true}, func(pageToken string) (string, error) {
+		// Make request to ContainerApps API for system logs
+		query := url.Values{}
+		query.Set("projectId", projectID)
+		if pageToken != "" {
+			query.Set("pageToken", pageToken)
+		}
+		path := fmt.Sprintf("/v2/containers/%s/systemLogs?%s", containerAppName, query.Encode())
+		body, err := c.client.Do(ctx, "GET", c.cfg.API.ContainersAPI+path, nil)
+		if err != nil {
+			return "", err
+		}
+
+		// An app without events may return an empty body
+		if len(bytes.TrimSpace(body)) == 0 {
+			return "", nil
+		}
+
+		// Parse response as a wrapper object containing a slice of ContainerAppSystemLogEntry
+		var response domain.ContainerAppSystemLogs
+		if err := json.Unmarshal(body, &response); err != nil {
+			return "", fmt.Errorf("failed to parse container app system logs response for '%s': %w body length: %d body: %s", containerAppName, err, len(body), string(body))
+		}
+		entries = append(entries, response.Data...)
+
+		return response.NextPageToken, nil
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	// Parse response as a wrapper object containing a slice of ContainerAppSystemLogEntry
-	var response domain.ContainerAppSystemLogs
-	if err := json.Unmarshal(body, &response); err != nil {
-		return nil, fmt.Errorf("failed to parse container app system logs response for '%s': %w body length: %d body: %s", containerAppName, err, len(body), string(body))
-	}
-
-	return &response, nil
+	return newSystemLogsResult(entries, nextPageToken, options)
 }
 
 // PatchContainerApp patches a ContainerApp in Cloud.ru
-func (c *ContainerAppsApplication) PatchContainerApp(projectID string, containerAppName string, updateRequest domain.PatchContainerAppRequest) (*domain.Operation, error) {
+func (c *ContainerAppsApplication) PatchContainerApp(ctx context.Context, projectID string, containerAppName string, updateRequest domain.PatchContainerAppRequest) (*domain.Operation, error) {
 	// First, get the current container app state
-	rawBody, err := c.getContainerAppRaw(projectID, containerAppName)
+	rawBody, err := c.getContainerAppRaw(ctx, projectID, containerAppName)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get current container app state for '%s': %w", containerAppName, err)
 	}
@@ -343,17 +439,12 @@ func (c *ContainerAppsApplication) PatchContainerApp(projectID string, container
 	}
 
 	// Use environment variables directly (already parsed)
-	var envVars []map[string]interface{}
+	var envVars []domain.EnvironmentVariable
 	if updateRequest.EnvironmentVariables != nil {
-		envVars = utils.ParseEnvironmentVariables(*updateRequest.EnvironmentVariables)
-	}
-
-	// Map CPU to memory if provided
-	var cpu, memory *string
-	if updateRequest.CPU != nil && *updateRequest.CPU != "" {
-		cpuVal, memoryVal := utils.ParseCPU(*updateRequest.CPU)
-		cpu = &cpuVal
-		memory = &memoryVal
+		envVars, err = utils.ParseEnvironmentVariables(*updateRequest.EnvironmentVariables)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	// Update description if provided
@@ -362,6 +453,16 @@ func (c *ContainerAppsApplication) PatchContainerApp(projectID string, container
 	}
 
 	// Update configuration section
+	if updateRequest.Privileged != nil {
+		if config, ok := currentContainerApp["configuration"].(map[string]interface{}); ok {
+			config["privileged"] = *updateRequest.Privileged
+		} else {
+			currentContainerApp["configuration"] = map[string]interface{}{
+				"privileged": *updateRequest.Privileged,
+			}
+		}
+	}
+
 	if updateRequest.PubliclyAccessible != nil {
 		if config, ok := currentContainerApp["configuration"].(map[string]interface{}); ok {
 			if ingress, ok := config["ingress"].(map[string]interface{}); ok {
@@ -431,43 +532,116 @@ func (c *ContainerAppsApplication) PatchContainerApp(projectID string, container
 			} // else not required, scaling should exists in template
 		}
 
-		// Update container section
+		// Remove sidecars and init containers if requested
+		if len(updateRequest.RemoveContainers) > 0 {
+			if err := removeContainers(template, updateRequest.RemoveContainers); err != nil {
+				return nil, err
+			}
+		}
+
+		// Add or replace sidecars and init containers if provided
+		if updateRequest.Sidecars != nil {
+			sidecars, err := parseContainerPayloads(*updateRequest.Sidecars)
+			if err != nil {
+				return nil, fmt.Errorf("invalid sidecars: %w", err)
+			}
+			if err := upsertSidecars(template, sidecars); err != nil {
+				return nil, err
+			}
+		}
+		if updateRequest.InitContainers != nil {
+			initContainers, err := parseContainerPayloads(*updateRequest.InitContainers)
+			if err != nil {
+				return nil, fmt.Errorf("invalid init containers: %w", err)
+			}
+			template["initContainers"] = upsertByName(template["initContainers"], initContainers)
+		}
+		if err := checkContainerNames(template); err != nil {
+			return nil, err
+		}
+
+		// Remove, add or replace volumes if requested
+		if len(updateRequest.RemoveVolumes) > 0 {
+			if err := removeVolumes(template, updateRequest.RemoveVolumes); err != nil {
+				return nil, err
+			}
+		}
+		if updateRequest.Volumes != nil {
+			volumes, err := parseVolumePayloads(*updateRequest.Volumes)
+			if err != nil {
+				return nil, fmt.Errorf("invalid volumes: %w", err)
+			}
+			template["volumes"] = upsertByName(template["volumes"], volumes)
+		}
+
+		// Update container section, the named container or init container or the first container
 		if containers, ok := template["containers"].([]interface{}); ok && len(containers) > 0 {
-			if container, ok := containers[0].(map[string]interface{}); ok {
-				// Update image if provided
-				if updateRequest.ContainerAppImage != nil {
-					container["image"] = *updateRequest.ContainerAppImage
-				}
+			initContainers, _ := template["initContainers"].([]interface{})
+			container, err := findContainer(append(append([]interface{}{}, containers...), initContainers...), updateRequest.ContainerName)
+			if err != nil {
+				return nil, err
+			}
 
-				// Update containerPort if provided
-				if updateRequest.ContainerAppPort != nil {
-					container["containerPort"] = *updateRequest.ContainerAppPort
-				}
+			// Update image if provided
+			if updateRequest.ContainerAppImage != nil {
+				container["image"] = *updateRequest.ContainerAppImage
+			}
 
-				// Update resources if provided
-				if cpu != nil && memory != nil {
-					container["resources"] = map[string]string{
-						"cpu":    *cpu,
-						"memory": *memory,
-					}
-				}
+			// Update containerPort if provided
+			if updateRequest.ContainerAppPort != nil {
+				container["containerPort"] = *updateRequest.ContainerAppPort
+			}
 
-				// Update environment variables if provided
-				if len(envVars) > 0 {
-					container["env"] = envVars
-				}
+			// Update resources if provided
+			resources, err := patchResources(container["resources"], updateRequest.CPU, updateRequest.Memory)
+			if err != nil {
+				return nil, err
+			}
+			if resources != nil {
+				container["resources"] = resources
+			}
+
+			// Update environment variables if provided, keeping the types of the current variables. An empty list removes all variables
+			if envVars != nil {
+				container["env"] = inheritEnvironmentVariableTypes(envVars, container["env"])
+			}
 
-				// Update command if provided
-				if len(updateRequest.Command) > 0 {
-					container["command"] = updateRequest.Command
+			// Merge incremental environment variable changes
+			if !updateRequest.EnvironmentVariableChanges.IsEmpty() {
+				env, err := applyEnvironmentVariableChanges(container["env"], updateRequest.EnvironmentVariableChanges)
+				if err != nil {
+					return nil, err
 				}
+				container["env"] = env
+			}
+
+			// Update command if provided, an empty command ([] in the tools) resets it to the image entrypoint
+			if updateRequest.Command != nil {
+				container["command"] = updateRequest.Command
+			}
+
+			// Update args if provided, empty args ([] in the tools) remove them
+			if updateRequest.Args != nil {
+				container["args"] = updateRequest.Args
+			}
 
-				// Update args if provided
-				if len(updateRequest.Args) > 0 {
-					container["args"] = updateRequest.Args
+			// Replace volume mounts if provided
+			if updateRequest.VolumeMounts != nil {
+				mounts, err := utils.ParseVolumeMounts(*updateRequest.VolumeMounts)
+				if err != nil {
+					return nil, fmt.Errorf("invalid volume mounts: %w", err)
 				}
+				if mounts == nil {
+					mounts = []domain.VolumeMount{}
+				}
+				container["volumeMounts"] = mounts
 			}
 		}
+
+		// Every volume mount must reference a declared volume
+		if err := checkVolumeMounts(template); err != nil {
+			return nil, err
+		}
 	}
 
 	// Convert payload to JSON
@@ -478,7 +652,11 @@ func (c *ContainerAppsApplication) PatchContainerApp(projectID string, container
 
 	// Make PATCH request to ContainerApps API
 	path := fmt.Sprintf("/v2/containers/%s?projectId=%s", containerAppName, projectID)
-	body, err := c.makeHTTPRequest("PATCH", path, jsonPayload)
+	// Record the request instead of sending it in dry-run mode
+	if err := checkDryRun(ctx, "PATCH", c.cfg.API.ContainersAPI+path, jsonPayload, rawBody); err != nil {
+		return nil, err
+	}
+	body, err := c.client.Do(ctx, "PATCH", c.cfg.API.ContainersAPI+path, jsonPayload)
 	if err != nil {
 		return nil, err
 	}
@@ -496,3 +674,25 @@ func (c *ContainerAppsApplication) PatchContainerApp(projectID string, container
 
 	return &response, nil
 }
+
+// addCreateContainers adds sidecars after the main container and init containers to the template of a create request
+func addCreateContainers(template map[string]interface{}, sidecars string, initContainers string) error {
+	sidecarPayloads, err := parseContainerPayloads(sidecars)
+	if err != nil {
+		return fmt.Errorf("invalid sidecars: %w", err)
+	}
+	initContainerPayloads, err := parseContainerPayloads(initContainers)
+	if err != nil {
+		return fmt.Errorf("invalid init containers: %w", err)
+	}
+
+	containers := make([]interface{}, 0, 1+len(sidecarPayloads))
+	for _, container := range template["containers"].([]map[string]interface{}) {
+		containers = append(containers, container)
+	}
+	template["containers"] = append(containers, sidecarPayloads...)
+	if len(initContainerPayloads) > 0 {
+		template["initContainers"] = initContainerPayloads
+	}
+	return checkContainerNames(template)
+}
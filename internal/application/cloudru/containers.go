@@ -0,0 +1,157 @@
+package cloudru
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Nick1994209/cloudru-containerapps-mcp/internal/domain"
+	"github.com/Nick1994209/cloudru-containerapps-mcp/internal/utils"
+)
+
+// containerPayload converts a sidecar or an init container to the API format
+func containerPayload(spec domain.ContainerSpec) map[string]interface{} {
+	env := spec.Env
+	if env == nil {
+		env = []domain.EnvironmentVariable{}
+	}
+	container := map[string]interface{}{
+		"name":  spec.Name,
+		"image": spec.Image,
+		"resources": map[string]string{
+			"cpu":    spec.CPU,
+			"memory": spec.Memory,
+		},
+		"env": env,
+	}
+	if spec.Port != 0 {
+		container["containerPort"] = spec.Port
+	}
+	if len(spec.Command) > 0 {
+		container["command"] = spec.Command
+	}
+	if len(spec.Args) > 0 {
+		container["args"] = spec.Args
+	}
+	if len(spec.VolumeMounts) > 0 {
+		container["volumeMounts"] = spec.VolumeMounts
+	}
+	return container
+}
+
+// parseContainerPayloads parses a JSON array of containers and converts them to the API format
+func parseContainerPayloads(containers string) ([]interface{}, error) {
+	specs, err := utils.ParseContainers(containers)
+	if err != nil {
+		return nil, err
+	}
+	payloads := make([]interface{}, 0, len(specs))
+	for _, spec := range specs {
+		payloads = append(payloads, containerPayload(spec))
+	}
+	return payloads, nil
+}
+
+// nameOf returns the name of a container or a volume in the API format
+func nameOf(container interface{}) string {
+	if typed, ok := container.(map[string]interface{}); ok {
+		name, _ := typed["name"].(string)
+		return name
+	}
+	return ""
+}
+
+// findContainer finds a container by name, the first container if the name is empty
+func findContainer(containers []interface{}, name string) (map[string]interface{}, error) {
+	names := make([]string, 0, len(containers))
+	for i, container := range containers {
+		typed, ok := container.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if (name == "" && i == 0) || nameOf(typed) == name {
+			return typed, nil
+		}
+		names = append(names, nameOf(typed))
+	}
+	return nil, fmt.Errorf("container %s not found, available containers: %s", name, strings.Join(names, ", "))
+}
+
+// upsertByName replaces the current containers or volumes with the same name and appends the others
+func upsertByName(current interface{}, items []interface{}) []interface{} {
+	result, _ := current.([]interface{})
+	for _, item := range items {
+		replaced := false
+		for i, existing := range result {
+			if nameOf(existing) == nameOf(item) {
+				result[i] = item
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			result = append(result, item)
+		}
+	}
+	return result
+}
+
+// upsertSidecars replaces sidecars with the same name and appends the others, the main (first) container cannot be replaced
+func upsertSidecars(template map[string]interface{}, sidecars []interface{}) error {
+	containers, _ := template["containers"].([]interface{})
+	for _, sidecar := range sidecars {
+		if len(containers) > 0 && nameOf(containers[0]) == nameOf(sidecar) {
+			return fmt.Errorf("container %s is the main container and cannot be replaced by a sidecar", nameOf(sidecar))
+		}
+	}
+	template["containers"] = upsertByName(template["containers"], sidecars)
+	return nil
+}
+
+// removeContainers removes sidecars and init containers by name, the main (first) container cannot be removed
+func removeContainers(template map[string]interface{}, names []string) error {
+	containers, _ := template["containers"].([]interface{})
+	initContainers, _ := template["initContainers"].([]interface{})
+	for _, name := range names {
+		if len(containers) > 0 && nameOf(containers[0]) == name {
+			return fmt.Errorf("container %s is the main container and cannot be removed", name)
+		}
+		var found bool
+		containers, found = withoutName(containers, name)
+		if !found {
+			initContainers, found = withoutName(initContainers, name)
+		}
+		if !found {
+			return fmt.Errorf("container %s not found", name)
+		}
+	}
+	template["containers"] = containers
+	if initContainers != nil {
+		template["initContainers"] = initContainers
+	}
+	return nil
+}
+
+// withoutName returns the containers or volumes without the named one and whether it was found
+func withoutName(items []interface{}, name string) ([]interface{}, bool) {
+	for i, item := range items {
+		if nameOf(item) == name {
+			return append(items[:i:i], items[i+1:]...), true
+		}
+	}
+	return items, false
+}
+
+// checkContainerNames checks that the main container, sidecars and init containers have unique names
+func checkContainerNames(template map[string]interface{}) error {
+	containers, _ := template["containers"].([]interface{})
+	initContainers, _ := template["initContainers"].([]interface{})
+	seen := make(map[string]bool, len(containers)+len(initContainers))
+	for _, container := range append(append([]interface{}{}, containers...), initContainers...) {
+		name := nameOf(container)
+		if seen[name] {
+			return fmt.Errorf("container name %s is used more than once, names of containers and init containers must be unique", name)
+		}
+		seen[name] = true
+	}
+	return nil
+}
@@ -0,0 +1,85 @@
+package cloudru
+
+import (
+	"testing"
+
+	"github.com/Nick1994209/cloudru-containerapps-mcp/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseContainerPayloads(t *testing.T) {
+	payloads, err := parseContainerPayloads(`[{"name": "proxy", "image": "nginx:1.27", "port": 8081, "env": {"A": "1"}, "command": ["nginx"]}]`)
+	require.NoError(t, err)
+
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{
+			"name":          "proxy",
+			"image":         "nginx:1.27",
+			"containerPort": 8081,
+			"resources":     map[string]string{"cpu": "0.1", "memory": "256Mi"},
+			"env":           []domain.EnvironmentVariable{{Name: "A", Value: "1"}},
+			"command":       []string{"nginx"},
+		},
+	}, payloads)
+
+	_, err = parseContainerPayloads(`[{"name": "proxy"}, {"name": "proxy", "image": "nginx", "cpu": "3"}]`)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "container 1: proxy: image is required")
+	assert.Contains(t, err.Error(), `container 2: proxy: invalid CPU "3"`)
+}
+
+func TestContainerChanges(t *testing.T) {
+	template := map[string]interface{}{
+		"containers": []interface{}{
+			map[string]interface{}{"name": "app", "image": "app:v1"},
+			map[string]interface{}{"name": "proxy", "image": "nginx:1.26"},
+		},
+		"initContainers": []interface{}{
+			map[string]interface{}{"name": "migrate", "image": "app:v1"},
+		},
+	}
+
+	// Sidecars with the same name are replaced and others are added
+	require.NoError(t, upsertSidecars(template, []interface{}{
+		map[string]interface{}{"name": "proxy", "image": "nginx:1.27"},
+		map[string]interface{}{"name": "agent", "image": "agent:v1"},
+	}))
+	require.NoError(t, checkContainerNames(template))
+
+	container, err := findContainer(template["containers"].([]interface{}), "proxy")
+	require.NoError(t, err)
+	assert.Equal(t, "nginx:1.27", container["image"])
+	container, err = findContainer(template["containers"].([]interface{}), "")
+	require.NoError(t, err)
+	assert.Equal(t, "app", container["name"])
+	_, err = findContainer(template["containers"].([]interface{}), "missing")
+	assert.EqualError(t, err, "container missing not found, available containers: app, proxy, agent")
+
+	require.NoError(t, removeContainers(template, []string{"proxy", "migrate"}))
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"name": "app", "image": "app:v1"},
+		map[string]interface{}{"name": "agent", "image": "agent:v1"},
+	}, template["containers"])
+	assert.Empty(t, template["initContainers"])
+
+	assert.EqualError(t, removeContainers(template, []string{"app"}), "container app is the main container and cannot be removed")
+	assert.EqualError(t, removeContainers(template, []string{"missing"}), "container missing not found")
+
+	template["initContainers"] = []interface{}{map[string]interface{}{"name": "agent", "image": "agent:v1"}}
+	assert.Error(t, checkContainerNames(template))
+}
+
+func TestUpsertSidecars_RejectsMainContainerName(t *testing.T) {
+	template := map[string]interface{}{
+		"containers": []interface{}{
+			map[string]interface{}{"name": "app", "image": "app:v1", "containerPort": 8080},
+		},
+	}
+
+	err := upsertSidecars(template, []interface{}{map[string]interface{}{"name": "app", "image": "nginx:1.27"}})
+	assert.EqualError(t, err, "container app is the main container and cannot be replaced by a sidecar")
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"name": "app", "image": "app:v1", "containerPort": 8080},
+	}, template["containers"])
+}
@@ -0,0 +1,30 @@
+package cloudru
+
+import (
+	"context"
+
+	"github.com/Nick1994209/cloudru-containerapps-mcp/internal/domain"
+)
+
+// checkDryRun is called by mutating service methods with the built payload right before the request is sent.
+// In dry-run mode (see domain.WithDryRun) it records the request with the current state of the object, nil for new objects,
+// and returns domain.ErrDryRun, so the service returns without calling the API
+func checkDryRun(ctx context.Context, method, url string, payload []byte, current []byte) error {
+	dryRun := domain.DryRunFromContext(ctx)
+	if dryRun == nil {
+		return nil
+	}
+
+	dryRun.Request = &domain.DryRunRequest{Method: method, URL: url, Payload: payload}
+	dryRun.Current = current
+	return domain.ErrDryRun
+}
+
+// dryRunCurrent gets the current object of a request without a payload (delete, start, stop) for the dry-run output.
+// The object is fetched only in dry-run mode, so requests which are sent do not make an extra call
+func dryRunCurrent(ctx context.Context, get func(ctx context.Context, projectID string, name string) ([]byte, error), projectID string, name string) ([]byte, error) {
+	if domain.DryRunFromContext(ctx) == nil {
+		return nil, nil
+	}
+	return get(ctx, projectID, name)
+}
@@ -0,0 +1,71 @@
+package cloudru
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Nick1994209/cloudru-containerapps-mcp/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJobsApplication_PatchJob_DryRunDoesNotSendRequest(t *testing.T) {
+	var methods []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		methods = append(methods, r.Method)
+		_, _ = w.Write([]byte(`{"name":"job","description":"old"}`))
+	}))
+	t.Cleanup(server.Close)
+	app := newTestJobsApplication(server.URL)
+
+	dryRun := &domain.DryRun{}
+	ctx := domain.WithDryRun(context.Background(), dryRun)
+	description := "new"
+	_, err := app.PatchJob(ctx, "project", "job", domain.PatchJobRequest{JobDescription: &description})
+
+	assert.ErrorIs(t, err, domain.ErrDryRun)
+	assert.Equal(t, []string{http.MethodGet}, methods, "the mutating request must not be sent")
+	require.NotNil(t, dryRun.Request)
+	assert.Equal(t, http.MethodPatch, dryRun.Request.Method)
+	assert.Equal(t, server.URL+"/v2/jobs/job?projectId=project", dryRun.Request.URL)
+	assert.JSONEq(t, `{"name":"job","description":"new"}`, string(dryRun.Request.Payload))
+	assert.JSONEq(t, `{"name":"job","description":"old"}`, string(dryRun.Current))
+}
+
+func TestJobsApplication_PatchJob_EmptyEnvAndCommandReset(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"name":"job","template":{"containers":[{"name":"job","env":[{"name":"A","value":"1"}],"command":["run"],"args":["--all"]}]}}`))
+	}))
+	t.Cleanup(server.Close)
+	app := newTestJobsApplication(server.URL)
+
+	dryRun := &domain.DryRun{}
+	ctx := domain.WithDryRun(context.Background(), dryRun)
+	env := "[]"
+	_, err := app.PatchJob(ctx, "project", "job", domain.PatchJobRequest{JobEnvironmentVariables: &env, JobCommand: []string{}})
+
+	assert.ErrorIs(t, err, domain.ErrDryRun)
+	require.NotNil(t, dryRun.Request)
+	assert.JSONEq(t, `{"name":"job","template":{"containers":[{"name":"job","env":[],"command":[],"args":["--all"]}]}}`, string(dryRun.Request.Payload))
+}
+
+func TestJobsApplication_DeleteJob_DryRunRecordsCurrentJob(t *testing.T) {
+	var methods []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		methods = append(methods, r.Method)
+		_, _ = w.Write([]byte(`{"name":"job","status":"ACTIVE"}`))
+	}))
+	t.Cleanup(server.Close)
+	app := newTestJobsApplication(server.URL)
+
+	dryRun := &domain.DryRun{}
+	_, err := app.DeleteJob(domain.WithDryRun(context.Background(), dryRun), "project", "job")
+
+	assert.ErrorIs(t, err, domain.ErrDryRun)
+	assert.Equal(t, []string{http.MethodGet}, methods, "the delete request must not be sent")
+	require.NotNil(t, dryRun.Request)
+	assert.Equal(t, http.MethodDelete, dryRun.Request.Method)
+	assert.JSONEq(t, `{"name":"job","status":"ACTIVE"}`, string(dryRun.Current))
+}
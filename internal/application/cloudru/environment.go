@@ -0,0 +1,92 @@
+package cloudru
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+
+	"github.com/Nick1994209/cloudru-containerapps-mcp/internal/domain"
+	"github.com/Nick1994209/cloudru-containerapps-mcp/internal/utils"
+)
+
+// inheritEnvironmentVariableTypes sets the type of the current variable with the same name to variables without an explicit type,
+// so patching a secret variable does not silently convert it to plain text. A masked secret value sent back from a tool output
+// keeps the current secret reference. current is the raw env list of the container
+func inheritEnvironmentVariableTypes(variables []domain.EnvironmentVariable, current interface{}) []domain.EnvironmentVariable {
+	currentList, _ := current.([]interface{})
+	currentVariables := make(map[string]domain.EnvironmentVariable, len(currentList))
+	for _, item := range currentList {
+		variable, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		name, _ := variable["name"].(string)
+		value, _ := variable["value"].(string)
+		variableType, _ := variable["type"].(string)
+		currentVariables[name] = domain.EnvironmentVariable{Name: name, Value: value, Type: variableType}
+	}
+
+	result := make([]domain.EnvironmentVariable, len(variables))
+	for i, variable := range variables {
+		result[i] = utils.ResolveEnvironmentVariable(variable, currentVariables[variable.Name])
+	}
+	return result
+}
+
+// applyEnvironmentVariableChanges merges incremental changes into the raw env list of the container.
+// Unknown fields and the order of the current variables are kept, new variables are appended
+func applyEnvironmentVariableChanges(current interface{}, changes domain.EnvironmentVariableChanges) ([]map[string]interface{}, error) {
+	set, err := utils.ParseEnvironmentVariables(changes.Set)
+	if err != nil {
+		return nil, err
+	}
+
+	// The env list is either the raw API list or the typed list of a replaced env
+	var variables []map[string]interface{}
+	if current != nil {
+		data, err := json.Marshal(current)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read current environment variables: %w", err)
+		}
+		if err := json.Unmarshal(data, &variables); err != nil {
+			return nil, fmt.Errorf("failed to read current environment variables: %w", err)
+		}
+	}
+	index := func(name string) int {
+		return slices.IndexFunc(variables, func(variable map[string]interface{}) bool {
+			return variable["name"] == name
+		})
+	}
+
+	oldNames := make([]string, 0, len(changes.Rename))
+	for oldName := range changes.Rename {
+		oldNames = append(oldNames, oldName)
+	}
+	sort.Strings(oldNames)
+	for _, oldName := range oldNames {
+		newName := changes.Rename[oldName]
+		i := index(oldName)
+		if i < 0 {
+			return nil, fmt.Errorf("cannot rename environment variable %s: it does not exist", oldName)
+		}
+		if index(newName) >= 0 {
+			return nil, fmt.Errorf("cannot rename environment variable %s to %s: %s already exists", oldName, newName, newName)
+		}
+		variables[i]["name"] = newName
+	}
+
+	variables = slices.DeleteFunc(variables, func(variable map[string]interface{}) bool {
+		name, _ := variable["name"].(string)
+		return slices.Contains(changes.Unset, name)
+	})
+
+	for _, variable := range set {
+		if i := index(variable.Name); i >= 0 {
+			variables[i] = utils.MergeEnvironmentVariable(variables[i], variable)
+			continue
+		}
+		variables = append(variables, utils.MergeEnvironmentVariable(nil, variable))
+	}
+	return variables, nil
+}
@@ -0,0 +1,83 @@
+package cloudru
+
+import (
+	"testing"
+
+	"github.com/Nick1994209/cloudru-containerapps-mcp/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInheritEnvironmentVariableTypes(t *testing.T) {
+	current := []interface{}{
+		map[string]interface{}{"name": "PASSWORD", "value": "password-ref", "type": "secret"},
+		map[string]interface{}{"name": "LOG_LEVEL", "value": "info"},
+	}
+
+	variables := inheritEnvironmentVariableTypes([]domain.EnvironmentVariable{
+		{Name: "PASSWORD", Value: "new-password-ref"},
+		{Name: "LOG_LEVEL", Value: "debug", Type: domain.EnvironmentVariableTypeSecret},
+		{Name: "NEW", Value: "1"},
+	}, current)
+
+	assert.Equal(t, []domain.EnvironmentVariable{
+		{Name: "PASSWORD", Value: "new-password-ref", Type: domain.EnvironmentVariableTypeSecret},
+		{Name: "LOG_LEVEL", Value: "debug", Type: domain.EnvironmentVariableTypeSecret},
+		{Name: "NEW", Value: "1"},
+	}, variables)
+}
+
+func TestApplyEnvironmentVariableChanges(t *testing.T) {
+	current := []interface{}{
+		map[string]interface{}{"name": "DB_URL", "value": "postgres://db", "type": "plain"},
+		map[string]interface{}{"name": "PASSWORD", "value": "password-ref", "type": "secret"},
+		map[string]interface{}{"name": "OLD", "value": "1"},
+	}
+
+	variables, err := applyEnvironmentVariableChanges(current, domain.EnvironmentVariableChanges{
+		Set:    `{"PASSWORD": "new-password-ref", "LOG_LEVEL": "debug"}`,
+		Unset:  []string{"OLD", "MISSING"},
+		Rename: map[string]string{"DB_URL": "DATABASE_URL"},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, []map[string]interface{}{
+		{"name": "DATABASE_URL", "value": "postgres://db", "type": "plain"},
+		{"name": "PASSWORD", "value": "new-password-ref", "type": "secret"},
+		{"name": "LOG_LEVEL", "value": "debug"},
+	}, variables)
+}
+
+func TestApplyEnvironmentVariableChanges_RenameErrors(t *testing.T) {
+	current := []domain.EnvironmentVariable{{Name: "A", Value: "1"}, {Name: "B", Value: "2"}}
+
+	_, err := applyEnvironmentVariableChanges(current, domain.EnvironmentVariableChanges{Rename: map[string]string{"C": "D"}})
+	assert.ErrorContains(t, err, "C: it does not exist")
+
+	_, err = applyEnvironmentVariableChanges(current, domain.EnvironmentVariableChanges{Rename: map[string]string{"A": "B"}})
+	assert.ErrorContains(t, err, "B already exists")
+}
+
+func TestEnvironmentVariables_MaskedSecretKeepsReference(t *testing.T) {
+	current := []interface{}{
+		map[string]interface{}{"name": "TOKEN", "value": "token-ref", "type": "secret"},
+		map[string]interface{}{"name": "LOG_LEVEL", "value": "info"},
+	}
+
+	// A masked value sent back from a tool output must not overwrite the secret reference
+	variables := inheritEnvironmentVariableTypes([]domain.EnvironmentVariable{
+		{Name: "TOKEN", Value: "***"},
+		{Name: "LOG_LEVEL", Value: "***"},
+	}, current)
+	assert.Equal(t, []domain.EnvironmentVariable{
+		{Name: "TOKEN", Value: "token-ref", Type: domain.EnvironmentVariableTypeSecret},
+		{Name: "LOG_LEVEL", Value: "***"},
+	}, variables)
+
+	changed, err := applyEnvironmentVariableChanges(current, domain.EnvironmentVariableChanges{Set: "TOKEN='***'; LOG_LEVEL='debug'"})
+	require.NoError(t, err)
+	assert.Equal(t, []map[string]interface{}{
+		{"name": "TOKEN", "value": "token-ref", "type": "secret"},
+		{"name": "LOG_LEVEL", "value": "debug"},
+	}, changed)
+}
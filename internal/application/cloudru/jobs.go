@@ -1,11 +1,10 @@
 package cloudru
 
 import (
-	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
-	"io"
-	"net/http"
+	"time"
 
 	"github.com/Nick1994209/cloudru-containerapps-mcp/internal/config"
 	"github.com/Nick1994209/cloudru-containerapps-mcp/internal/domain"
@@ -14,91 +13,51 @@ import (
 
 // JobsApplication implements the JobsService interface
 type JobsApplication struct {
-	authService domain.AuthService
-	cfg         *config.Config
+	client *Client
+	cfg    *config.Config
 }
 
 // NewJobsApplication creates a new JobsApplication
-func NewJobsApplication(cfg *config.Config) domain.JobsService {
+func NewJobsApplication(cfg *config.Config, client *Client) domain.JobsService {
 	return &JobsApplication{
-		authService: NewAuthApplication(cfg),
-		cfg:         cfg,
+		client: client,
+		cfg:    cfg,
 	}
 }
 
-// makeHTTPRequest makes an HTTP request to the Cloud.ru API
-func (j *JobsApplication) makeHTTPRequest(method, path string, body []byte) ([]byte, error) {
-	token, err := j.authService.GetAccessToken()
-	if err != nil {
-		return nil, fmt.Errorf("failed to get access token: %w", err)
-	}
-
-	// Construct full URL by combining base API URL with path
-	url := j.cfg.API.ContainersAPI + path
-
-	var bodyReader io.Reader
-	if body != nil {
-		bodyReader = bytes.NewBuffer(body)
-	}
-
-	req, err := http.NewRequest(method, url, bodyReader)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
-	}
-
-	req.Header.Set("Authorization", "Bearer "+token)
-	req.Header.Set("Content-Type", "application/json")
-
-	client := &http.Client{}
-	resp, err := client.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("failed to make request: %w", err)
-	}
-	defer resp.Body.Close()
-
-	responseBody, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read response body: %w", err)
-	}
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(responseBody))
-	}
-
-	return responseBody, nil
-}
+// GetListJobs gets a page of Jobs from Cloud.ru API, or all pages in fetch-all mode
+func (j *JobsApplication) GetListJobs(ctx context.Context, projectID string, options domain.ListOptions) (*domain.JobsPage, error) {
+	result := &domain.JobsPage{Data: []domain.Job{}}
+	nextPageToken, err := fetchPages(options, func(pageToken string) (string, error) {
+		// Make request to Jobs API
+		path := "/v2/jobs?" + listQuery(projectID, options, pageToken)
+		body, err := j.client.Do(ctx, "GET", j.cfg.API.ContainersAPI+path, nil)
+		if err != nil {
+			return "", err
+		}
 
-// GetListJobs gets a list of Jobs from Cloud.ru API
-func (j *JobsApplication) GetListJobs(projectID string, pageSize string) ([]domain.Job, error) {
-	// Set default pageSize to 100 if not provided
-	if pageSize == "" {
-		pageSize = "100"
-	}
+		// Parse response as a wrapper object containing a slice of Job
+		var response domain.JobsPage
+		if err := json.Unmarshal(body, &response); err != nil {
+			return "", fmt.Errorf("failed to parse jobs response: %w body length: %d body: %s", err, len(body), string(body))
+		}
+		result.Data = append(result.Data, response.Data...)
 
-	// Make request to Jobs API
-	path := fmt.Sprintf("/v2/jobs?projectId=%s&pageSize=%s", projectID, pageSize)
-	body, err := j.makeHTTPRequest("GET", path, nil)
+		return response.NextPageToken, nil
+	})
 	if err != nil {
 		return nil, err
 	}
+	result.NextPageToken = nextPageToken
 
-	// Parse response as a wrapper object containing a slice of Job
-	var response struct {
-		Data []domain.Job `json:"data"`
-	}
-	if err := json.Unmarshal(body, &response); err != nil {
-		return nil, fmt.Errorf("failed to parse jobs response: %w body length: %d body: %s", err, len(body), string(body))
-	}
-	jobs := response.Data
-
-	return jobs, nil
+	return result, nil
 }
 
 // GetJob gets a specific Job from Cloud.ru API by name
-func (j *JobsApplication) GetJob(projectID string, jobName string) (*domain.Job, error) {
+func (j *JobsApplication) GetJob(ctx context.Context, projectID string, jobName string) (*domain.Job, error) {
 	// Make request to Jobs API
 	path := fmt.Sprintf("/v2/jobs/%s?projectId=%s", jobName, projectID)
-	body, err := j.makeHTTPRequest("GET", path, nil)
+	body, err := j.client.Do(ctx, "GET", j.cfg.API.ContainersAPI+path, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -113,10 +72,16 @@ func (j *JobsApplication) GetJob(projectID string, jobName string) (*domain.Job,
 }
 
 // CreateJob creates a new Job in Cloud.ru
-func (j *JobsApplication) CreateJob(request domain.CreateJobRequest) (*domain.Operation, error) {
-	// Map CPU to memory
-	cpu, memory := utils.ParseCPU(request.JobCPU)
-	envVars := utils.ParseEnvironmentVariables(request.JobEnvironmentVariables)
+func (j *JobsApplication) CreateJob(ctx context.Context, request domain.CreateJobRequest) (*domain.Operation, error) {
+	// Validate the CPU/memory pair
+	cpu, memory, err := utils.ParseResources(request.JobCPU, request.JobMemory)
+	if err != nil {
+		return nil, err
+	}
+	envVars, err := utils.ParseEnvironmentVariables(request.JobEnvironmentVariables)
+	if err != nil {
+		return nil, err
+	}
 
 	// Prepare request body according to swagger spec
 	requestBody := map[string]interface{}{
@@ -159,7 +124,45 @@ func (j *JobsApplication) CreateJob(request domain.CreateJobRequest) (*domain.Op
 
 	// Make request to Jobs API
 	path := "/v2/jobs"
-	body, err := j.makeHTTPRequest("POST", path, jsonBody)
+	// Record the request instead of sending it in dry-run mode
+	if err := checkDryRun(ctx, "POST", j.cfg.API.ContainersAPI+path, jsonBody, nil); err != nil {
+		return nil, err
+	}
+	body, err := j.client.Do(ctx, "POST", j.cfg.API.ContainersAPI+path, jsonBody)
+	if err != nil {
+		return nil, err
+	}
+
+	// Parse response as an Operation
+	var operation domain.Operation
+	if err := json.Unmarshal(body, &operation); err != nil {
+		return nil, fmt.Errorf("failed to parse operation response: %w body length: %d body: %s", err, len(body), string(body))
+	}
+
+	return &operation, nil
+}
+
+// CreateJobFromObject creates a Job in the project from a raw object without server-managed fields,
+// e.g. a copy of another Job
+func (j *JobsApplication) CreateJobFromObject(ctx context.Context, projectID string, object []byte) (*domain.Operation, error) {
+	var requestBody map[string]interface{}
+	if err := json.Unmarshal(object, &requestBody); err != nil {
+		return nil, fmt.Errorf("failed to parse job object: %w", err)
+	}
+	requestBody["projectId"] = projectID
+
+	jsonBody, err := json.Marshal(requestBody)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request body: %w", err)
+	}
+
+	// Make request to Jobs API
+	path := "/v2/jobs"
+	// Record the request instead of sending it in dry-run mode
+	if err := checkDryRun(ctx, "POST", j.cfg.API.ContainersAPI+path, jsonBody, nil); err != nil {
+		return nil, err
+	}
+	body, err := j.client.Do(ctx, "POST", j.cfg.API.ContainersAPI+path, jsonBody)
 	if err != nil {
 		return nil, err
 	}
@@ -174,10 +177,18 @@ func (j *JobsApplication) CreateJob(request domain.CreateJobRequest) (*domain.Op
 }
 
 // DeleteJob deletes a specific Job from Cloud.ru
-func (j *JobsApplication) DeleteJob(projectID string, jobName string) (*domain.Operation, error) {
+func (j *JobsApplication) DeleteJob(ctx context.Context, projectID string, jobName string) (*domain.Operation, error) {
 	// Make request to Jobs API
 	path := fmt.Sprintf("/v2/jobs/%s?projectId=%s", jobName, projectID)
-	body, err := j.makeHTTPRequest("DELETE", path, nil)
+	// Record the request with the current state instead of sending it in dry-run mode
+	current, err := dryRunCurrent(ctx, j.getJobRaw, projectID, jobName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current job state for '%s': %w", jobName, err)
+	}
+	if err := checkDryRun(ctx, "DELETE", j.cfg.API.ContainersAPI+path, nil, current); err != nil {
+		return nil, err
+	}
+	body, err := j.client.Do(ctx, "DELETE", j.cfg.API.ContainersAPI+path, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -192,7 +203,7 @@ func (j *JobsApplication) DeleteJob(projectID string, jobName string) (*domain.O
 }
 
 // ExecuteJob executes a specific Job in Cloud.ru
-func (j *JobsApplication) ExecuteJob(projectID string, jobName string, params map[string]interface{}) (*domain.JobExecution, error) {
+func (j *JobsApplication) ExecuteJob(ctx context.Context, projectID string, jobName string, params map[string]interface{}) (*domain.JobExecution, error) {
 	// Prepare request body
 	requestBody := map[string]interface{}{
 		"projectId": projectID,
@@ -207,11 +218,20 @@ func (j *JobsApplication) ExecuteJob(projectID string, jobName string, params ma
 
 	// Make request to Jobs API
 	path := fmt.Sprintf("/v2/jobs/%s:execute", jobName)
-	body, err := j.makeHTTPRequest("POST", path, jsonBody)
+	// Record the request instead of sending it in dry-run mode
+	if err := checkDryRun(ctx, "POST", j.cfg.API.ContainersAPI+path, jsonBody, nil); err != nil {
+		return nil, err
+	}
+	body, err := j.client.Do(ctx, "POST", j.cfg.API.ContainersAPI+path, jsonBody)
 	if err != nil {
 		return nil, err
 	}
 
+	// The API may start the execution asynchronously and return its operation
+	if operation := responseOperation(body); operation != nil {
+		return &domain.JobExecution{Operation: operation}, nil
+	}
+
 	// Parse response as a JobExecution
 	var jobExecution domain.JobExecution
 	if err := json.Unmarshal(body, &jobExecution); err != nil {
@@ -221,39 +241,39 @@ func (j *JobsApplication) ExecuteJob(projectID string, jobName string, params ma
 	return &jobExecution, nil
 }
 
-// GetListExecutions gets a list of Job Executions from Cloud.ru API
-func (j *JobsApplication) GetListExecutions(projectID string, jobName string, pageSize string) ([]domain.JobExecution, error) {
-	// Set default pageSize to 100 if not provided
-	if pageSize == "" {
-		pageSize = "100"
-	}
+// GetListExecutions gets a page of Job Executions from Cloud.ru API, or all pages in fetch-all mode
+func (j *JobsApplication) GetListExecutions(ctx context.Context, projectID string, jobName string, options domain.ListOptions) (*domain.JobExecutionsPage, error) {
+	result := &domain.JobExecutionsPage{Data: []domain.JobExecution{}}
+	nextPageToken, err := fetchPages(options, func(pageToken string) (string, error) {
+		// Make request to Jobs API
+		path := fmt.Sprintf("/v2/jobs/%s/executions?%s", jobName, listQuery(projectID, options, pageToken))
+		body, err := j.client.Do(ctx, "GET", j.cfg.API.ContainersAPI+path, nil)
+		if err != nil {
+			return "", err
+		}
 
-	// Build URL with query parameters
-	path := fmt.Sprintf("/v2/jobs/%s/executions?projectId=%s&pageSize=%s", jobName, projectID, pageSize)
+		// Parse response as a wrapper object containing a slice of JobExecution
+		var response domain.JobExecutionsPage
+		if err := json.Unmarshal(body, &response); err != nil {
+			return "", fmt.Errorf("failed to parse job executions response: %w body length: %d body: %s", err, len(body), string(body))
+		}
+		result.Data = append(result.Data, response.Data...)
 
-	// Make request to Jobs API
-	body, err := j.makeHTTPRequest("GET", path, nil)
+		return response.NextPageToken, nil
+	})
 	if err != nil {
 		return nil, err
 	}
+	result.NextPageToken = nextPageToken
 
-	// Parse response as a wrapper object containing a slice of JobExecution
-	var response struct {
-		Data []domain.JobExecution `json:"data"`
-	}
-	if err := json.Unmarshal(body, &response); err != nil {
-		return nil, fmt.Errorf("failed to parse job executions response: %w body length: %d body: %s", err, len(body), string(body))
-	}
-	executions := response.Data
-
-	return executions, nil
+	return result, nil
 }
 
 // getJobRaw gets the raw response body from the Jobs API
-func (j *JobsApplication) getJobRaw(projectID string, jobName string) ([]byte, error) {
+func (j *JobsApplication) getJobRaw(ctx context.Context, projectID string, jobName string) ([]byte, error) {
 	// Make request to Jobs API
 	path := fmt.Sprintf("/v2/jobs/%s?projectId=%s", jobName, projectID)
-	body, err := j.makeHTTPRequest("GET", path, nil)
+	body, err := j.client.Do(ctx, "GET", j.cfg.API.ContainersAPI+path, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -266,10 +286,15 @@ func (j *JobsApplication) getJobRaw(projectID string, jobName string) ([]byte, e
 	return body, nil
 }
 
+// GetJobRaw gets a specific Job from Cloud.ru API as is, including fields which are not mapped to domain.Job
+func (j *JobsApplication) GetJobRaw(ctx context.Context, projectID string, jobName string) ([]byte, error) {
+	return j.getJobRaw(ctx, projectID, jobName)
+}
+
 // PatchJob patches a Job in Cloud.ru
-func (j *JobsApplication) PatchJob(projectID string, jobName string, updateRequest domain.PatchJobRequest) (*domain.Operation, error) {
+func (j *JobsApplication) PatchJob(ctx context.Context, projectID string, jobName string, updateRequest domain.PatchJobRequest) (*domain.Operation, error) {
 	// First, get the current job state
-	rawBody, err := j.getJobRaw(projectID, jobName)
+	rawBody, err := j.getJobRaw(ctx, projectID, jobName)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get current job state for project %s and job %s: %w", projectID, jobName, err)
 	}
@@ -302,17 +327,12 @@ func (j *JobsApplication) PatchJob(projectID string, jobName string, updateReque
 	}
 
 	// Use environment variables directly (already parsed)
-	var envVars []map[string]interface{}
+	var envVars []domain.EnvironmentVariable
 	if updateRequest.JobEnvironmentVariables != nil {
-		envVars = utils.ParseEnvironmentVariables(*updateRequest.JobEnvironmentVariables)
-	}
-
-	// Map CPU to memory if provided
-	var cpu, memory *string
-	if updateRequest.JobCPU != nil && *updateRequest.JobCPU != "" {
-		cpuVal, memoryVal := utils.ParseCPU(*updateRequest.JobCPU)
-		cpu = &cpuVal
-		memory = &memoryVal
+		envVars, err = utils.ParseEnvironmentVariables(*updateRequest.JobEnvironmentVariables)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	// Update template section
@@ -336,25 +356,35 @@ func (j *JobsApplication) PatchJob(projectID string, jobName string, updateReque
 				}
 
 				// Update resources if provided
-				if cpu != nil && memory != nil {
-					container["resources"] = map[string]string{
-						"cpu":    *cpu,
-						"memory": *memory,
-					}
+				resources, err := patchResources(container["resources"], updateRequest.JobCPU, updateRequest.JobMemory)
+				if err != nil {
+					return nil, err
+				}
+				if resources != nil {
+					container["resources"] = resources
 				}
 
-				// Update environment variables if provided
-				if len(envVars) > 0 {
-					container["env"] = envVars
+				// Update environment variables if provided, keeping the types of the current variables. An empty list removes all variables
+				if envVars != nil {
+					container["env"] = inheritEnvironmentVariableTypes(envVars, container["env"])
 				}
 
-				// Update command if provided
-				if len(updateRequest.JobCommand) > 0 {
+				// Merge incremental environment variable changes
+				if !updateRequest.JobEnvironmentVariableChanges.IsEmpty() {
+					env, err := applyEnvironmentVariableChanges(container["env"], updateRequest.JobEnvironmentVariableChanges)
+					if err != nil {
+						return nil, err
+					}
+					container["env"] = env
+				}
+
+				// Update command if provided, an empty command ([] in the tools) resets it to the image entrypoint
+				if updateRequest.JobCommand != nil {
 					container["command"] = updateRequest.JobCommand
 				}
 
-				// Update args if provided
-				if len(updateRequest.JobArgs) > 0 {
+				// Update args if provided, empty args ([] in the tools) remove them
+				if updateRequest.JobArgs != nil {
 					container["args"] = updateRequest.JobArgs
 				}
 			}
@@ -369,7 +399,11 @@ func (j *JobsApplication) PatchJob(projectID string, jobName string, updateReque
 
 	// Make PATCH request to Jobs API
 	path := fmt.Sprintf("/v2/jobs/%s?projectId=%s", jobName, projectID)
-	body, err := j.makeHTTPRequest("PATCH", path, jsonPayload)
+	// Record the request instead of sending it in dry-run mode
+	if err := checkDryRun(ctx, "PATCH", j.cfg.API.ContainersAPI+path, jsonPayload, rawBody); err != nil {
+		return nil, err
+	}
+	body, err := j.client.Do(ctx, "PATCH", j.cfg.API.ContainersAPI+path, jsonPayload)
 	if err != nil {
 		return nil, err
 	}
@@ -387,3 +421,41 @@ func (j *JobsApplication) PatchJob(projectID string, jobName string, updateReque
 
 	return &response, nil
 }
+
+// GetJobExecution gets a specific Job Execution from Cloud.ru API by name
+func (j *JobsApplication) GetJobExecution(ctx context.Context, projectID string, jobName string, executionName string) (*domain.JobExecution, error) {
+	// Make request to Jobs API
+	path := fmt.Sprintf("/v2/jobs/%s/executions/%s?projectId=%s", jobName, executionName, projectID)
+	body, err := j.client.Do(ctx, "GET", j.cfg.API.ContainersAPI+path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// Parse response as a JobExecution
+	var jobExecution domain.JobExecution
+	if err := json.Unmarshal(body, &jobExecution); err != nil {
+		return nil, fmt.Errorf("failed to parse job execution response: %w body length: %d body: %s", err, len(body), string(body))
+	}
+
+	return &jobExecution, nil
+}
+
+// GetJobExecutionLogs gets logs of a specific Job Execution from Cloud.ru API.
+// The filters of the options are applied to the entries returned by the API, the result is in chronological order
+func (j *JobsApplication) GetJobExecutionLogs(ctx context.Context, projectID string, jobName string, executionName string, options domain.LogOptions) (*domain.JobExecutionLogs, error) {
+	// Validate the filters before calling the API, relative time bounds are counted from now
+	now := time.Now()
+	filter, err := newLogFilter(options, now)
+	if err != nil {
+		return nil, err
+	}
+
+	// Make requests to Jobs API for logs, older pages are fetched until since is covered
+	logsURL := fmt.Sprintf("%s/v2/jobs/%s/executions/%s/logs", j.cfg.API.ContainersAPI, jobName, executionName)
+	response, err := fetchLogPages(ctx, j.client, logsURL, projectID, filter.window.since, executionName)
+	if err != nil {
+		return nil, err
+	}
+
+	return filterLogs(response, options, now)
+}
@@ -0,0 +1,242 @@
+package cloudru
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Nick1994209/cloudru-containerapps-mcp/internal/domain"
+)
+
+// maxLogPages bounds the pages fetched to cover since, so a chatty app does not page through its whole history
+const maxLogPages = 20
+
+// logsPage is a page of log entries returned by the logs API
+type logsPage struct {
+	Data          []domain.ContainerAppLogEntry `json:"data"`
+	NextPageToken string                        `json:"nextPageToken"`
+}
+
+// fetchLogPages gets log entries of a logs API URL. The API has no time, level or text parameters and returns its own default page,
+// so while since is not covered the next pages are fetched as long as the API returns a page token, at most maxLogPages.
+// name is the resource shown in parse errors
+func fetchLogPages(ctx context.Context, client *Client, logsURL string, projectID string, since time.Time, name string) (*domain.ContainerAppLogs, error) {
+	logs := &domain.ContainerAppLogs{}
+	pageToken := ""
+	for pages := 0; pages < maxLogPages; pages++ {
+		query := url.Values{}
+		query.Set("projectId", projectID)
+		if pageToken != "" {
+			query.Set("pageToken", pageToken)
+		}
+		body, err := client.Do(ctx, "GET", logsURL+"?"+query.Encode(), nil)
+		if err != nil {
+			return nil, err
+		}
+
+		// A resource without output may return an empty body
+		var page logsPage
+		if len(bytes.TrimSpace(body)) > 0 {
+			if err := json.Unmarshal(body, &page); err != nil {
+				return nil, fmt.Errorf("failed to parse logs response for '%s': %w body length: %d body: %s", name, err, len(body), string(body))
+			}
+		}
+		logs.Data = append(logs.Data, page.Data...)
+
+		if since.IsZero() || page.NextPageToken == "" {
+			break
+		}
+		if oldest, ok := oldestLogTime(logs.Data); ok && !oldest.After(since) {
+			break
+		}
+		pageToken = page.NextPageToken
+	}
+	return logs, nil
+}
+
+// logWindow is the time window of a log request, zero bounds are open
+type logWindow struct {
+	since time.Time
+	until time.Time
+}
+
+// parseLogWindow parses the since and until bounds of a log request relative to now
+func parseLogWindow(since string, until string, now time.Time) (logWindow, error) {
+	var window logWindow
+	var err error
+	if window.since, err = parseLogTime("since", since, now); err != nil {
+		return logWindow{}, err
+	}
+	if window.until, err = parseLogTime("until", until, now); err != nil {
+		return logWindow{}, err
+	}
+	if !window.since.IsZero() && !window.until.IsZero() && window.since.After(window.until) {
+		return logWindow{}, fmt.Errorf("since %s is after until %s", window.since.Format(time.RFC3339), window.until.Format(time.RFC3339))
+	}
+	return window, nil
+}
+
+// parseLogTime parses an RFC3339 timestamp or a duration before now, e.g. 15m or 2h. An empty value is a zero time
+func parseLogTime(field string, value string, now time.Time) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if duration, err := time.ParseDuration(value); err == nil && duration >= 0 {
+		return now.Add(-duration), nil
+	}
+	if timestamp, err := time.Parse(time.RFC3339Nano, value); err == nil {
+		return timestamp, nil
+	}
+	return time.Time{}, fmt.Errorf("invalid %s %q, expected an RFC3339 timestamp like 2024-05-01T10:00:00Z or a duration like 15m or 2h", field, value)
+}
+
+// isOpen reports if the window has no bounds
+func (w logWindow) isOpen() bool {
+	return w.since.IsZero() && w.until.IsZero()
+}
+
+// contains checks if a log timestamp is inside the window, entries without a valid timestamp are only inside an open window
+func (w logWindow) contains(timestamp string) bool {
+	if w.isOpen() {
+		return true
+	}
+	parsed, err := time.Parse(time.RFC3339Nano, timestamp)
+	if err != nil {
+		return false
+	}
+	return (w.since.IsZero() || !parsed.Before(w.since)) && (w.until.IsZero() || !parsed.After(w.until))
+}
+
+// logFilter matches log entries against log options
+type logFilter struct {
+	window        logWindow
+	levels        map[string]bool
+	podName       string
+	versionID     string
+	containerName string
+	text          string
+}
+
+// newLogFilter validates log options and creates their filter
+func newLogFilter(options domain.LogOptions, now time.Time) (*logFilter, error) {
+	window, err := parseLogWindow(options.Since, options.Until, now)
+	if err != nil {
+		return nil, err
+	}
+	if options.MaxLines < 0 {
+		return nil, fmt.Errorf("max lines must not be negative, got: %d", options.MaxLines)
+	}
+
+	filter := &logFilter{
+		window:        window,
+		podName:       strings.TrimSpace(options.PodName),
+		versionID:     strings.TrimSpace(options.VersionID),
+		containerName: strings.TrimSpace(options.ContainerName),
+		text:          strings.ToLower(strings.TrimSpace(options.Text)),
+	}
+	for _, level := range strings.Split(options.Level, ",") {
+		if level = strings.ToLower(strings.TrimSpace(level)); level != "" {
+			if filter.levels == nil {
+				filter.levels = map[string]bool{}
+			}
+			filter.levels[level] = true
+		}
+	}
+	return filter, nil
+}
+
+// match checks if an entry passes all filters. Pod names match by substring, because pods have generated suffixes
+func (f *logFilter) match(entry domain.ContainerAppLogEntry) bool {
+	if f.levels != nil && !f.levels[strings.ToLower(entry.Level)] {
+		return false
+	}
+	if f.podName != "" && !strings.Contains(entry.PodName, f.podName) {
+		return false
+	}
+	if f.versionID != "" && entry.VersionID != f.versionID {
+		return false
+	}
+	if f.containerName != "" && entry.ContainerName != f.containerName {
+		return false
+	}
+	if f.text != "" && !strings.Contains(strings.ToLower(entry.Message), f.text) {
+		return false
+	}
+	return f.window.contains(entry.Timestamp)
+}
+
+// filterLogs keeps the entries matching the options in chronological order, limited to the latest MaxLines entries.
+// The API returns its own pages of entries without filters, so the result is marked partial when the fetched pages start after since
+func filterLogs(logs *domain.ContainerAppLogs, options domain.LogOptions, now time.Time) (*domain.ContainerAppLogs, error) {
+	filter, err := newLogFilter(options, now)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]domain.ContainerAppLogEntry, 0, len(logs.Data))
+	for _, entry := range logs.Data {
+		if filter.match(entry) {
+			entries = append(entries, entry)
+		}
+	}
+	sortLogEntries(entries)
+
+	result := &domain.ContainerAppLogs{Data: entries, Total: len(entries)}
+	if options.MaxLines > 0 && len(entries) > options.MaxLines {
+		result.Data = entries[len(entries)-options.MaxLines:]
+		result.Truncated = true
+	}
+	if oldest, ok := oldestLogTime(logs.Data); ok && !filter.window.since.IsZero() && oldest.After(filter.window.since) {
+		result.Partial = true
+		result.AvailableSince = oldest.Format(time.RFC3339Nano)
+	}
+	return result, nil
+}
+
+// oldestLogTime returns the oldest valid timestamp of the entries, false if no entry has one
+func oldestLogTime(entries []domain.ContainerAppLogEntry) (time.Time, bool) {
+	var oldest time.Time
+	for _, entry := range entries {
+		timestamp, err := time.Parse(time.RFC3339Nano, entry.Timestamp)
+		if err == nil && (oldest.IsZero() || timestamp.Before(oldest)) {
+			oldest = timestamp
+		}
+	}
+	return oldest, !oldest.IsZero()
+}
+
+// sortLogEntries sorts entries by timestamp
+func sortLogEntries(entries []domain.ContainerAppLogEntry) {
+	sortByTimestamp(entries, func(entry domain.ContainerAppLogEntry) string { return entry.Timestamp })
+}
+
+// sortByTimestamp sorts entries by their RFC 3339 timestamps parsed once per entry.
+// Entries with invalid timestamps go last, entries with equal or invalid timestamps keep the API order
+func sortByTimestamp[T any](entries []T, timestamp func(T) string) {
+	type keyedEntry struct {
+		entry T
+		at    time.Time
+		valid bool
+	}
+	keyed := make([]keyedEntry, len(entries))
+	for i, entry := range entries {
+		at, err := time.Parse(time.RFC3339Nano, timestamp(entry))
+		keyed[i] = keyedEntry{entry: entry, at: at, valid: err == nil}
+	}
+
+	sort.SliceStable(keyed, func(i, j int) bool {
+		if keyed[i].valid != keyed[j].valid {
+			return keyed[i].valid
+		}
+		return keyed[i].valid && keyed[i].at.Before(keyed[j].at)
+	})
+	for i := range keyed {
+		entries[i] = keyed[i].entry
+	}
+}
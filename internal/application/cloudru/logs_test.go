@@ -0,0 +1,188 @@
+package cloudru
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Nick1994209/cloudru-containerapps-mcp/internal/config"
+	"github.com/Nick1994209/cloudru-containerapps-mcp/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testLogsNow = time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+var testLogs = &domain.ContainerAppLogs{Data: []domain.ContainerAppLogEntry{
+	{Timestamp: "2024-05-01T11:58:00Z", Level: "ERROR", PodName: "app-v2-abc", VersionID: "v2", ContainerName: "app", Message: "panic: connection refused"},
+	{Timestamp: "2024-05-01T11:00:00Z", Level: "info", PodName: "app-v1-xyz", VersionID: "v1", ContainerName: "app", Message: "listening on :8080"},
+	{Timestamp: "2024-05-01T11:55:00Z", Level: "info", PodName: "app-v2-abc", VersionID: "v2", ContainerName: "proxy", Message: "proxy started"},
+	{Timestamp: "2024-05-01T11:59:00Z", Level: "error", PodName: "app-v2-def", VersionID: "v2", ContainerName: "app", Message: "Connection refused, retrying"},
+}}
+
+func TestFilterLogs(t *testing.T) {
+	tests := []struct {
+		name      string
+		options   domain.LogOptions
+		messages  []string
+		total     int
+		truncated bool
+		partial   bool
+	}{
+		{
+			name:     "no filters sorts by timestamp",
+			messages: []string{"listening on :8080", "proxy started", "panic: connection refused", "Connection refused, retrying"},
+			total:    4,
+		},
+		{
+			name:     "relative since and level",
+			options:  domain.LogOptions{Since: "10m", Level: "error, warn"},
+			messages: []string{"panic: connection refused", "Connection refused, retrying"},
+			total:    2,
+		},
+		{
+			name:     "since older than the returned entries",
+			options:  domain.LogOptions{Since: "2h", Level: "info"},
+			messages: []string{"listening on :8080", "proxy started"},
+			total:    2,
+			partial:  true,
+		},
+		{
+			name:     "until timestamp",
+			options:  domain.LogOptions{Until: "2024-05-01T11:30:00Z"},
+			messages: []string{"listening on :8080"},
+			total:    1,
+		},
+		{
+			name:     "pod, version and container",
+			options:  domain.LogOptions{PodName: "abc", VersionID: "v2", ContainerName: "app"},
+			messages: []string{"panic: connection refused"},
+			total:    1,
+		},
+		{
+			name:     "case-insensitive text",
+			options:  domain.LogOptions{Text: "CONNECTION"},
+			messages: []string{"panic: connection refused", "Connection refused, retrying"},
+			total:    2,
+		},
+		{
+			name:      "max lines keeps the latest entries",
+			options:   domain.LogOptions{MaxLines: 1},
+			messages:  []string{"Connection refused, retrying"},
+			total:     4,
+			truncated: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logs, err := filterLogs(testLogs, tt.options, testLogsNow)
+			require.NoError(t, err)
+
+			messages := make([]string, 0, len(logs.Data))
+			for _, entry := range logs.Data {
+				messages = append(messages, entry.Message)
+			}
+			assert.Equal(t, tt.messages, messages)
+			assert.Equal(t, tt.total, logs.Total)
+			assert.Equal(t, tt.truncated, logs.Truncated)
+			assert.Equal(t, tt.partial, logs.Partial)
+			if tt.partial {
+				assert.Equal(t, "2024-05-01T11:00:00Z", logs.AvailableSince)
+			}
+		})
+	}
+}
+
+func TestFilterLogs_InvalidOptions(t *testing.T) {
+	_, err := filterLogs(testLogs, domain.LogOptions{Since: "yesterday"}, testLogsNow)
+	assert.ErrorContains(t, err, `invalid since "yesterday"`)
+
+	_, err = filterLogs(testLogs, domain.LogOptions{Since: "5m", Until: "1h"}, testLogsNow)
+	assert.ErrorContains(t, err, "is after until")
+}
+
+func TestGetJobExecutionLogs(t *testing.T) {
+	bodies := map[string]string{
+		"exec-1": `{"data":[{"timestamp":"2024-05-01T11:59:00Z","level":"error","message":"exit 1"},{"timestamp":"2024-05-01T11:58:00Z","level":"info","message":"processing"}]}`,
+		"exec-2": "",
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		executionName := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/v2/jobs/job/executions/"), "/logs")
+		assert.Equal(t, "project", r.URL.Query().Get("projectId"))
+		_, _ = w.Write([]byte(bodies[executionName]))
+	}))
+	t.Cleanup(server.Close)
+	app := newTestJobsApplication(server.URL)
+
+	logs, err := app.GetJobExecutionLogs(context.Background(), "project", "job", "exec-1", domain.LogOptions{MaxLines: 1})
+	require.NoError(t, err)
+	require.Len(t, logs.Data, 1)
+	assert.Equal(t, "exit 1", logs.Data[0].Message)
+	assert.Equal(t, 2, logs.Total)
+	assert.True(t, logs.Truncated)
+
+	logs, err = app.GetJobExecutionLogs(context.Background(), "project", "job", "exec-2", domain.LogOptions{})
+	require.NoError(t, err)
+	assert.Empty(t, logs.Data)
+
+	_, err = app.GetJobExecutionLogs(context.Background(), "project", "job", "exec-1", domain.LogOptions{Until: "soon"})
+	assert.ErrorContains(t, err, `invalid until "soon"`)
+}
+
+func TestGetContainerAppLogs_PagesUntilSinceIsCovered(t *testing.T) {
+	since := time.Now().Add(-time.Hour).UTC()
+	pages := map[string]string{
+		"":       `{"data":[{"timestamp":"` + since.Add(50*time.Minute).Format(time.RFC3339) + `","message":"newest"}],"nextPageToken":"page-2"}`,
+		"page-2": `{"data":[{"timestamp":"` + since.Add(-time.Minute).Format(time.RFC3339) + `","message":"older"}],"nextPageToken":"page-3"}`,
+		"page-3": `{"data":[{"timestamp":"` + since.Add(-time.Hour).Format(time.RFC3339) + `","message":"oldest"}]}`,
+	}
+	var pageTokens []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v2/containers/app/logs", r.URL.Path)
+		assert.Equal(t, "project", r.URL.Query().Get("projectId"))
+		pageTokens = append(pageTokens, r.URL.Query().Get("pageToken"))
+		_, _ = w.Write([]byte(pages[r.URL.Query().Get("pageToken")]))
+	}))
+	t.Cleanup(server.Close)
+	app := &ContainerAppsApplication{
+		client: newTestClient(1),
+		cfg:    &config.Config{API: config.APIURLs{ContainersAPI: server.URL}},
+	}
+
+	// Without since only the default page is fetched
+	logs, err := app.GetContainerAppLogs(context.Background(), "project", "app", domain.LogOptions{})
+	require.NoError(t, err)
+	assert.Len(t, logs.Data, 1)
+	assert.Equal(t, []string{""}, pageTokens)
+
+	// Pages are fetched until the oldest entry reaches since
+	pageTokens = nil
+	logs, err = app.GetContainerAppLogs(context.Background(), "project", "app", domain.LogOptions{Since: since.Format(time.RFC3339)})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"", "page-2"}, pageTokens)
+	require.Len(t, logs.Data, 1)
+	assert.Equal(t, "newest", logs.Data[0].Message)
+	assert.False(t, logs.Partial)
+}
+
+func TestSortLogEntries_InvalidTimestampsLast(t *testing.T) {
+	entries := []domain.ContainerAppLogEntry{
+		{Timestamp: "garbage", Message: "first invalid"},
+		{Timestamp: "2024-05-01T11:59:00Z", Message: "latest"},
+		{Message: "second invalid"},
+		{Timestamp: "2024-05-01T11:00:00.5+00:00", Message: "earliest"},
+		{Timestamp: "2024-05-01T11:59:00Z", Message: "latest again"},
+	}
+
+	sortLogEntries(entries)
+
+	messages := make([]string, len(entries))
+	for i, entry := range entries {
+		messages[i] = entry.Message
+	}
+	assert.Equal(t, []string{"earliest", "latest", "latest again", "first invalid", "second invalid"}, messages)
+}
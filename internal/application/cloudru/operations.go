@@ -0,0 +1,121 @@
+package cloudru
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/Nick1994209/cloudru-containerapps-mcp/internal/config"
+	"github.com/Nick1994209/cloudru-containerapps-mcp/internal/domain"
+)
+
+// Operation polling intervals, the interval grows while the operation is in progress
+const (
+	operationPollInitialInterval = time.Second
+	operationPollMaxInterval     = 10 * time.Second
+)
+
+// OperationsApplication implements the OperationsService interface
+type OperationsApplication struct {
+	client          *Client
+	cfg             *config.Config
+	pollInterval    time.Duration
+	maxPollInterval time.Duration
+}
+
+// NewOperationsApplication creates a new OperationsApplication
+func NewOperationsApplication(cfg *config.Config, client *Client) domain.OperationsService {
+	return &OperationsApplication{
+		client:          client,
+		cfg:             cfg,
+		pollInterval:    operationPollInitialInterval,
+		maxPollInterval: operationPollMaxInterval,
+	}
+}
+
+// GetOperation gets a long-running operation from Cloud.ru Operations API
+func (o *OperationsApplication) GetOperation(ctx context.Context, operationID string) (*domain.Operation, error) {
+	if operationID == "" {
+		return nil, fmt.Errorf("operation id is empty")
+	}
+
+	// According to the API documentation: GET https://operations.api.cloud.ru/v1/operations/<operation_id>
+	path := fmt.Sprintf("/v1/operations/%s", url.PathEscape(operationID))
+	body, err := o.client.Do(ctx, "GET", o.cfg.API.OperationsAPI+path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var operation domain.Operation
+	if err := json.Unmarshal(body, &operation); err != nil {
+		return nil, fmt.Errorf("failed to parse operation response for '%s': %w body length: %d body: %s", operationID, err, len(body), string(body))
+	}
+
+	return &operation, nil
+}
+
+// responseOperation returns the long-running operation of a response body, nil if the body is not an operation
+func responseOperation(body []byte) *domain.Operation {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(body, &fields); err != nil {
+		return nil
+	}
+	if _, ok := fields["done"]; !ok {
+		return nil
+	}
+
+	var operation domain.Operation
+	if err := json.Unmarshal(body, &operation); err != nil || operation.ID == "" {
+		return nil
+	}
+	return &operation
+}
+
+// WaitOperation polls a long-running operation until it is done, fails, timeout expires or ctx is cancelled.
+// The last known state of the operation is returned together with the error
+func (o *OperationsApplication) WaitOperation(ctx context.Context, operationID string, timeout time.Duration) (*domain.Operation, error) {
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	interval := o.pollInterval
+	var operation *domain.Operation
+	for {
+		current, err := o.GetOperation(ctx, operationID)
+		if err != nil {
+			if ctx.Err() != nil {
+				return operation, waitOperationError(ctx, operationID, timeout)
+			}
+			return operation, err
+		}
+		operation = current
+		if operation.Done {
+			if operation.Error != nil {
+				return operation, fmt.Errorf("operation %s failed: %s", operationID, operation.Error.Message)
+			}
+			return operation, nil
+		}
+
+		if err := sleepContext(ctx, interval); err != nil {
+			return operation, waitOperationError(ctx, operationID, timeout)
+		}
+
+		interval *= 2
+		if interval > o.maxPollInterval {
+			interval = o.maxPollInterval
+		}
+	}
+}
+
+// waitOperationError describes why WaitOperation stopped before the operation was done
+func waitOperationError(ctx context.Context, operationID string, timeout time.Duration) error {
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) && timeout > 0 {
+		return fmt.Errorf("timed out after %s waiting for operation %s to complete", timeout, operationID)
+	}
+	return fmt.Errorf("stopped waiting for operation %s: %w", operationID, ctx.Err())
+}
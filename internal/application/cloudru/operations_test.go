@@ -0,0 +1,95 @@
+package cloudru
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Nick1994209/cloudru-containerapps-mcp/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestOperationsApplication creates an OperationsApplication pointed at the test server with short poll intervals
+func newTestOperationsApplication(serverURL string) *OperationsApplication {
+	return &OperationsApplication{
+		client:          newTestClient(1),
+		cfg:             &config.Config{API: config.APIURLs{OperationsAPI: serverURL}},
+		pollInterval:    time.Millisecond,
+		maxPollInterval: 5 * time.Millisecond,
+	}
+}
+
+// newOperationServer serves the responses in order for GET /v1/operations/op-1, repeating the last one
+func newOperationServer(t *testing.T, responses ...string) (*httptest.Server, *int32) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/operations/op-1", r.URL.Path)
+		call := int(atomic.AddInt32(&calls, 1))
+		if call > len(responses) {
+			call = len(responses)
+		}
+		_, _ = w.Write([]byte(responses[call-1]))
+	}))
+	t.Cleanup(server.Close)
+	return server, &calls
+}
+
+func TestOperationsApplication_WaitOperation_PollsUntilDone(t *testing.T) {
+	server, calls := newOperationServer(t,
+		`{"id":"op-1","resourceName":"my-app","done":false}`,
+		`{"id":"op-1","resourceName":"my-app","done":false}`,
+		`{"id":"op-1","resourceName":"my-app","done":true}`,
+	)
+
+	operation, err := newTestOperationsApplication(server.URL).WaitOperation(context.Background(), "op-1", time.Second)
+
+	require.NoError(t, err)
+	assert.True(t, operation.Done)
+	assert.Equal(t, "my-app", operation.ResourceName)
+	assert.Equal(t, int32(3), atomic.LoadInt32(calls))
+}
+
+func TestOperationsApplication_WaitOperation_ReturnsOperationError(t *testing.T) {
+	server, _ := newOperationServer(t,
+		`{"id":"op-1","done":true,"error":{"code":3,"message":"image not found"}}`,
+	)
+
+	operation, err := newTestOperationsApplication(server.URL).WaitOperation(context.Background(), "op-1", time.Second)
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "image not found")
+	require.NotNil(t, operation)
+	assert.Equal(t, "image not found", operation.Error.Message)
+}
+
+func TestOperationsApplication_WaitOperation_TimesOut(t *testing.T) {
+	server, _ := newOperationServer(t, `{"id":"op-1","done":false}`)
+
+	operation, err := newTestOperationsApplication(server.URL).WaitOperation(context.Background(), "op-1", 30*time.Millisecond)
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "timed out")
+	require.NotNil(t, operation)
+	assert.False(t, operation.Done)
+}
+
+func TestOperationsApplication_GetOperation_RequiresID(t *testing.T) {
+	_, err := newTestOperationsApplication("http://127.0.0.1:0").GetOperation(context.Background(), "")
+
+	assert.Error(t, err)
+}
+
+func TestResponseOperation(t *testing.T) {
+	operation := responseOperation([]byte(`{"id":"op-1","resourceId":"registry-id","done":false}`))
+	require.NotNil(t, operation)
+	assert.Equal(t, "op-1", operation.ID)
+	assert.Equal(t, "registry-id", operation.ResourceID)
+
+	assert.Nil(t, responseOperation([]byte(`{"executionName":"nightly-1","executionStatus":"RUNNING"}`)))
+	assert.Nil(t, responseOperation([]byte(`{"done":true}`)))
+	assert.Nil(t, responseOperation([]byte(`not json`)))
+}
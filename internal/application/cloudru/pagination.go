@@ -0,0 +1,62 @@
+package cloudru
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/Nick1994209/cloudru-containerapps-mcp/internal/domain"
+)
+
+// defaultPageSize is used when list options have no page size
+const defaultPageSize = "100"
+
+// maxFetchAllPages protects fetch-all mode from endless paging
+const maxFetchAllPages = 100
+
+// listQuery builds the query string of a list request from list options
+func listQuery(projectID string, options domain.ListOptions, pageToken string) string {
+	pageSize := options.PageSize
+	if pageSize == "" {
+		pageSize = defaultPageSize
+	}
+
+	query := url.Values{}
+	query.Set("projectId", projectID)
+	query.Set("pageSize", pageSize)
+	if pageToken != "" {
+		query.Set("pageToken", pageToken)
+	}
+	if options.Filter != "" {
+		query.Set("filter", options.Filter)
+	}
+	if options.OrderBy != "" {
+		query.Set("orderBy", options.OrderBy)
+	}
+	return query.Encode()
+}
+
+// fetchPages requests the page at options.PageToken and, in fetch-all mode, every following page.
+// fetchPage receives the page token to request and returns the next page token.
+// The returned token is the next page token of the last fetched page
+func fetchPages(options domain.ListOptions, fetchPage func(pageToken string) (string, error)) (string, error) {
+	pageToken := options.PageToken
+	seenTokens := map[string]bool{}
+	for page := 1; ; page++ {
+		nextPageToken, err := fetchPage(pageToken)
+		if err != nil {
+			return "", err
+		}
+		if !options.FetchAll || nextPageToken == "" {
+			return nextPageToken, nil
+		}
+
+		if seenTokens[nextPageToken] {
+			return "", fmt.Errorf("API returned page token %q twice, stopped fetching pages", nextPageToken)
+		}
+		if page >= maxFetchAllPages {
+			return nextPageToken, nil
+		}
+		seenTokens[nextPageToken] = true
+		pageToken = nextPageToken
+	}
+}
@@ -0,0 +1,90 @@
+package cloudru
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/Nick1994209/cloudru-containerapps-mcp/internal/config"
+	"github.com/Nick1994209/cloudru-containerapps-mcp/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newJobsPagesServer serves /v2/jobs in pages keyed by the page token
+func newJobsPagesServer(t *testing.T, pages map[string]string) (*httptest.Server, *[]url.Values) {
+	var queries []url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v2/jobs", r.URL.Path)
+		queries = append(queries, r.URL.Query())
+		_, _ = w.Write([]byte(pages[r.URL.Query().Get("pageToken")]))
+	}))
+	t.Cleanup(server.Close)
+	return server, &queries
+}
+
+func newTestJobsApplication(serverURL string) *JobsApplication {
+	return &JobsApplication{
+		client: newTestClient(1),
+		cfg:    &config.Config{API: config.APIURLs{ContainersAPI: serverURL}},
+	}
+}
+
+var jobsPages = map[string]string{
+	"":       `{"data":[{"name":"job-1"},{"name":"job-2"}],"nextPageToken":"page-2"}`,
+	"page-2": `{"data":[{"name":"job-3"}],"nextPageToken":"page-3"}`,
+	"page-3": `{"data":[{"name":"job-4"}]}`,
+}
+
+func TestJobsApplication_GetListJobs_ReturnsSinglePage(t *testing.T) {
+	server, queries := newJobsPagesServer(t, jobsPages)
+
+	page, err := newTestJobsApplication(server.URL).GetListJobs(context.Background(), "project-1", domain.ListOptions{
+		PageSize:  "2",
+		PageToken: "page-2",
+		Filter:    `name="job-3"`,
+		OrderBy:   "createdAt desc",
+	})
+
+	require.NoError(t, err)
+	require.Len(t, page.Data, 1)
+	assert.Equal(t, "job-3", page.Data[0].Name)
+	assert.Equal(t, "page-3", page.NextPageToken)
+
+	require.Len(t, *queries, 1)
+	query := (*queries)[0]
+	assert.Equal(t, "project-1", query.Get("projectId"))
+	assert.Equal(t, "2", query.Get("pageSize"))
+	assert.Equal(t, "page-2", query.Get("pageToken"))
+	assert.Equal(t, `name="job-3"`, query.Get("filter"))
+	assert.Equal(t, "createdAt desc", query.Get("orderBy"))
+}
+
+func TestJobsApplication_GetListJobs_FetchesAllPages(t *testing.T) {
+	server, queries := newJobsPagesServer(t, jobsPages)
+
+	page, err := newTestJobsApplication(server.URL).GetListJobs(context.Background(), "project-1", domain.ListOptions{FetchAll: true})
+
+	require.NoError(t, err)
+	var names []string
+	for _, job := range page.Data {
+		names = append(names, job.Name)
+	}
+	assert.Equal(t, []string{"job-1", "job-2", "job-3", "job-4"}, names)
+	assert.Empty(t, page.NextPageToken)
+	assert.Len(t, *queries, 3)
+	assert.Equal(t, defaultPageSize, (*queries)[0].Get("pageSize"))
+}
+
+func TestFetchPages_StopsOnRepeatedToken(t *testing.T) {
+	calls := 0
+	_, err := fetchPages(domain.ListOptions{FetchAll: true}, func(pageToken string) (string, error) {
+		calls++
+		return "same-token", nil
+	})
+
+	assert.Error(t, err)
+	assert.Equal(t, 2, calls)
+}
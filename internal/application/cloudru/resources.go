@@ -0,0 +1,36 @@
+package cloudru
+
+import (
+	"github.com/Nick1994209/cloudru-containerapps-mcp/internal/utils"
+)
+
+// patchResources applies the CPU and memory of a patch request to the current container resources.
+// A CPU without memory gets the default memory of the CPU, a memory without CPU is validated against the current CPU.
+// Returns nil if neither CPU nor memory is passed
+func patchResources(current interface{}, cpu *string, memory *string) (map[string]string, error) {
+	var newCPU, newMemory string
+	if cpu != nil {
+		newCPU = *cpu
+	}
+	if memory != nil {
+		newMemory = *memory
+	}
+	if newCPU == "" && newMemory == "" {
+		return nil, nil
+	}
+
+	if newCPU == "" {
+		if resources, ok := current.(map[string]interface{}); ok {
+			newCPU, _ = resources["cpu"].(string)
+		}
+	}
+
+	newCPU, newMemory, err := utils.ParseResources(newCPU, newMemory)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]string{
+		"cpu":    newCPU,
+		"memory": newMemory,
+	}, nil
+}
@@ -0,0 +1,130 @@
+package cloudru
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/Nick1994209/cloudru-containerapps-mcp/internal/config"
+)
+
+// RetryPolicy decides which Cloud.ru API calls are retried and how long to wait between attempts
+type RetryPolicy struct {
+	MaxAttempts int
+	BaseDelay   time.Duration
+	MaxDelay    time.Duration
+}
+
+// NewRetryPolicy creates a RetryPolicy from the configuration
+func NewRetryPolicy(cfg config.RetryConfig) RetryPolicy {
+	policy := RetryPolicy{
+		MaxAttempts: cfg.MaxAttempts,
+		BaseDelay:   cfg.BaseDelay,
+		MaxDelay:    cfg.MaxDelay,
+	}
+	if policy.MaxAttempts < 1 {
+		policy.MaxAttempts = 1
+	}
+	if policy.MaxDelay < policy.BaseDelay {
+		policy.MaxDelay = policy.BaseDelay
+	}
+	return policy
+}
+
+// retryableStatuses are transient gateway and throttling responses
+var retryableStatuses = map[int]bool{
+	http.StatusTooManyRequests:    true,
+	http.StatusBadGateway:         true,
+	http.StatusServiceUnavailable: true,
+	http.StatusGatewayTimeout:     true,
+}
+
+// isIdempotent reports whether repeating the request cannot change the result
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
+
+// shouldRetry reports whether a failed attempt may be repeated.
+// Idempotent requests are retried on transient statuses and transport errors.
+// Non-idempotent requests (POST, PATCH) are retried only on 429, because a throttled request was not processed
+func (p RetryPolicy) shouldRetry(ctx context.Context, method string, attempt int, err error) bool {
+	if attempt >= p.MaxAttempts || ctx.Err() != nil {
+		return false
+	}
+
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		if !retryableStatuses[apiErr.StatusCode] {
+			return false
+		}
+		return isIdempotent(method) || apiErr.StatusCode == http.StatusTooManyRequests
+	}
+
+	// Transport error, the request may have reached the server
+	return isIdempotent(method)
+}
+
+// delay returns how long to wait before the next attempt.
+// Retry-After is honoured up to MaxDelay, otherwise exponential backoff with jitter is used
+func (p RetryPolicy) delay(attempt int, retryAfter string) time.Duration {
+	if wait, ok := parseRetryAfter(retryAfter, time.Now()); ok {
+		if wait > p.MaxDelay {
+			return p.MaxDelay
+		}
+		return wait
+	}
+
+	backoff := p.BaseDelay << (attempt - 1)
+	if backoff > p.MaxDelay || backoff <= 0 {
+		backoff = p.MaxDelay
+	}
+	if backoff <= 0 {
+		return 0
+	}
+
+	// Equal jitter: wait at least half of the backoff to keep requests spread out
+	half := backoff / 2
+	return half + time.Duration(rand.Int63n(int64(half)+1))
+}
+
+// parseRetryAfter parses the Retry-After header given either in seconds or as an HTTP date
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		wait := date.Sub(now)
+		if wait < 0 {
+			wait = 0
+		}
+		return wait, true
+	}
+	return 0, false
+}
+
+// sleepContext waits for d or until ctx is done
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
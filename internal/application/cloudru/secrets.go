@@ -0,0 +1,101 @@
+package cloudru
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/url"
+
+	"github.com/Nick1994209/cloudru-containerapps-mcp/internal/config"
+	"github.com/Nick1994209/cloudru-containerapps-mcp/internal/domain"
+)
+
+// SecretsApplication implements the SecretsService interface
+type SecretsApplication struct {
+	client *Client
+	cfg    *config.Config
+}
+
+// NewSecretsApplication creates a new SecretsApplication
+func NewSecretsApplication(cfg *config.Config, client *Client) domain.SecretsService {
+	return &SecretsApplication{
+		client: client,
+		cfg:    cfg,
+	}
+}
+
+// GetListSecrets gets a list of Secret Manager secrets of a project, secret values are not returned
+func (s *SecretsApplication) GetListSecrets(ctx context.Context, projectID string) ([]domain.Secret, error) {
+	// Make request to Secret Manager API
+	path := fmt.Sprintf("%s/v2/secrets?parentId=%s", s.cfg.API.SecretsAPI, url.QueryEscape(projectID))
+	body, err := s.client.Do(ctx, "GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check if body is empty
+	if len(body) == 0 {
+		// Return empty slice if no secrets found
+		return []domain.Secret{}, nil
+	}
+
+	// Parse response
+	var response struct {
+		Secrets []domain.Secret `json:"secrets"`
+	}
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, fmt.Errorf("failed to parse secrets response: %w body length: %d", err, len(body))
+	}
+	if response.Secrets == nil {
+		return []domain.Secret{}, nil
+	}
+
+	return response.Secrets, nil
+}
+
+// CreateSecret creates a new Secret Manager secret with the first version holding the value
+func (s *SecretsApplication) CreateSecret(ctx context.Context, request domain.CreateSecretRequest) (*domain.Secret, error) {
+	if request.Value == "" {
+		return nil, fmt.Errorf("secret value is required")
+	}
+
+	// Prepare the request payload, the value is sent base64 encoded
+	payload := map[string]interface{}{
+		"parentId":    request.ProjectID,
+		"name":        request.Name,
+		"description": request.Description,
+		"payload": map[string]string{
+			"value": base64.StdEncoding.EncodeToString([]byte(request.Value)),
+		},
+	}
+
+	// Convert payload to JSON
+	jsonPayload, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal payload: %w", err)
+	}
+
+	// Make request to Secret Manager API
+	// Record the request instead of sending it in dry-run mode
+	if err := checkDryRun(ctx, "POST", s.cfg.API.SecretsAPI+"/v2/secrets", jsonPayload, nil); err != nil {
+		return nil, err
+	}
+	body, err := s.client.Do(ctx, "POST", s.cfg.API.SecretsAPI+"/v2/secrets", jsonPayload)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check if body is empty
+	if len(body) == 0 {
+		return nil, fmt.Errorf("API returned empty response body")
+	}
+
+	// Parse response, the body is not included into errors as it may echo the payload
+	var secret domain.Secret
+	if err := json.Unmarshal(body, &secret); err != nil {
+		return nil, fmt.Errorf("failed to parse secret response: %w body length: %d", err, len(body))
+	}
+
+	return &secret, nil
+}
@@ -0,0 +1,122 @@
+package cloudru
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Nick1994209/cloudru-containerapps-mcp/internal/domain"
+)
+
+// systemLogCategory is a known failure category of system events
+type systemLogCategory struct {
+	name string
+	// markers are lower-case substrings of the event reason or message
+	markers []string
+	hint    string
+}
+
+// systemLogCategories are checked in order, the first matching category wins
+var systemLogCategories = []systemLogCategory{
+	{
+		name:    domain.SystemLogFindingOOM,
+		markers: []string{"oomkilled", "oom killed", "out of memory", "oom-kill"},
+		hint:    "The container exceeded its memory limit, increase memory (see allowed CPU/memory pairs) or reduce the memory usage of the application",
+	},
+	{
+		name:    domain.SystemLogFindingImagePull,
+		markers: []string{"errimagepull", "imagepullbackoff", "invalidimagename", "failed to pull", "pull access denied", "manifest unknown"},
+		hint:    "The image cannot be pulled, check the image name and tag with cloudru_get_containerapp and that the registry is accessible",
+	},
+	{
+		name:    domain.SystemLogFindingScheduling,
+		markers: []string{"failedscheduling", "unschedulable", "insufficient cpu", "insufficient memory", "quota exceeded"},
+		hint:    "No capacity to run the instance, lower CPU/memory or the max instance count, or check the project quotas",
+	},
+}
+
+// newSystemLogsResult filters system events, sorts them by timestamp and summarises the matching ones into findings.
+// Findings cover all matching events, Data is limited to the latest MaxLines events
+func newSystemLogsResult(entries []domain.ContainerAppSystemLogEntry, nextPageToken string, options domain.SystemLogOptions) (*domain.ContainerAppSystemLogs, error) {
+	if options.MaxLines < 0 {
+		return nil, fmt.Errorf("max lines must not be negative, got: %d", options.MaxLines)
+	}
+
+	matching := make([]domain.ContainerAppSystemLogEntry, 0, len(entries))
+	for _, entry := range entries {
+		if matchSystemLog(entry, options) {
+			matching = append(matching, entry)
+		}
+	}
+	// The latest finding and the MaxLines tail rely on chronological order, which the API does not guarantee
+	sortSystemLogEntries(matching)
+
+	result := &domain.ContainerAppSystemLogs{
+		Data:          matching,
+		NextPageToken: nextPageToken,
+		Total:         len(matching),
+		Findings:      systemLogFindings(matching),
+	}
+	if options.MaxLines > 0 && len(matching) > options.MaxLines {
+		result.Data = matching[len(matching)-options.MaxLines:]
+		result.Truncated = true
+	}
+	return result, nil
+}
+
+// matchSystemLog checks if an event passes all filters, filters are case-insensitive
+func matchSystemLog(entry domain.ContainerAppSystemLogEntry, options domain.SystemLogOptions) bool {
+	return matchValue(entry.EventType, options.EventType) &&
+		matchValue(entry.Component, options.Component) &&
+		matchValue(entry.RevisionName, options.RevisionName)
+}
+
+func matchValue(value string, filter string) bool {
+	filter = strings.TrimSpace(filter)
+	return filter == "" || strings.EqualFold(value, filter)
+}
+
+// systemLogFindings groups events of known failure categories, the latest event of a category is reported
+func systemLogFindings(entries []domain.ContainerAppSystemLogEntry) []domain.SystemLogFinding {
+	var findings []domain.SystemLogFinding
+	indexes := map[string]int{}
+	for _, entry := range entries {
+		category, ok := classifySystemLog(entry)
+		if !ok {
+			continue
+		}
+
+		finding := domain.SystemLogFinding{
+			Category:     category.name,
+			Count:        1,
+			Reason:       entry.Reason,
+			Message:      entry.Message,
+			RevisionName: entry.RevisionName,
+			Hint:         category.hint,
+		}
+		if index, seen := indexes[category.name]; seen {
+			finding.Count += findings[index].Count
+			findings[index] = finding
+			continue
+		}
+		indexes[category.name] = len(findings)
+		findings = append(findings, finding)
+	}
+	return findings
+}
+
+// sortSystemLogEntries sorts events by timestamp
+func sortSystemLogEntries(entries []domain.ContainerAppSystemLogEntry) {
+	sortByTimestamp(entries, func(entry domain.ContainerAppSystemLogEntry) string { return entry.Timestamp })
+}
+
+func classifySystemLog(entry domain.ContainerAppSystemLogEntry) (systemLogCategory, bool) {
+	text := strings.ToLower(entry.Reason + " " + entry.Message)
+	for _, category := range systemLogCategories {
+		for _, marker := range category.markers {
+			if strings.Contains(text, marker) {
+				return category, true
+			}
+		}
+	}
+	return systemLogCategory{}, false
+}
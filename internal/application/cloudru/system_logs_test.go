@@ -0,0 +1,109 @@
+package cloudru
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Nick1994209/cloudru-containerapps-mcp/internal/config"
+	"github.com/Nick1994209/cloudru-containerapps-mcp/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newSystemLogsServer serves /v2/containers/app/systemLogs in pages keyed by the page token
+func newSystemLogsServer(t *testing.T, pages map[string]string) *ContainerAppsApplication {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v2/containers/app/systemLogs", r.URL.Path)
+		assert.Equal(t, "project", r.URL.Query().Get("projectId"))
+		_, _ = w.Write([]byte(pages[r.URL.Query().Get("pageToken")]))
+	}))
+	t.Cleanup(server.Close)
+	return &ContainerAppsApplication{
+		client: newTestClient(1),
+		cfg:    &config.Config{API: config.APIURLs{ContainersAPI: server.URL}},
+	}
+}
+
+func TestGetContainerAppSystemLogs(t *testing.T) {
+	app := newSystemLogsServer(t, map[string]string{
+		"": `{"data":[
+			{"eventType":"Warning","component":"scheduler","reason":"FailedScheduling","message":"0/3 nodes are available: 3 Insufficient memory","revisionName":"app-v1"},
+			{"eventType":"Normal","component":"kubelet","reason":"Pulled","message":"Successfully pulled image","revisionName":"app-v2"}
+		],"nextPageToken":"page-2"}`,
+		"page-2": `{"data":[
+			{"eventType":"Warning","component":"kubelet","reason":"Failed","message":"Failed to pull image \"app:latest\": manifest unknown","revisionName":"app-v2"},
+			{"eventType":"Warning","component":"kubelet","reason":"OOMKilled","message":"Container app was OOMKilled","revisionName":"app-v2"},
+			{"eventType":"Warning","component":"kubelet","reason":"BackOff","message":"Back-off pulling image \"app:latest\": ImagePullBackOff","revisionName":"app-v2"}
+		]}`,
+	})
+
+	logs, err := app.GetContainerAppSystemLogs(context.Background(), "project", "app", domain.SystemLogOptions{})
+	require.NoError(t, err)
+	assert.Len(t, logs.Data, 5)
+	assert.Equal(t, 5, logs.Total)
+	require.Len(t, logs.Findings, 3)
+	assert.Equal(t, domain.SystemLogFindingScheduling, logs.Findings[0].Category)
+	assert.Equal(t, domain.SystemLogFindingImagePull, logs.Findings[1].Category)
+	assert.Equal(t, 2, logs.Findings[1].Count)
+	assert.Equal(t, "BackOff", logs.Findings[1].Reason)
+	assert.Equal(t, domain.SystemLogFindingOOM, logs.Findings[2].Category)
+
+	logs, err = app.GetContainerAppSystemLogs(context.Background(), "project", "app", domain.SystemLogOptions{
+		EventType:    "warning",
+		Component:    "kubelet",
+		RevisionName: "app-v2",
+		MaxLines:     1,
+	})
+	require.NoError(t, err)
+	require.Len(t, logs.Data, 1)
+	assert.Equal(t, "BackOff", logs.Data[0].Reason)
+	assert.Equal(t, 3, logs.Total)
+	assert.True(t, logs.Truncated)
+	assert.Len(t, logs.Findings, 2)
+}
+
+func TestGetContainerAppSystemLogs_OutOfOrderEvents(t *testing.T) {
+	app := newSystemLogsServer(t, map[string]string{
+		"": `{"data":[
+			{"timestamp":"2024-05-01T12:02:00Z","eventType":"Warning","reason":"OOMKilled","message":"Container app was OOMKilled","revisionName":"app-v3"},
+			{"timestamp":"2024-05-01T12:00:00Z","eventType":"Warning","reason":"OOMKilled","message":"Container app was OOMKilled","revisionName":"app-v1"},
+			{"timestamp":"2024-05-01T12:01:00Z","eventType":"Warning","reason":"OOMKilled","message":"Container app was OOMKilled","revisionName":"app-v2"}
+		]}`,
+	})
+
+	logs, err := app.GetContainerAppSystemLogs(context.Background(), "project", "app", domain.SystemLogOptions{MaxLines: 1})
+	require.NoError(t, err)
+	require.Len(t, logs.Data, 1)
+	assert.Equal(t, "app-v3", logs.Data[0].RevisionName)
+	require.Len(t, logs.Findings, 1)
+	assert.Equal(t, 3, logs.Findings[0].Count)
+	assert.Equal(t, "app-v3", logs.Findings[0].RevisionName)
+}
+
+func TestGetContainerAppSystemLogs_EventsWithoutTimestampLast(t *testing.T) {
+	app := newSystemLogsServer(t, map[string]string{
+		"": `{"data":[
+			{"eventType":"Normal","reason":"Pulled","message":"Image pulled","revisionName":"app-v1"},
+			{"timestamp":"2024-05-01T12:01:00Z","eventType":"Normal","reason":"Started","message":"Started container","revisionName":"app-v2"},
+			{"timestamp":"2024-05-01T12:00:00Z","eventType":"Normal","reason":"Created","message":"Created container","revisionName":"app-v2"}
+		]}`,
+	})
+
+	logs, err := app.GetContainerAppSystemLogs(context.Background(), "project", "app", domain.SystemLogOptions{MaxLines: 10})
+	require.NoError(t, err)
+	require.Len(t, logs.Data, 3)
+	assert.Equal(t, []string{"Created", "Started", "Pulled"}, []string{logs.Data[0].Reason, logs.Data[1].Reason, logs.Data[2].Reason})
+}
+
+func TestGetContainerAppSystemLogs_EmptyResponse(t *testing.T) {
+	for _, body := range []string{"", "{}", `{"data":null}`} {
+		app := newSystemLogsServer(t, map[string]string{"": body})
+
+		logs, err := app.GetContainerAppSystemLogs(context.Background(), "project", "app", domain.SystemLogOptions{})
+		require.NoError(t, err, body)
+		assert.Empty(t, logs.Data, body)
+		assert.Empty(t, logs.Findings, body)
+	}
+}
@@ -0,0 +1,121 @@
+package cloudru
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/Nick1994209/cloudru-containerapps-mcp/internal/domain"
+	"github.com/Nick1994209/cloudru-containerapps-mcp/internal/utils"
+)
+
+// volumePayload converts a bucket volume to the API format
+func volumePayload(volume domain.Volume) map[string]interface{} {
+	return map[string]interface{}{
+		"name": volume.Name,
+		"type": domain.VolumeTypeS3,
+		"volumeAttributes": map[string]string{
+			"bucketName": volume.BucketName,
+			"tenantId":   volume.TenantID,
+			"region":     volume.Region,
+			"entrypoint": volume.Entrypoint,
+			"readOnly":   strconv.FormatBool(volume.ReadOnly),
+		},
+	}
+}
+
+// parseVolumePayloads parses a JSON array of volumes and converts them to the API format
+func parseVolumePayloads(volumes string) ([]interface{}, error) {
+	parsed, err := utils.ParseVolumes(volumes)
+	if err != nil {
+		return nil, err
+	}
+	payloads := make([]interface{}, 0, len(parsed))
+	for _, volume := range parsed {
+		payloads = append(payloads, volumePayload(volume))
+	}
+	return payloads, nil
+}
+
+// removeVolumes removes volumes by name, a mounted volume is reported by checkVolumeMounts
+func removeVolumes(template map[string]interface{}, names []string) error {
+	volumes, _ := template["volumes"].([]interface{})
+	for _, name := range names {
+		var found bool
+		volumes, found = withoutName(volumes, name)
+		if !found {
+			return fmt.Errorf("volume %s not found", name)
+		}
+	}
+	template["volumes"] = volumes
+	return nil
+}
+
+// checkVolumeMounts checks that every volume mount of the containers and init containers references a declared volume
+func checkVolumeMounts(template map[string]interface{}) error {
+	declared := make(map[string]bool)
+	for _, volume := range templateItems(template, "volumes") {
+		declared[nameOf(volume)] = true
+	}
+
+	for _, key := range []string{"containers", "initContainers"} {
+		for _, container := range templateItems(template, key) {
+			mounts, err := volumeMounts(container)
+			if err != nil {
+				return err
+			}
+			for _, mount := range mounts {
+				if !declared[mount.Name] {
+					return fmt.Errorf("container %s mounts volume %s which is not declared, declared volumes: %s", nameOf(container), mount.Name, formatNames(declared))
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// templateItems returns containers or volumes of a template, created payloads use typed slices
+func templateItems(template map[string]interface{}, key string) []interface{} {
+	switch items := template[key].(type) {
+	case []interface{}:
+		return items
+	case []map[string]interface{}:
+		result := make([]interface{}, 0, len(items))
+		for _, item := range items {
+			result = append(result, item)
+		}
+		return result
+	}
+	return nil
+}
+
+// volumeMounts returns the volume mounts of a container in the API format
+func volumeMounts(container interface{}) ([]domain.VolumeMount, error) {
+	typed, ok := container.(map[string]interface{})
+	if !ok || typed["volumeMounts"] == nil {
+		return nil, nil
+	}
+	data, err := json.Marshal(typed["volumeMounts"])
+	if err != nil {
+		return nil, fmt.Errorf("failed to read volume mounts of container %s: %w", nameOf(container), err)
+	}
+	var mounts []domain.VolumeMount
+	if err := json.Unmarshal(data, &mounts); err != nil {
+		return nil, fmt.Errorf("failed to read volume mounts of container %s: %w", nameOf(container), err)
+	}
+	return mounts, nil
+}
+
+func formatNames(names map[string]bool) string {
+	if len(names) == 0 {
+		return "none"
+	}
+	sorted := make([]string, 0, len(names))
+	for name := range names {
+		sorted = append(sorted, name)
+	}
+	sort.Strings(sorted)
+	return strings.Join(sorted, ", ")
+}
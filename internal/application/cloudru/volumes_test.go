@@ -0,0 +1,55 @@
+package cloudru
+
+import (
+	"testing"
+
+	"github.com/Nick1994209/cloudru-containerapps-mcp/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseVolumePayloads(t *testing.T) {
+	volumes, err := parseVolumePayloads(`[{"name": "data", "bucketName": "my-bucket", "tenantId": "tenant", "readOnly": true}]`)
+	require.NoError(t, err)
+
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{
+			"name": "data",
+			"type": domain.VolumeTypeS3,
+			"volumeAttributes": map[string]string{
+				"bucketName": "my-bucket",
+				"tenantId":   "tenant",
+				"region":     "ru-central-1",
+				"entrypoint": "https://s3.cloud.ru",
+				"readOnly":   "true",
+			},
+		},
+	}, volumes)
+
+	_, err = parseVolumePayloads(`[{"name": "data", "bucket": "my-bucket"}]`)
+	assert.ErrorContains(t, err, `unknown field "bucket"`)
+	_, err = parseVolumePayloads(`[{"name": "data", "tenantId": "tenant"}]`)
+	assert.EqualError(t, err, "volume data: bucketName is required")
+}
+
+func TestCheckVolumeMounts(t *testing.T) {
+	template := map[string]interface{}{
+		"containers": []map[string]interface{}{
+			{"name": "app", "volumeMounts": []domain.VolumeMount{{Name: "data", MountPath: "/data"}}},
+		},
+		"initContainers": []interface{}{
+			map[string]interface{}{"name": "migrate", "volumeMounts": []interface{}{
+				map[string]interface{}{"name": "cache", "mountPath": "/cache", "readOnly": true},
+			}},
+		},
+		"volumes": []interface{}{
+			map[string]interface{}{"name": "data"},
+			map[string]interface{}{"name": "cache"},
+		},
+	}
+	require.NoError(t, checkVolumeMounts(template))
+
+	require.NoError(t, removeVolumes(template, []string{"cache"}))
+	assert.EqualError(t, checkVolumeMounts(template), "container migrate mounts volume cache which is not declared, declared volumes: data")
+	assert.EqualError(t, removeVolumes(template, []string{"missing"}), "volume missing not found")
+}
@@ -23,23 +23,47 @@ func (d *DescriptionApplication) GetDescription() string {
 1. cloudru_containerapps_description() - Returns usage instructions for this MCP
 2. cloudru_docker_login(registry_name) - Login to Cloud.ru Artifact registry (Docker registry)
 3. cloudru_docker_build_and_push(registry_name, repository_name, image_version, dockerfile_path, dockerfile_target, dockerfile_folder, show_commands) - Build and push Docker image to Cloud.ru Artifact Registry (Docker registry)
-4. cloudru_get_list_containerapps(project_id) - Get list of Container Apps from Cloud.ru. Project ID can be set via PROJECT_ID environment variable and obtained from console.cloud.ru
+4. cloudru_get_list_containerapps(project_id, page_size, page_token, filter, order_by, fetch_all, view, limit) - Get list of Container Apps from Cloud.ru. Project ID can be set via PROJECT_ID environment variable and obtained from console.cloud.ru
 5. cloudru_get_containerapp(project_id, containerapp_name) - Get a specific Container App from Cloud.ru by name. Project ID can be set via PROJECT_ID environment variable and obtained from console.cloud.ru
-6. cloudru_create_containerapp(project_id, containerapp_name, containerapp_port, containerapp_image, containerapp_auto_deployments_enabled, containerapp_auto_deployments_pattern, containerapp_privileged, containerapp_idle_timeout, containerapp_timeout, containerapp_cpu, containerapp_min_instance_count, containerapp_max_instance_count, containerapp_description, containerapp_publicly_accessible, containerapp_protocol, containerapp_environment_variables, containerapp_command, containerapp_args) - Create a new Container App in Cloud.ru
-7. cloudru_patch_containerapp(project_id, containerapp_name, containerapp_port, containerapp_image, containerapp_auto_deployments_enabled, containerapp_auto_deployments_pattern, containerapp_idle_timeout, containerapp_timeout, containerapp_cpu, containerapp_min_instance_count, containerapp_max_instance_count, containerapp_description, containerapp_publicly_accessible, containerapp_protocol, containerapp_environment_variables, containerapp_command, containerapp_args) - Patch an existing Container App in Cloud.ru. This function gets the current state, merges it with the new values, and updates the container app.
-8. cloudru_delete_containerapp(project_id, containerapp_name) - Delete a Container App from Cloud.ru. WARNING: This action cannot be undone!
-9. cloudru_start_containerapp(project_id, containerapp_name) - Start a Container App in Cloud.ru
-10. cloudru_stop_containerapp(project_id, containerapp_name) - Stop a Container App in Cloud.ru
-11. cloudru_get_containerapp_logs(project_id, containerapp_name) - Get logs for a specific Container App from Cloud.ru by name. Project ID can be set via PROJECT_ID environment variable and obtained from console.cloud.ru
+6. cloudru_create_containerapp(project_id, containerapp_name, containerapp_port, containerapp_image, containerapp_auto_deployments_enabled, containerapp_auto_deployments_pattern, containerapp_privileged, containerapp_idle_timeout, containerapp_timeout, containerapp_cpu, containerapp_memory, containerapp_min_instance_count, containerapp_max_instance_count, containerapp_description, containerapp_publicly_accessible, containerapp_protocol, containerapp_environment_variables, containerapp_command, containerapp_args, containerapp_sidecars, containerapp_init_containers, containerapp_volumes, containerapp_volume_mounts, wait, wait_timeout, dry_run) - Create a new Container App in Cloud.ru
+7. cloudru_patch_containerapp(project_id, containerapp_name, containerapp_port, containerapp_image, containerapp_auto_deployments_enabled, containerapp_auto_deployments_pattern, containerapp_idle_timeout, containerapp_timeout, containerapp_cpu, containerapp_memory, containerapp_min_instance_count, containerapp_max_instance_count, containerapp_description, containerapp_publicly_accessible, containerapp_protocol, containerapp_environment_variables, env_set, env_unset, env_rename, containerapp_command, containerapp_args, containerapp_container_name, containerapp_sidecars, containerapp_init_containers, containerapp_remove_containers, containerapp_volumes, containerapp_volume_mounts, containerapp_remove_volumes, wait, wait_timeout, dry_run) - Patch an existing Container App in Cloud.ru. This function gets the current state, merges it with the new values, and updates the container app.
+8. cloudru_delete_containerapp(project_id, containerapp_name, wait, wait_timeout, dry_run) - Delete a Container App from Cloud.ru. WARNING: This action cannot be undone!
+9. cloudru_start_containerapp(project_id, containerapp_name, wait, wait_timeout, dry_run) - Start a Container App in Cloud.ru
+10. cloudru_stop_containerapp(project_id, containerapp_name, wait, wait_timeout, dry_run) - Stop a Container App in Cloud.ru
+11. cloudru_get_containerapp_logs(project_id, containerapp_name, since, until, level, pod_name, version_id, container_name, search, max_lines, log_format) - Get logs for a specific Container App from Cloud.ru by name, filtered by time window, level, pod, version, container and message text, e.g. since=15m level=error log_format=text. Project ID can be set via PROJECT_ID environment variable and obtained from console.cloud.ru
 12. cloudru_get_list_docker_registries(project_id) - Get list of Docker Registries from Cloud.ru. Project ID can be set via PROJECT_ID environment variable and obtained from console.cloud.ru
-13. cloudru_create_docker_registry(project_id, registry_name, registry_is_public) - Create a new Docker Registry in Cloud.ru
-14. cloudru_jobs_list(project_id, page_size) - Get paginated list of jobs from Cloud.ru. Project ID can be set via PROJECT_ID environment variable and obtained from console.cloud.ru
-15. cloudru_create_job(project_id, job_name, job_image, job_privileged, job_cpu, job_description, job_environment_variables, job_command, job_args, job_retry_count, job_execution_timeout, job_run_immediately) - Create a new Job in Cloud.ru
-16. cloudru_patch_job(project_id, job_name, job_image, job_privileged, job_cpu, job_description, job_environment_variables, job_command, job_args, job_retry_count, job_execution_timeout, job_run_immediately) - Patch a Job in Cloud.ru. This will get the current state, merge with new values, and update the job.
-17. cloudru_execute_job(project_id, job_name, params) - Execute a Job in Cloud.ru by name. Project ID can be set via PROJECT_ID environment variable and obtained from console.cloud.ru
-18. cloudru_job_executions_list(project_id, job_name, page_size) - Get paginated list of job executions from Cloud.ru. Project ID can be set via PROJECT_ID environment variable and obtained from console.cloud.ru
+13. cloudru_create_docker_registry(project_id, registry_name, registry_is_public, dry_run) - Create a new Docker Registry in Cloud.ru
+14. cloudru_jobs_list(project_id, page_size, page_token, filter, order_by, fetch_all, view, limit) - Get paginated list of jobs from Cloud.ru. Project ID can be set via PROJECT_ID environment variable and obtained from console.cloud.ru
+15. cloudru_create_job(project_id, job_name, job_image, job_privileged, job_cpu, job_memory, job_description, job_environment_variables, job_command, job_args, job_retry_count, job_execution_timeout, job_run_immediately, wait, wait_timeout, dry_run) - Create a new Job in Cloud.ru
+16. cloudru_patch_job(project_id, job_name, job_image, job_privileged, job_cpu, job_memory, job_description, job_environment_variables, env_set, env_unset, env_rename, job_command, job_args, job_retry_count, job_execution_timeout, job_run_immediately, wait, wait_timeout, dry_run) - Patch a Job in Cloud.ru. This will get the current state, merge with new values, and update the job.
+17. cloudru_execute_job(project_id, job_name, params, dry_run) - Execute a Job in Cloud.ru by name. Project ID can be set via PROJECT_ID environment variable and obtained from console.cloud.ru
+18. cloudru_job_executions_list(project_id, job_name, page_size, page_token, filter, order_by, fetch_all) - Get paginated list of job executions from Cloud.ru. Project ID can be set via PROJECT_ID environment variable and obtained from console.cloud.ru
 19. cloudru_get_job(project_id, job_name) - Get a specific Job from Cloud.ru by name. Project ID can be set via PROJECT_ID environment variable and obtained from console.cloud.ru
-20. cloudru_delete_job(project_id, job_name) - Delete a Job from Cloud.ru. WARNING: This action cannot be undone!
+20. cloudru_delete_job(project_id, job_name, wait, wait_timeout, dry_run) - Delete a Job from Cloud.ru. WARNING: This action cannot be undone!
+21. cloudru_get_operation(operation_id) - Get the current state of a long-running operation returned by create, patch, delete, start and stop functions
+22. cloudru_wait_operation(operation_id, wait_timeout) - Wait until a long-running operation is done and return its final state or error
+23. cloudru_deploy(project_id, containerapp_name, registry_name, repository_name, image_version, dockerfile_path, dockerfile_target, dockerfile_folder, containerapp_port, containerapp_publicly_accessible, wait_timeout, dry_run) - Build and push the image, create the Container App or update its image pinned by digest, wait for the operation and return the public URI and status. With dry_run=true only the planned steps are reported. Prefer it over chaining build, get, create and patch functions
+24. cloudru_apply(manifest_path, project_id, wait, wait_timeout, dry_run) - Create or patch a Container App or a Job to match a cloudru.yaml manifest
+25. cloudru_diff(manifest_path, project_id) - Show field-level differences between a cloudru.yaml manifest and the live Container App or Job
+26. cloudru_export_containerapp(project_id, containerapp_name, export_format, target_project_id) - Export an existing Container App as a cloudru.yaml manifest or a ready-to-run create call without server-managed fields, e.g. to migrate it to another project
+27. cloudru_export_job(project_id, job_name, export_format, target_project_id) - Export an existing Job as a cloudru.yaml manifest or a ready-to-run create call without server-managed fields, e.g. to migrate it to another project
+28. cloudru_clone_containerapp(project_id, containerapp_name, target_project_id, target_containerapp_name, containerapp_image, containerapp_environment_variables, containerapp_min_instance_count, containerapp_max_instance_count, wait, wait_timeout, dry_run) - Clone a Container App into another project or under another name, optionally overriding the image, environment variables and scaling
+29. cloudru_clone_job(project_id, job_name, target_project_id, target_job_name, job_image, job_environment_variables, wait, wait_timeout, dry_run) - Clone a Job into another project or under another name, optionally overriding the image and environment variables
+30. cloudru_get_list_secrets(project_id) - Get list of Secret Manager secrets of a project, secret values are not returned
+31. cloudru_create_secret(project_id, secret_name, secret_value, secret_description) - Create a Secret Manager secret, e.g. for a database password, and reference its ID in an environment variable with the secret type
+32. cloudru_get_containerapp_system_logs(project_id, containerapp_name, event_type, component, revision_name, max_lines, log_format) - Get system events of a Container App with a short diagnosis of scheduling failures, image pull errors and OOM kills, use it when the container does not start
+33. cloudru_tail_containerapp_logs(project_id, containerapp_name, tail_duration, tail_interval, since, level, pod_name, version_id, container_name, search, max_lines, log_format) - Follow logs of a Container App for up to 10m, e.g. while a new revision rolls out, new lines are pushed as progress or logging notifications
+34. cloudru_get_job_execution(project_id, job_name, execution_name) - Get a Job execution with its status, start and finish time, exit code and failure reason
+35. cloudru_get_job_execution_logs(project_id, job_name, execution_name, since, until, level, search, max_lines, log_format) - Get logs of a Job execution filtered by time window, level and message text, e.g. level=error log_format=text to debug a failed run
+
+Create, patch, delete, start and stop functions return a pending operation. Pass wait=true to get the final state (or error) instead.
+Pass dry_run=true to create, clone, patch, delete, start, stop and execute functions to get the exact request payload and a diff against the current object without calling the API.
+Sidecars and init containers of a Container App are passed as a JSON array of {"name", "image", "cpu", "memory", "port", "env", "command", "args", "volumeMounts"}, pass containerapp_container_name to patch a container other than the main one.
+Object Storage buckets are declared with containerapp_volumes as a JSON array of {"name", "bucketName", "tenantId", "region", "entrypoint", "readOnly"} and mounted with containerapp_volume_mounts as a JSON array of {"name", "mountPath", "readOnly"}, every mount must reference a declared volume.
+CPU and memory must be an allowed Cloud.ru combination, an invalid pair is rejected, memory defaults to the default memory of the CPU.
+Environment variables can be passed as a JSON object {"NAME": "value", "PASSWORD": {"value": "<secret reference>", "type": "secret"}}, a JSON array of {name, value, type} or as <name>='<value>';<next_name>='value2'. Values of secret variables are masked as *** in all outputs, sending *** back without a type keeps the current secret reference. Create secrets with cloudru_create_secret instead of passing passwords as plain values. Patch functions keep the type of existing variables, use env_set, env_unset and env_rename to change single variables without re-sending all others.
+List functions return one page with nextPageToken, pass it as page_token to get the next page or use fetch_all=true to get all pages.
+List functions return a compact summary table by default, use view=full or the get functions to see all fields.
 
 Environment variables can be used as fallbacks for parameters:
 
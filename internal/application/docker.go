@@ -1,10 +1,10 @@
 package application
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
-	"io/ioutil"
-	"net/http"
+	"log"
 	"os/exec"
 	"strings"
 
@@ -17,25 +17,25 @@ import (
 type DockerApplication struct {
 	registryDomain string
 	creds          domain.Credentials
-	authService    domain.AuthService
+	client         *cloudru.Client
 }
 
 // NewDockerApplication creates a new DockerApplication with config
-func NewDockerApplication(cfg *config.Config) domain.DockerService {
+func NewDockerApplication(cfg *config.Config, client *cloudru.Client) domain.DockerService {
 	return &DockerApplication{
 		registryDomain: cfg.RegistryDomain,
 		creds: domain.Credentials{
 			KeyID:     cfg.KeyID,
 			KeySecret: cfg.KeySecret,
 		},
-		authService: cloudru.NewAuthApplication(cfg),
+		client: client,
 	}
 }
 
 // Login logs into the Cloud.ru Docker registry using Docker CLI
-func (d *DockerApplication) Login(registryName string) (string, error) {
+func (d *DockerApplication) Login(ctx context.Context, registryName string) (string, error) {
 	loginTarget := fmt.Sprintf("%s.%s", registryName, d.registryDomain)
-	cmd := exec.Command("docker", "login", loginTarget, "-u", d.creds.KeyID, "--password-stdin")
+	cmd := exec.CommandContext(ctx, "docker", "login", loginTarget, "-u", d.creds.KeyID, "--password-stdin")
 
 	// Create a pipe to send the password to stdin
 	stdin, err := cmd.StdinPipe()
@@ -58,9 +58,9 @@ func (d *DockerApplication) Login(registryName string) (string, error) {
 }
 
 // BuildAndPush builds and pushes a Docker image to Cloud.ru Artifact Registry
-func (d *DockerApplication) BuildAndPush(image domain.DockerImage) (string, error) {
+func (d *DockerApplication) BuildAndPush(ctx context.Context, image domain.DockerImage) (string, error) {
 	// Login to the Docker registry
-	if _, err := d.Login(image.RegistryName); err != nil {
+	if _, err := d.Login(ctx, image.RegistryName); err != nil {
 		return "", err
 	}
 
@@ -74,12 +74,12 @@ func (d *DockerApplication) BuildAndPush(image domain.DockerImage) (string, erro
 	// Split the build command string and execute it
 	buildCmdParts := strings.Fields(buildCmdStr)
 	if len(buildCmdParts) > 0 {
-		buildCmd := exec.Command(buildCmdParts[0], buildCmdParts[1:]...)
+		buildCmd := exec.CommandContext(ctx, buildCmdParts[0], buildCmdParts[1:]...)
 		buildOutput, buildErr := buildCmd.CombinedOutput()
 
-		// Always include build output in the response for visibility
+		// Build output goes to the log, stdout is reserved for the stdio transport
 		if len(buildOutput) > 0 {
-			fmt.Printf("Docker build output:\n%s\n", string(buildOutput))
+			log.Printf("Docker build output:\n%s", string(buildOutput))
 		}
 
 		if buildErr != nil {
@@ -91,12 +91,12 @@ func (d *DockerApplication) BuildAndPush(image domain.DockerImage) (string, erro
 	// Split the push command string and execute it
 	pushCmdParts := strings.Fields(pushCmdStr)
 	if len(pushCmdParts) > 0 {
-		pushCmd := exec.Command(pushCmdParts[0], pushCmdParts[1:]...)
+		pushCmd := exec.CommandContext(ctx, pushCmdParts[0], pushCmdParts[1:]...)
 		pushOutput, pushErr := pushCmd.CombinedOutput()
 
-		// Always include push output in the response for visibility
+		// Push output goes to the log, stdout is reserved for the stdio transport
 		if len(pushOutput) > 0 {
-			fmt.Printf("Docker push output:\n%s\n", string(pushOutput))
+			log.Printf("Docker push output:\n%s", string(pushOutput))
 		}
 
 		if pushErr != nil {
@@ -107,6 +107,37 @@ func (d *DockerApplication) BuildAndPush(image domain.DockerImage) (string, erro
 	return imageTag, nil
 }
 
+// GetImageDigestReference returns the pushed image reference pinned by digest, e.g. registry.cr.cloud.ru/app@sha256:...
+func (d *DockerApplication) GetImageDigestReference(ctx context.Context, imageTag string) (string, error) {
+	cmd := exec.CommandContext(ctx, "docker", "image", "inspect", "--format", "{{json .RepoDigests}}", imageTag)
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return "", fmt.Errorf("failed to inspect Docker image %s: %w\nOutput: %s", imageTag, err, string(output))
+	}
+
+	var repoDigests []string
+	if err := json.Unmarshal(output, &repoDigests); err != nil {
+		return "", fmt.Errorf("failed to parse repo digests of Docker image %s: %w output: %s", imageTag, err, string(output))
+	}
+
+	return findRepoDigest(imageTag, repoDigests)
+}
+
+// findRepoDigest picks the digest reference of the image repository, an image may have digests in several registries
+func findRepoDigest(imageTag string, repoDigests []string) (string, error) {
+	repository := imageTag
+	if slash, colon := strings.LastIndex(imageTag, "/"), strings.LastIndex(imageTag, ":"); colon > slash {
+		repository = imageTag[:colon]
+	}
+
+	for _, repoDigest := range repoDigests {
+		if strings.HasPrefix(repoDigest, repository+"@") {
+			return repoDigest, nil
+		}
+	}
+	return "", fmt.Errorf("image %s has no digest in repository %s, make sure it was pushed", imageTag, repository)
+}
+
 // generateImageTag creates the full image tag for a Docker image
 // If ImageVersion is empty, it defaults to "latest"
 func (d *DockerApplication) generateImageTag(image domain.DockerImage) string {
@@ -158,8 +189,8 @@ func (d *DockerApplication) generateCommands(image domain.DockerImage) (string,
 }
 
 // ShowBuildAndPushCommands returns the docker build and push commands as strings without executing them
-func (d *DockerApplication) ShowBuildAndPushCommands(image domain.DockerImage) (string, string, error) {
-	if _, err := d.Login(image.RegistryName); err != nil {
+func (d *DockerApplication) ShowBuildAndPushCommands(ctx context.Context, image domain.DockerImage) (string, string, error) {
+	if _, err := d.Login(ctx, image.RegistryName); err != nil {
 		return "", "", err
 	}
 
@@ -167,42 +198,21 @@ func (d *DockerApplication) ShowBuildAndPushCommands(image domain.DockerImage) (
 	return buildCmd, pushCmd, nil
 }
 
-// GetRegistryImages gets a list of images from a Docker registry using Cloud.ru API token
-func (d *DockerApplication) GetRegistryImages(registryName string) ([]domain.RegistryImage, error) {
-	// Get access token using AuthApplication
-	token, err := d.authService.GetAccessToken()
-	if err != nil {
-		return nil, fmt.Errorf("failed to get access token: %w", err)
-	}
+// GetBuildAndPushCommands returns the image tag and the docker build and push commands without logging in or executing them
+func (d *DockerApplication) GetBuildAndPushCommands(image domain.DockerImage) (string, string, string) {
+	buildCmd, pushCmd := d.generateCommands(image)
+	return d.generateImageTag(image), buildCmd, pushCmd
+}
 
+// GetRegistryImages gets a list of images from a Docker registry using Cloud.ru API token
+func (d *DockerApplication) GetRegistryImages(ctx context.Context, registryName string) ([]domain.RegistryImage, error) {
 	// now this is not working =(, we will fix later
 	// Use the Cloud.ru API token to access the registry
 	registryURL := fmt.Sprintf("https://%s.%s/v2/_catalog", registryName, d.registryDomain)
 
-	client := &http.Client{}
-	req, err := http.NewRequest("GET", registryURL, nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
-	}
-
-	// Use Bearer token for authentication
-	req.Header.Set("Authorization", "Bearer "+token)
-	req.Header.Set("Accept", "application/json")
-	req.Header.Set("User-Agent", "cloudru-containerapps-mcp/1.0")
-
-	resp, err := client.Do(req)
+	body, err := d.client.Do(ctx, "GET", registryURL, nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to make request to registry API: %w", err)
-	}
-	defer resp.Body.Close()
-
-	body, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read response body: %w", err)
-	}
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("registry API returned status %d: %s", resp.StatusCode, string(body))
+		return nil, fmt.Errorf("failed to get registry catalog: %w", err)
 	}
 
 	// Parse the catalog response
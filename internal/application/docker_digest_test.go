@@ -0,0 +1,33 @@
+package application
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindRepoDigest(t *testing.T) {
+	repoDigests := []string{
+		"docker.io/library/app@sha256:1111",
+		"my-registry.cr.cloud.ru/app@sha256:2222",
+	}
+
+	digest, err := findRepoDigest("my-registry.cr.cloud.ru/app:v1.0.0", repoDigests)
+
+	require.NoError(t, err)
+	assert.Equal(t, "my-registry.cr.cloud.ru/app@sha256:2222", digest)
+}
+
+func TestFindRepoDigest_RegistryWithPort(t *testing.T) {
+	digest, err := findRepoDigest("localhost:5000/app", []string{"localhost:5000/app@sha256:3333"})
+
+	require.NoError(t, err)
+	assert.Equal(t, "localhost:5000/app@sha256:3333", digest)
+}
+
+func TestFindRepoDigest_NotPushed(t *testing.T) {
+	_, err := findRepoDigest("my-registry.cr.cloud.ru/app:v1.0.0", []string{"docker.io/library/app@sha256:1111"})
+
+	assert.ErrorContains(t, err, "has no digest in repository my-registry.cr.cloud.ru/app")
+}
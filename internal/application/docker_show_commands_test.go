@@ -23,8 +23,8 @@ func TestDockerApplication_ShowBuildAndPushCommands(t *testing.T) {
 			KeyID:     cfg.KeyID,
 			KeySecret: cfg.KeySecret,
 		},
-		// We won't actually use the auth service in this test
-		authService: nil,
+		// We won't actually use the API client in this test
+		client: nil,
 	}
 
 	tests := []struct {
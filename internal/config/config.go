@@ -4,6 +4,8 @@ import (
 	"log"
 	"os"
 	"path/filepath"
+	"strconv"
+	"time"
 
 	"github.com/joho/godotenv"
 )
@@ -13,8 +15,33 @@ type APIURLs struct {
 	ContainersAPI string
 	IAMAPI        string
 	ArtifactAPI   string
+	OperationsAPI string
+	SecretsAPI    string
+	Timeout       time.Duration
+	Retry         RetryConfig
 }
 
+// RetryConfig holds the retry policy for transient Cloud.ru API failures
+type RetryConfig struct {
+	MaxAttempts int
+	BaseDelay   time.Duration
+	MaxDelay    time.Duration
+}
+
+// TransportConfig holds the settings of the MCP transport the server is exposed with
+type TransportConfig struct {
+	Type     string
+	Address  string
+	BasePath string
+}
+
+// Supported MCP transports
+const (
+	TransportStdio = "stdio"
+	TransportSSE   = "sse"
+	TransportHTTP  = "http"
+)
+
 // Config holds the configuration for the MCP
 type Config struct {
 	RegistryName     string
@@ -29,6 +56,7 @@ type Config struct {
 	ContainerAppName string
 	CurrentDir       string
 	API              APIURLs
+	Transport        TransportConfig
 }
 
 // EnvVarNames contains the names of environment variables
@@ -46,8 +74,20 @@ const (
 	EnvContainersAPI    = "CLOUDRU_CONTAINERS_API"
 	EnvIAMAPI           = "CLOUDRU_IAM_API"
 	EnvArtifactAPI      = "CLOUDRU_ARTIFACT_API"
+	EnvOperationsAPI    = "CLOUDRU_OPERATIONS_API"
+	EnvSecretsAPI       = "CLOUDRU_SECRETS_API"
+	EnvAPITimeout       = "CLOUDRU_API_TIMEOUT"
+	EnvAPIRetryAttempts = "CLOUDRU_API_RETRY_MAX_ATTEMPTS"
+	EnvAPIRetryBase     = "CLOUDRU_API_RETRY_BASE_DELAY"
+	EnvAPIRetryMax      = "CLOUDRU_API_RETRY_MAX_DELAY"
+	EnvMCPTransport     = "CLOUDRU_MCP_TRANSPORT"
+	EnvMCPAddress       = "CLOUDRU_MCP_ADDRESS"
+	EnvMCPBasePath      = "CLOUDRU_MCP_BASE_PATH"
 )
 
+// DefaultMCPAddress is the listen address of sse and http transports, reachable only from the local machine
+const DefaultMCPAddress = "127.0.0.1:8080"
+
 // LoadConfig loads configuration from environment variables and .env file
 func LoadConfig() *Config {
 	// Load .env file if it exists
@@ -91,12 +131,42 @@ A valid Key ID and Key Secret are necessary to access this service.`)
 		artifactAPI = "https://ar.api.cloud.ru"
 	}
 
+	operationsAPI := os.Getenv(EnvOperationsAPI)
+	if operationsAPI == "" {
+		operationsAPI = "https://operations.api.cloud.ru"
+	}
+
+	secretsAPI := os.Getenv(EnvSecretsAPI)
+	if secretsAPI == "" {
+		secretsAPI = "https://secretmanager.api.cloud.ru"
+	}
+
+	// Set default API request timeout and retry policy if environment variables are not provided or invalid
+	apiTimeout := getDurationEnv(EnvAPITimeout, 60*time.Second)
+	retryConfig := RetryConfig{
+		MaxAttempts: getIntEnv(EnvAPIRetryAttempts, 3),
+		BaseDelay:   getDurationEnv(EnvAPIRetryBase, 500*time.Millisecond),
+		MaxDelay:    getDurationEnv(EnvAPIRetryMax, 10*time.Second),
+	}
+
 	// Set default registry domain if environment variable is not provided
 	registryDomain := os.Getenv(EnvRegistryDomain)
 	if registryDomain == "" {
 		registryDomain = "cr.cloud.ru"
 	}
 
+	// Set default MCP transport if environment variables are not provided
+	transport := os.Getenv(EnvMCPTransport)
+	if transport == "" {
+		transport = TransportStdio
+	}
+
+	// Listen on loopback by default, HTTP transports have no authentication
+	address := os.Getenv(EnvMCPAddress)
+	if address == "" {
+		address = DefaultMCPAddress
+	}
+
 	return &Config{
 		RegistryName:     os.Getenv(EnvRegistryName),
 		RegistryDomain:   registryDomain,
@@ -113,6 +183,43 @@ A valid Key ID and Key Secret are necessary to access this service.`)
 			ContainersAPI: containersAPI,
 			IAMAPI:        iamAPI,
 			ArtifactAPI:   artifactAPI,
+			OperationsAPI: operationsAPI,
+			SecretsAPI:    secretsAPI,
+			Timeout:       apiTimeout,
+			Retry:         retryConfig,
 		},
+		Transport: TransportConfig{
+			Type:     transport,
+			Address:  address,
+			BasePath: os.Getenv(EnvMCPBasePath),
+		},
+	}
+}
+
+// getDurationEnv reads a positive Go duration (e.g. "30s") from an environment variable
+func getDurationEnv(name string, defaultValue time.Duration) time.Duration {
+	value := os.Getenv(name)
+	if value == "" {
+		return defaultValue
+	}
+	parsed, err := time.ParseDuration(value)
+	if err != nil || parsed <= 0 {
+		log.Printf("Invalid %s value %q, using default %s", name, value, defaultValue)
+		return defaultValue
+	}
+	return parsed
+}
+
+// getIntEnv reads a positive integer from an environment variable
+func getIntEnv(name string, defaultValue int) int {
+	value := os.Getenv(name)
+	if value == "" {
+		return defaultValue
+	}
+	parsed, err := strconv.Atoi(value)
+	if err != nil || parsed <= 0 {
+		log.Printf("Invalid %s value %q, using default %d", name, value, defaultValue)
+		return defaultValue
 	}
+	return parsed
 }
@@ -0,0 +1,39 @@
+package domain
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+)
+
+// ErrDryRun is returned instead of the response of a mutating request which was not sent in dry-run mode
+var ErrDryRun = errors.New("dry run: the request was not sent")
+
+// DryRunRequest is a mutating API request recorded in dry-run mode
+type DryRunRequest struct {
+	Method  string          `json:"method"`
+	URL     string          `json:"url"`
+	Payload json.RawMessage `json:"payload,omitempty"`
+}
+
+// DryRun records the mutating API request a service would send. Read requests are sent as usual,
+// Current is the state of the object the request would change, nil for new objects
+type DryRun struct {
+	Request *DryRunRequest
+	Current json.RawMessage
+}
+
+type dryRunContextKey struct{}
+
+// WithDryRun returns a context in which services record their mutating request to dryRun and return ErrDryRun
+// right after the payload is built, instead of sending it.
+// A nil dryRun turns dry-run mode off
+func WithDryRun(ctx context.Context, dryRun *DryRun) context.Context {
+	return context.WithValue(ctx, dryRunContextKey{}, dryRun)
+}
+
+// DryRunFromContext returns the dry-run recorder of ctx, nil if dry-run mode is off
+func DryRunFromContext(ctx context.Context) *DryRun {
+	dryRun, _ := ctx.Value(dryRunContextKey{}).(*DryRun)
+	return dryRun
+}
@@ -0,0 +1,6 @@
+package domain
+
+import "errors"
+
+// ErrNotFound is matched by errors.Is when a requested Cloud.ru resource does not exist
+var ErrNotFound = errors.New("resource not found")
@@ -1,5 +1,10 @@
 package domain
 
+import (
+	"context"
+	"time"
+)
+
 // DescriptionService provides usage instructions for the MCP
 type DescriptionService interface {
 	GetDescription() string
@@ -7,43 +12,63 @@ type DescriptionService interface {
 
 // DockerService handles Docker operations
 type DockerService interface {
-	Login(registryName string) (string, error)
-	BuildAndPush(image DockerImage) (string, error)
-	ShowBuildAndPushCommands(image DockerImage) (string, string, error)
-	GetRegistryImages(registryName string) ([]RegistryImage, error)
+	Login(ctx context.Context, registryName string) (string, error)
+	BuildAndPush(ctx context.Context, image DockerImage) (string, error)
+	ShowBuildAndPushCommands(ctx context.Context, image DockerImage) (string, string, error)
+	GetBuildAndPushCommands(image DockerImage) (string, string, string)
+	GetImageDigestReference(ctx context.Context, imageTag string) (string, error)
+	GetRegistryImages(ctx context.Context, registryName string) ([]RegistryImage, error)
 }
 
 // AuthService handles authentication operations
 type AuthService interface {
-	GetAccessToken() (string, error)
+	GetAccessToken(ctx context.Context) (string, error)
 }
 
 // ContainerAppsService handles Cloud.ru Container Apps API operations
 type ContainerAppsService interface {
-	GetListContainerApps(projectID string) ([]ContainerApp, error)
-	GetContainerApp(projectID string, containerAppName string) (*ContainerApp, error)
-	CreateContainerApp(request CreateContainerAppRequest) (*Operation, error)
-	PatchContainerApp(projectID string, containerAppName string, request PatchContainerAppRequest) (*Operation, error)
-	DeleteContainerApp(projectID string, containerAppName string) (*Operation, error)
-	StartContainerApp(projectID string, containerAppName string) (*Operation, error)
-	StopContainerApp(projectID string, containerAppName string) (*Operation, error)
-	GetContainerAppLogs(projectID string, containerAppName string) (*ContainerAppLogs, error)
-	GetContainerAppSystemLogs(projectID string, containerAppName string) (*ContainerAppSystemLogs, error)
+	GetListContainerApps(ctx context.Context, projectID string, options ListOptions) (*ContainerAppsPage, error)
+	GetContainerApp(ctx context.Context, projectID string, containerAppName string) (*ContainerApp, error)
+	GetContainerAppRaw(ctx context.Context, projectID string, containerAppName string) ([]byte, error)
+	CreateContainerApp(ctx context.Context, request CreateContainerAppRequest) (*Operation, error)
+	CreateContainerAppFromObject(ctx context.Context, projectID string, object []byte) (*Operation, error)
+	PatchContainerApp(ctx context.Context, projectID string, containerAppName string, request PatchContainerAppRequest) (*Operation, error)
+	DeleteContainerApp(ctx context.Context, projectID string, containerAppName string) (*Operation, error)
+	StartContainerApp(ctx context.Context, projectID string, containerAppName string) (*Operation, error)
+	StopContainerApp(ctx context.Context, projectID string, containerAppName string) (*Operation, error)
+	GetContainerAppLogs(ctx context.Context, projectID string, containerAppName string, options LogOptions) (*ContainerAppLogs, error)
+	GetContainerAppSystemLogs(ctx context.Context, projectID string, containerAppName string, options SystemLogOptions) (*ContainerAppSystemLogs, error)
 }
 
 // ArtifactRegistryService handles Cloud.ru Artifact Registry API operations
 type ArtifactRegistryService interface {
-	GetListDockerRegistries(projectID string) ([]DockerRegistry, error)
-	CreateDockerRegistry(projectID string, registryName string, isPublic bool) (*DockerRegistry, error)
+	GetListDockerRegistries(ctx context.Context, projectID string) ([]DockerRegistry, error)
+	CreateDockerRegistry(ctx context.Context, projectID string, registryName string, isPublic bool) (*DockerRegistry, error)
 }
 
 // JobsService handles Cloud.ru Jobs API operations
 type JobsService interface {
-	GetListJobs(projectID string, pageSize string) ([]Job, error)
-	GetJob(projectID string, jobName string) (*Job, error)
-	CreateJob(request CreateJobRequest) (*Operation, error)
-	PatchJob(projectID string, jobName string, request PatchJobRequest) (*Operation, error)
-	DeleteJob(projectID string, jobName string) (*Operation, error)
-	ExecuteJob(projectID string, jobName string, params map[string]interface{}) (*JobExecution, error)
-	GetListExecutions(projectID string, jobName string, pageSize string) ([]JobExecution, error)
+	GetListJobs(ctx context.Context, projectID string, options ListOptions) (*JobsPage, error)
+	GetJob(ctx context.Context, projectID string, jobName string) (*Job, error)
+	GetJobRaw(ctx context.Context, projectID string, jobName string) ([]byte, error)
+	CreateJob(ctx context.Context, request CreateJobRequest) (*Operation, error)
+	CreateJobFromObject(ctx context.Context, projectID string, object []byte) (*Operation, error)
+	PatchJob(ctx context.Context, projectID string, jobName string, request PatchJobRequest) (*Operation, error)
+	DeleteJob(ctx context.Context, projectID string, jobName string) (*Operation, error)
+	ExecuteJob(ctx context.Context, projectID string, jobName string, params map[string]interface{}) (*JobExecution, error)
+	GetListExecutions(ctx context.Context, projectID string, jobName string, options ListOptions) (*JobExecutionsPage, error)
+	GetJobExecution(ctx context.Context, projectID string, jobName string, executionName string) (*JobExecution, error)
+	GetJobExecutionLogs(ctx context.Context, projectID string, jobName string, executionName string, options LogOptions) (*JobExecutionLogs, error)
+}
+
+// SecretsService handles Cloud.ru Secret Manager API operations
+type SecretsService interface {
+	GetListSecrets(ctx context.Context, projectID string) ([]Secret, error)
+	CreateSecret(ctx context.Context, request CreateSecretRequest) (*Secret, error)
+}
+
+// OperationsService handles Cloud.ru long-running operations
+type OperationsService interface {
+	GetOperation(ctx context.Context, operationID string) (*Operation, error)
+	WaitOperation(ctx context.Context, operationID string, timeout time.Duration) (*Operation, error)
 }
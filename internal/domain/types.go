@@ -28,6 +28,7 @@ type CreateContainerAppRequest struct {
 	IdleTimeout            string   `json:"idleTimeout"`
 	Timeout                string   `json:"timeout"`
 	CPU                    string   `json:"cpu"`
+	Memory                 string   `json:"memory"`
 	MinInstanceCount       int      `json:"minInstanceCount"`
 	MaxInstanceCount       int      `json:"maxInstanceCount"`
 	Description            string   `json:"description"`
@@ -36,27 +37,51 @@ type CreateContainerAppRequest struct {
 	EnvironmentVariables   string   `json:"environmentVariables"`
 	Command                []string `json:"command"`
 	Args                   []string `json:"args"`
+	// Sidecars and InitContainers are JSON arrays of ContainerSpec
+	Sidecars       string `json:"sidecars"`
+	InitContainers string `json:"initContainers"`
+	// Volumes is a JSON array of Volume, VolumeMounts is a JSON array of VolumeMount of the main container
+	Volumes      string `json:"volumes"`
+	VolumeMounts string `json:"volumeMounts"`
 }
 
 // PatchContainerAppRequest represents a request to patch a Container App
 type PatchContainerAppRequest struct {
-	ProjectID              string   `json:"projectId"`
-	ContainerAppName       string   `json:"containerAppName"`
-	ContainerAppPort       *int     `json:"containerAppPort"`
-	ContainerAppImage      *string  `json:"containerAppImage"`
-	AutoDeploymentsEnabled *bool    `json:"autoDeploymentsEnabled"`
-	AutoDeploymentsPattern *string  `json:"autoDeploymentsPattern"`
-	IdleTimeout            *string  `json:"idleTimeout"`
-	Timeout                *string  `json:"timeout"`
-	CPU                    *string  `json:"cpu"`
-	MinInstanceCount       *int     `json:"minInstanceCount"`
-	MaxInstanceCount       *int     `json:"maxInstanceCount"`
-	Description            *string  `json:"description"`
-	PubliclyAccessible     *bool    `json:"publiclyAccessible"`
-	Protocol               *string  `json:"protocol"`
-	EnvironmentVariables   *string  `json:"environmentVariables"`
-	Command                []string `json:"command"`
-	Args                   []string `json:"args"`
+	ProjectID              string  `json:"projectId"`
+	ContainerAppName       string  `json:"containerAppName"`
+	ContainerAppPort       *int    `json:"containerAppPort"`
+	ContainerAppImage      *string `json:"containerAppImage"`
+	AutoDeploymentsEnabled *bool   `json:"autoDeploymentsEnabled"`
+	AutoDeploymentsPattern *string `json:"autoDeploymentsPattern"`
+	Privileged             *bool   `json:"privileged"`
+	IdleTimeout            *string `json:"idleTimeout"`
+	Timeout                *string `json:"timeout"`
+	CPU                    *string `json:"cpu"`
+	Memory                 *string `json:"memory"`
+	MinInstanceCount       *int    `json:"minInstanceCount"`
+	MaxInstanceCount       *int    `json:"maxInstanceCount"`
+	Description            *string `json:"description"`
+	PubliclyAccessible     *bool   `json:"publiclyAccessible"`
+	Protocol               *string `json:"protocol"`
+	EnvironmentVariables   *string `json:"environmentVariables"`
+	// EnvironmentVariableChanges are merged into the current variables after EnvironmentVariables are applied
+	EnvironmentVariableChanges EnvironmentVariableChanges `json:"environmentVariableChanges"`
+	// Command and Args are left as is when nil, empty slices reset them
+	Command []string `json:"command"`
+	Args    []string `json:"args"`
+	// ContainerName is the container which image, port, resources, env, command and args are patched, the first container if empty
+	ContainerName string `json:"containerName"`
+	// Sidecars and InitContainers are JSON arrays of ContainerSpec, containers with the same name are replaced and others are added
+	Sidecars       *string `json:"sidecars"`
+	InitContainers *string `json:"initContainers"`
+	// RemoveContainers are names of sidecars or init containers to remove
+	RemoveContainers []string `json:"removeContainers"`
+	// Volumes is a JSON array of Volume, volumes with the same name are replaced and others are added
+	Volumes *string `json:"volumes"`
+	// VolumeMounts is a JSON array of VolumeMount which replaces the mounts of the patched container
+	VolumeMounts *string `json:"volumeMounts"`
+	// RemoveVolumes are names of volumes to remove, they must not be mounted
+	RemoveVolumes []string `json:"removeVolumes"`
 }
 
 // ContainerApp represents a Cloud.ru Container App
@@ -66,8 +91,11 @@ type ContainerApp struct {
 	Name          string `json:"name"`
 	Description   string `json:"description"`
 	Status        string `json:"status"`
+	CreatedAt     string `json:"createdAt"`
+	UpdatedAt     string `json:"updatedAt"`
 	Configuration struct {
-		Ingress struct {
+		Privileged bool `json:"privileged"`
+		Ingress    struct {
 			PubliclyAccessible bool   `json:"publiclyAccessible"`
 			PublicUri          string `json:"publicUri"`
 			//InternalUri            string        `json:"internalUri"`
@@ -100,19 +128,11 @@ type ContainerApp struct {
 				CPU    string `json:"cpu"`
 				Memory string `json:"memory"`
 			} `json:"resources"`
-			ContainerPort int `json:"containerPort"`
-			Env           []struct {
-				Name  string `json:"name"`
-				Value string `json:"value"`
-				Type  string `json:"type,omitempty"`
-			} `json:"env"`
-			Command      []interface{} `json:"command"`
-			Args         []interface{} `json:"args"`
-			VolumeMounts []struct {
-				Name      string `json:"name"`
-				MountPath string `json:"mountPath"`
-				ReadOnly  bool   `json:"readOnly"`
-			} `json:"volumeMounts"`
+			ContainerPort int                   `json:"containerPort"`
+			Env           []EnvironmentVariable `json:"env"`
+			Command       []interface{}         `json:"command"`
+			Args          []interface{}         `json:"args"`
+			VolumeMounts  []VolumeMount         `json:"volumeMounts"`
 		} `json:"containers"`
 		InitContainers []interface{} `json:"initContainers"`
 		Volumes        []struct {
@@ -129,6 +149,80 @@ type ContainerApp struct {
 	} `json:"template"`
 }
 
+// Environment variable types
+const (
+	EnvironmentVariableTypePlain  = "plain"
+	EnvironmentVariableTypeSecret = "secret"
+)
+
+// EnvironmentVariableMask replaces values of secret environment variables in tool outputs
+const EnvironmentVariableMask = "***"
+
+// EnvironmentVariable represents an environment variable of a container.
+// Value of a secret variable is a reference to a Secret Manager secret, an empty Type means a plain value
+type EnvironmentVariable struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+	Type  string `json:"type,omitempty"`
+}
+
+// EnvironmentVariableChanges are incremental changes of the container environment variables applied on patch.
+// Variables are renamed first, then unset and then set
+type EnvironmentVariableChanges struct {
+	// Set adds or updates variables, in any format of the environment variables parameters.
+	// Variables without a type keep the type of the current variable
+	Set string `json:"set,omitempty"`
+	// Unset removes variables by name, missing variables are ignored
+	Unset []string `json:"unset,omitempty"`
+	// Rename renames variables from the key to the value, keeping their values and types
+	Rename map[string]string `json:"rename,omitempty"`
+}
+
+// IsEmpty reports whether there are no changes
+func (c EnvironmentVariableChanges) IsEmpty() bool {
+	return c.Set == "" && len(c.Unset) == 0 && len(c.Rename) == 0
+}
+
+// ContainerSpec describes a sidecar or an init container of a Container App
+type ContainerSpec struct {
+	Name    string                `json:"name"`
+	Image   string                `json:"image"`
+	CPU     string                `json:"cpu,omitempty"`
+	Memory  string                `json:"memory,omitempty"`
+	Port    int                   `json:"port,omitempty"`
+	Env     []EnvironmentVariable `json:"env,omitempty"`
+	Command []string              `json:"command,omitempty"`
+	Args    []string              `json:"args,omitempty"`
+	// VolumeMounts mount volumes of the Container App into the container
+	VolumeMounts []VolumeMount `json:"volumeMounts,omitempty"`
+}
+
+// VolumeTypeS3 is the type of volumes backed by an S3-compatible Object Storage bucket
+const VolumeTypeS3 = "s3"
+
+// Volume is an S3-compatible Object Storage bucket which can be mounted into the containers of a Container App
+type Volume struct {
+	Name       string `json:"name"`
+	BucketName string `json:"bucketName"`
+	TenantID   string `json:"tenantId"`
+	Region     string `json:"region"`
+	Entrypoint string `json:"entrypoint"`
+	ReadOnly   bool   `json:"readOnly"`
+}
+
+// VolumeMount mounts a volume into a container
+type VolumeMount struct {
+	Name      string `json:"name"`
+	MountPath string `json:"mountPath"`
+	ReadOnly  bool   `json:"readOnly"`
+}
+
+// ContainerAppsPage represents a page of Container Apps returned by a list request
+type ContainerAppsPage struct {
+	Data          []ContainerApp `json:"data"`
+	NextPageToken string         `json:"nextPageToken,omitempty"`
+}
+
 // DockerRegistry represents a Cloud.ru Docker Registry
 type DockerRegistry struct {
 	ID                       string `json:"id"`
@@ -141,11 +235,52 @@ type DockerRegistry struct {
 	Status                   string `json:"status"`
 	IsPublic                 bool   `json:"isPublic"`
 	QuarantineMode           string `json:"quarantineMode"`
+	// Operation of the create request, set when the API creates the registry asynchronously
+	Operation *Operation `json:"operation,omitempty"`
+}
+
+// Secret represents a Cloud.ru Secret Manager secret, the value is never returned
+type Secret struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	CreatedAt   string `json:"createdAt"`
+	UpdatedAt   string `json:"updatedAt"`
 }
 
-// ContainerAppLogs represents the logs response from Cloud.ru Container App
+// CreateSecretRequest represents a request to create a Secret Manager secret
+type CreateSecretRequest struct {
+	ProjectID   string `json:"projectId"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Value       string `json:"-"`
+}
+
+// ContainerAppLogs represents the logs response from Cloud.ru Container App.
+// Total is the number of entries matching the log options, Truncated is set when only the latest MaxLines of them are returned.
+// The log options are applied to the entries returned by the API, Partial is set when they do not reach back to Since,
+// AvailableSince is then the oldest returned timestamp
 type ContainerAppLogs struct {
-	Data []ContainerAppLogEntry `json:"data"`
+	Data           []ContainerAppLogEntry `json:"data"`
+	Total          int                    `json:"total,omitempty"`
+	Truncated      bool                   `json:"truncated,omitempty"`
+	Partial        bool                   `json:"partial,omitempty"`
+	AvailableSince string                 `json:"availableSince,omitempty"`
+}
+
+// LogOptions holds filters of log requests, empty filters match all entries.
+// Since and Until are RFC3339 timestamps or durations before now, e.g. 15m.
+// Level is a comma-separated list of levels, Text is a case-insensitive substring of the message
+type LogOptions struct {
+	Since         string
+	Until         string
+	Level         string
+	PodName       string
+	VersionID     string
+	ContainerName string
+	Text          string
+	// MaxLines limits the result to the latest matching entries, 0 means no limit
+	MaxLines int
 }
 
 // ContainerAppLogEntry represents a single log entry
@@ -158,13 +293,45 @@ type ContainerAppLogEntry struct {
 	ContainerName string `json:"containerName"`
 }
 
-// ContainerAppSystemLogs represents the system logs response from Cloud.ru Container App
+// ContainerAppSystemLogs represents the system logs response from Cloud.ru Container App.
+// Total, Truncated and Findings describe the entries matching the system log options
 type ContainerAppSystemLogs struct {
-	Data []ContainerAppSystemLogEntry `json:"data"`
+	Data          []ContainerAppSystemLogEntry `json:"data"`
+	NextPageToken string                       `json:"nextPageToken,omitempty"`
+	Total         int                          `json:"total,omitempty"`
+	Truncated     bool                         `json:"truncated,omitempty"`
+	Findings      []SystemLogFinding           `json:"findings,omitempty"`
+}
+
+// SystemLogOptions holds filters of system log requests, empty filters match all events
+type SystemLogOptions struct {
+	EventType    string
+	Component    string
+	RevisionName string
+	// MaxLines limits the result to the latest matching events, 0 means no limit
+	MaxLines int
+}
+
+// System log finding categories
+const (
+	SystemLogFindingScheduling = "scheduling"
+	SystemLogFindingImagePull  = "image_pull"
+	SystemLogFindingOOM        = "oom"
+)
+
+// SystemLogFinding summarises system events of a known failure category
+type SystemLogFinding struct {
+	Category     string `json:"category"`
+	Count        int    `json:"count"`
+	Reason       string `json:"reason"`
+	Message      string `json:"message"`
+	RevisionName string `json:"revisionName,omitempty"`
+	Hint         string `json:"hint"`
 }
 
 // ContainerAppSystemLogEntry represents a single system log entry
 type ContainerAppSystemLogEntry struct {
+	Timestamp    string `json:"timestamp,omitempty"`
 	EventType    string `json:"eventType"`
 	Component    string `json:"component"`
 	Reason       string `json:"reason"`
@@ -184,10 +351,20 @@ type RegistryImage struct {
 
 // Operation represents a long-running operation
 type Operation struct {
-	ResourceName string `json:"resourceName"`
-	ResourceID   string `json:"resourceId"`
-	Description  string `json:"description"`
-	Done         bool   `json:"done"`
+	ID           string          `json:"id,omitempty"`
+	ResourceName string          `json:"resourceName"`
+	ResourceID   string          `json:"resourceId"`
+	Description  string          `json:"description"`
+	CreatedAt    string          `json:"createdAt,omitempty"`
+	ModifiedAt   string          `json:"modifiedAt,omitempty"`
+	Done         bool            `json:"done"`
+	Error        *OperationError `json:"error,omitempty"`
+}
+
+// OperationError represents the error of a failed long-running operation
+type OperationError struct {
+	Code    interface{} `json:"code,omitempty"`
+	Message string      `json:"message"`
 }
 
 // RegistryImagesResponse represents the response from registry images API
@@ -197,16 +374,18 @@ type RegistryImagesResponse struct {
 
 // Job represents a Cloud.ru Job
 type Job struct {
-	ProjectID     string `json:"projectId"`
-	ID            string `json:"id"`
-	Name          string `json:"name"`
-	Description   string `json:"description"`
-	Status        string `json:"status"`
-	CreatedAt     string `json:"createdAt"`
-	CreatedBy     string `json:"createdBy"`
-	UpdatedAt     string `json:"updatedAt"`
-	UpdatedBy     string `json:"updatedBy"`
-	Configuration struct {
+	ProjectID   string `json:"projectId"`
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Status      string `json:"status"`
+	CreatedAt   string `json:"createdAt"`
+	CreatedBy   string `json:"createdBy"`
+	UpdatedAt   string `json:"updatedAt"`
+	UpdatedBy   string `json:"updatedBy"`
+	// RunImmediately is nil when the API does not return it
+	RunImmediately *bool `json:"runImmediately,omitempty"`
+	Configuration  struct {
 		Privileged     bool `json:"privileged"`
 		LoggingService struct {
 			Enabled bool   `json:"enabled"`
@@ -214,7 +393,9 @@ type Job struct {
 		} `json:"loggingService"`
 	} `json:"configuration"`
 	Template struct {
+		// Timeout and MaxRetries of an execution are returned as numbers or strings, e.g. "3600s"
 		Timeout     interface{} `json:"timeout"`
+		MaxRetries  interface{} `json:"maxRetries,omitempty"`
 		IdleTimeout string      `json:"idleTimeout"`
 		Protocol    string      `json:"protocol"`
 		Scaling     struct {
@@ -228,14 +409,10 @@ type Job struct {
 				CPU    string `json:"cpu"`
 				Memory string `json:"memory"`
 			} `json:"resources"`
-			ContainerPort int `json:"containerPort"`
-			Env           []struct {
-				Name  string `json:"name"`
-				Value string `json:"value"`
-				Type  string `json:"type,omitempty"`
-			} `json:"env"`
-			Command []interface{} `json:"command"`
-			Args    []interface{} `json:"args"`
+			ContainerPort int                   `json:"containerPort"`
+			Env           []EnvironmentVariable `json:"env"`
+			Command       []interface{}         `json:"command"`
+			Args          []interface{}         `json:"args"`
 		} `json:"containers"`
 	} `json:"template"`
 }
@@ -246,6 +423,39 @@ type JobExecution struct {
 	ExecutionStatus string `json:"executionStatus"`
 	CreatedAt       string `json:"createdAt"`
 	UpdatedAt       string `json:"updatedAt"`
+	// Details returned by the get execution request, they explain why an execution failed
+	StartedAt  string `json:"startedAt,omitempty"`
+	FinishedAt string `json:"finishedAt,omitempty"`
+	ExitCode   *int   `json:"exitCode,omitempty"`
+	Reason     string `json:"reason,omitempty"`
+	Message    string `json:"message,omitempty"`
+	// Operation of the execute request, set when the API starts the execution asynchronously
+	Operation *Operation `json:"operation,omitempty"`
+}
+
+// JobExecutionLogs represents the logs response of a Job execution, entries have the Container App log format
+type JobExecutionLogs = ContainerAppLogs
+
+// JobsPage represents a page of Jobs returned by a list request
+type JobsPage struct {
+	Data          []Job  `json:"data"`
+	NextPageToken string `json:"nextPageToken,omitempty"`
+}
+
+// JobExecutionsPage represents a page of Job Executions returned by a list request
+type JobExecutionsPage struct {
+	Data          []JobExecution `json:"data"`
+	NextPageToken string         `json:"nextPageToken,omitempty"`
+}
+
+// ListOptions holds pagination, filtering and sorting parameters of list requests
+type ListOptions struct {
+	PageSize  string
+	PageToken string
+	Filter    string
+	OrderBy   string
+	// FetchAll requests all pages starting from PageToken and merges them into a single page
+	FetchAll bool
 }
 
 // CreateJobRequest represents a request to create a Job
@@ -255,6 +465,7 @@ type CreateJobRequest struct {
 	JobImage                string   `json:"jobImage"`
 	JobPrivileged           bool     `json:"jobPrivileged"`
 	JobCPU                  string   `json:"jobCPU"`
+	JobMemory               string   `json:"jobMemory"`
 	JobDescription          string   `json:"jobDescription"`
 	JobEnvironmentVariables string   `json:"jobEnvironmentVariables"`
 	JobCommand              []string `json:"jobCommand"`
@@ -266,16 +477,20 @@ type CreateJobRequest struct {
 
 // PatchJobRequest represents a request to patch a Job
 type PatchJobRequest struct {
-	ProjectID               string   `json:"projectId"`
-	JobName                 string   `json:"jobName"`
-	JobImage                *string  `json:"jobImage"`
-	JobPrivileged           *bool    `json:"jobPrivileged"`
-	JobCPU                  *string  `json:"jobCPU"`
-	JobDescription          *string  `json:"jobDescription"`
-	JobEnvironmentVariables *string  `json:"jobEnvironmentVariables"`
-	JobCommand              []string `json:"jobCommand"`
-	JobArgs                 []string `json:"jobArgs"`
-	JobRetryCount           *uint32  `json:"jobRetryCount"`
-	JobExecutionTimeout     *uint32  `json:"jobExecutionTimeout"`
-	JobRunImmediately       *bool    `json:"jobRunImmediately"`
+	ProjectID               string  `json:"projectId"`
+	JobName                 string  `json:"jobName"`
+	JobImage                *string `json:"jobImage"`
+	JobPrivileged           *bool   `json:"jobPrivileged"`
+	JobCPU                  *string `json:"jobCPU"`
+	JobMemory               *string `json:"jobMemory"`
+	JobDescription          *string `json:"jobDescription"`
+	JobEnvironmentVariables *string `json:"jobEnvironmentVariables"`
+	// JobEnvironmentVariableChanges are merged into the current variables after JobEnvironmentVariables are applied
+	JobEnvironmentVariableChanges EnvironmentVariableChanges `json:"jobEnvironmentVariableChanges"`
+	// JobCommand and JobArgs are left as is when nil, empty slices reset them
+	JobCommand          []string `json:"jobCommand"`
+	JobArgs             []string `json:"jobArgs"`
+	JobRetryCount       *uint32  `json:"jobRetryCount"`
+	JobExecutionTimeout *uint32  `json:"jobExecutionTimeout"`
+	JobRunImmediately   *bool    `json:"jobRunImmediately"`
 }
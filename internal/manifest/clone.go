@@ -0,0 +1,84 @@
+package manifest
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/Nick1994209/cloudru-containerapps-mcp/internal/domain"
+	"github.com/Nick1994209/cloudru-containerapps-mcp/internal/utils"
+)
+
+// Overrides are the fields replaced when a Container App or a Job is cloned, nil fields are copied from the source
+type Overrides struct {
+	Image *string
+	// EnvironmentVariables in any format of utils.ParseEnvironmentVariables are merged into the source variables,
+	// variables without a type keep the type of the source variable
+	EnvironmentVariables *string
+	MinInstanceCount     *int
+	MaxInstanceCount     *int
+}
+
+// CloneObject builds the create payload of a clone from the raw object returned by the API.
+// Server-managed fields are stripped and the overrides are applied to the first container, all other settings
+// (privileged, sidecars, init containers, volumes) are copied as is
+func CloneObject(raw []byte, name string, overrides Overrides) (json.RawMessage, error) {
+	stripped, err := StripServerFields(raw)
+	if err != nil {
+		return nil, err
+	}
+	var object map[string]interface{}
+	if err := json.Unmarshal(stripped, &object); err != nil {
+		return nil, fmt.Errorf("failed to parse object: %w", err)
+	}
+
+	template, _ := object["template"].(map[string]interface{})
+	containers, _ := template["containers"].([]interface{})
+	if len(containers) == 0 {
+		return nil, fmt.Errorf("%v has no containers", object["name"])
+	}
+	container, ok := containers[0].(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("%v has an invalid container", object["name"])
+	}
+	object["name"] = name
+
+	if overrides.Image != nil {
+		container["image"] = *overrides.Image
+	}
+	if overrides.EnvironmentVariables != nil {
+		variables, err := utils.ParseEnvironmentVariables(*overrides.EnvironmentVariables)
+		if err != nil {
+			return nil, err
+		}
+		env, _ := container["env"].([]interface{})
+		for _, variable := range variables {
+			env = mergeEnvironmentVariable(env, variable)
+		}
+		container["env"] = env
+	}
+	if overrides.MinInstanceCount != nil || overrides.MaxInstanceCount != nil {
+		scaling, _ := template["scaling"].(map[string]interface{})
+		if scaling == nil {
+			scaling = map[string]interface{}{}
+			template["scaling"] = scaling
+		}
+		if overrides.MinInstanceCount != nil {
+			scaling["minInstanceCount"] = *overrides.MinInstanceCount
+		}
+		if overrides.MaxInstanceCount != nil {
+			scaling["maxInstanceCount"] = *overrides.MaxInstanceCount
+		}
+	}
+	return json.Marshal(object)
+}
+
+// mergeEnvironmentVariable merges the variable into the raw variable with the same name or appends a new one
+func mergeEnvironmentVariable(env []interface{}, variable domain.EnvironmentVariable) []interface{} {
+	for i, item := range env {
+		if current, ok := item.(map[string]interface{}); ok && current["name"] == variable.Name {
+			env[i] = utils.MergeEnvironmentVariable(current, variable)
+			return env
+		}
+	}
+	return append(env, utils.MergeEnvironmentVariable(nil, variable))
+}
@@ -0,0 +1,262 @@
+package manifest
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Nick1994209/cloudru-containerapps-mcp/internal/domain"
+	"github.com/Nick1994209/cloudru-containerapps-mcp/internal/utils"
+)
+
+// Change is a field-level difference between the live resource and the manifest
+type Change struct {
+	Field    string `json:"field"`
+	Live     string `json:"live"`
+	Manifest string `json:"manifest"`
+}
+
+// changes collects differences of the declared manifest fields
+type changes struct {
+	result []Change
+	// all reports every declared field, used when the resource does not exist yet
+	all bool
+}
+
+func (c *changes) add(field string, live string, manifest string) {
+	if c.all || live != manifest {
+		c.result = append(c.result, Change{Field: field, Live: live, Manifest: manifest})
+	}
+}
+
+// DiffContainerApp returns differences between the live Container App and the manifest.
+// Only the declared fields are compared, a nil Container App means it does not exist yet
+func (m *Manifest) DiffContainerApp(live *domain.ContainerApp) []Change {
+	c := changes{all: live == nil}
+	if live == nil {
+		live = &domain.ContainerApp{}
+	}
+
+	var image, cpu, memory string
+	var port int
+	var env map[string]domain.EnvironmentVariable
+	var command, args []interface{}
+	if len(live.Template.Containers) > 0 {
+		container := live.Template.Containers[0]
+		image, cpu, memory, port = container.Image, container.Resources.CPU, container.Resources.Memory, container.ContainerPort
+		env = make(map[string]domain.EnvironmentVariable, len(container.Env))
+		for _, variable := range container.Env {
+			env[variable.Name] = variable
+		}
+		command, args = container.Command, container.Args
+	}
+
+	if m.Description != nil {
+		c.add("description", live.Description, *m.Description)
+	}
+	c.add("image", image, m.Image)
+	if m.Port != nil {
+		c.add("port", formatInt(port, c.all), strconv.Itoa(*m.Port))
+	}
+	if m.CPU != nil {
+		c.add("cpu", cpu, *m.CPU)
+	}
+	if expected, _ := m.expectedMemory(); expected != "" {
+		c.add("memory", memory, expected)
+	}
+	if m.Protocol != nil {
+		c.add("protocol", live.Template.Protocol, *m.Protocol)
+	}
+	if m.Timeout != nil {
+		c.add("timeout", live.Template.Timeout, *m.Timeout)
+	}
+	if m.IdleTimeout != nil {
+		c.add("idleTimeout", live.Template.IdleTimeout, *m.IdleTimeout)
+	}
+	if m.Scaling != nil && m.Scaling.MinInstanceCount != nil {
+		c.add("scaling.minInstanceCount", formatInt(live.Template.Scaling.MinInstanceCount, c.all), strconv.Itoa(*m.Scaling.MinInstanceCount))
+	}
+	if m.Scaling != nil && m.Scaling.MaxInstanceCount != nil {
+		c.add("scaling.maxInstanceCount", formatInt(live.Template.Scaling.MaxInstanceCount, c.all), strconv.Itoa(*m.Scaling.MaxInstanceCount))
+	}
+	if m.Privileged != nil {
+		c.add("privileged", formatBool(live.Configuration.Privileged, c.all), strconv.FormatBool(*m.Privileged))
+	}
+	if m.Ingress != nil && m.Ingress.PubliclyAccessible != nil {
+		c.add("ingress.publiclyAccessible", formatBool(live.Configuration.Ingress.PubliclyAccessible, c.all), strconv.FormatBool(*m.Ingress.PubliclyAccessible))
+	}
+	if m.AutoDeployments != nil && m.AutoDeployments.Enabled != nil {
+		c.add("autoDeployments.enabled", formatBool(live.Configuration.AutoDeployments.Enabled, c.all), strconv.FormatBool(*m.AutoDeployments.Enabled))
+	}
+	if m.AutoDeployments != nil && m.AutoDeployments.Pattern != nil {
+		c.add("autoDeployments.pattern", live.Configuration.AutoDeployments.Pattern, *m.AutoDeployments.Pattern)
+	}
+	m.diffContainer(&c, env, command, args)
+
+	return c.result
+}
+
+// DiffJob returns differences between the live Job and the manifest.
+// Only the declared fields are compared, a nil Job means it does not exist yet.
+// retryCount, executionTimeout and runImmediately which the API does not return are always reported, so apply sends them
+func (m *Manifest) DiffJob(live *domain.Job) []Change {
+	c := changes{all: live == nil}
+	if live == nil {
+		live = &domain.Job{}
+	}
+
+	var image, cpu, memory string
+	var env map[string]domain.EnvironmentVariable
+	var command, args []interface{}
+	if len(live.Template.Containers) > 0 {
+		container := live.Template.Containers[0]
+		image, cpu, memory = container.Image, container.Resources.CPU, container.Resources.Memory
+		env = make(map[string]domain.EnvironmentVariable, len(container.Env))
+		for _, variable := range container.Env {
+			env[variable.Name] = variable
+		}
+		command, args = container.Command, container.Args
+	}
+
+	if m.Description != nil {
+		c.add("description", live.Description, *m.Description)
+	}
+	c.add("image", image, m.Image)
+	if m.CPU != nil {
+		c.add("cpu", cpu, *m.CPU)
+	}
+	if expected, _ := m.expectedMemory(); expected != "" {
+		c.add("memory", memory, expected)
+	}
+	if m.Privileged != nil {
+		c.add("privileged", formatBool(live.Configuration.Privileged, c.all), strconv.FormatBool(*m.Privileged))
+	}
+	if m.RetryCount != nil {
+		c.add("retryCount", formatJobNumber(live.Template.MaxRetries), strconv.FormatUint(uint64(*m.RetryCount), 10))
+	}
+	if m.ExecutionTimeout != nil {
+		c.add("executionTimeout", formatJobNumber(live.Template.Timeout), strconv.FormatUint(uint64(*m.ExecutionTimeout), 10))
+	}
+	if m.RunImmediately != nil {
+		var runImmediately string
+		if live.RunImmediately != nil {
+			runImmediately = strconv.FormatBool(*live.RunImmediately)
+		}
+		c.add("runImmediately", runImmediately, strconv.FormatBool(*m.RunImmediately))
+	}
+	m.diffContainer(&c, env, command, args)
+
+	return c.result
+}
+
+// diffContainer compares environment variables, command and args of the first container.
+// Declared env replaces the live one, so live variables missing in the manifest are reported as removed.
+// Variables without a declared type keep the live type and masked secret values keep the live value, the same way as on patch
+func (m *Manifest) diffContainer(c *changes, env map[string]domain.EnvironmentVariable, command []interface{}, args []interface{}) {
+	if m.Env != nil {
+		names := make([]string, 0, len(env)+len(m.Env))
+		for name := range m.Env {
+			names = append(names, name)
+		}
+		for name := range env {
+			if _, ok := m.Env[name]; !ok {
+				names = append(names, name)
+			}
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			var liveValue, manifestValue string
+			live, hasLive := env[name]
+			if hasLive {
+				liveValue = formatEnvValue(live.Value, live.Type)
+			}
+			if declared, ok := m.Env[name]; ok {
+				if declared.Type == "" {
+					if utils.IsMaskedSecret(declared.Value, live.Type) {
+						declared.Value = live.Value
+					}
+					declared.Type = live.Type
+				}
+				manifestValue = formatEnvValue(declared.Value, declared.Type)
+			}
+			c.add("env."+name, liveValue, manifestValue)
+		}
+	}
+	if m.Command != nil {
+		c.add("command", joinValues(command), strings.Join(m.Command, " "))
+	}
+	if m.Args != nil {
+		c.add("args", joinValues(args), strings.Join(m.Args, " "))
+	}
+}
+
+// formatEnvValue formats an environment variable value, the type is shown for non-plain variables.
+// Secret values are replaced with a short fingerprint, so changes are visible without revealing the value
+func formatEnvValue(value string, variableType string) string {
+	if variableType == "" || variableType == domain.EnvironmentVariableTypePlain {
+		return value
+	}
+	if variableType == domain.EnvironmentVariableTypeSecret {
+		sum := sha256.Sum256([]byte(value))
+		value = "sha256:" + hex.EncodeToString(sum[:])[:8]
+	}
+	return fmt.Sprintf("%s (%s)", value, variableType)
+}
+
+// formatJobNumber formats a live job number, empty if the API did not return it
+func formatJobNumber(value interface{}) string {
+	number, ok := parseJobNumber(value)
+	if !ok {
+		return ""
+	}
+	return strconv.FormatUint(uint64(number), 10)
+}
+
+// parseJobNumber parses a job retry count or timeout in seconds returned as a number, a numeric string or a duration like "3600s"
+func parseJobNumber(value interface{}) (uint32, bool) {
+	switch typed := value.(type) {
+	case float64:
+		if typed < 0 || typed > math.MaxUint32 {
+			return 0, false
+		}
+		return uint32(typed), true
+	case string:
+		if number, err := strconv.ParseUint(typed, 10, 32); err == nil {
+			return uint32(number), true
+		}
+		if duration, err := time.ParseDuration(typed); err == nil && duration >= 0 && duration.Seconds() <= math.MaxUint32 {
+			return uint32(duration.Seconds()), true
+		}
+	}
+	return 0, false
+}
+
+// formatInt formats a live number, a missing resource has no value instead of 0
+func formatInt(value int, missing bool) string {
+	if missing {
+		return ""
+	}
+	return strconv.Itoa(value)
+}
+
+// formatBool formats a live flag, a missing resource has no value instead of false
+func formatBool(value bool, missing bool) string {
+	if missing {
+		return ""
+	}
+	return strconv.FormatBool(value)
+}
+
+// joinValues joins command or args values returned by the API
+func joinValues(values []interface{}) string {
+	parts := make([]string, 0, len(values))
+	for _, value := range values {
+		parts = append(parts, fmt.Sprint(value))
+	}
+	return strings.Join(parts, " ")
+}
@@ -0,0 +1,200 @@
+package manifest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/Nick1994209/cloudru-containerapps-mcp/internal/domain"
+	"gopkg.in/yaml.v3"
+)
+
+// serverFields are managed by Cloud.ru and are not accepted by create requests
+var serverFields = map[string]bool{
+	"id":          true,
+	"projectId":   true,
+	"status":      true,
+	"createdAt":   true,
+	"createdBy":   true,
+	"updatedAt":   true,
+	"updatedBy":   true,
+	"deletedAt":   true,
+	"publicUri":   true,
+	"internalUri": true,
+}
+
+// StripServerFields removes server-managed fields (ids, status, timestamps, URIs) at any depth of a raw API object
+func StripServerFields(raw []byte) (json.RawMessage, error) {
+	var object interface{}
+	if err := json.Unmarshal(raw, &object); err != nil {
+		return nil, fmt.Errorf("failed to parse object: %w", err)
+	}
+	return json.Marshal(stripValue(object))
+}
+
+func stripValue(value interface{}) interface{} {
+	switch typed := value.(type) {
+	case map[string]interface{}:
+		for key, item := range typed {
+			if serverFields[key] {
+				delete(typed, key)
+				continue
+			}
+			typed[key] = stripValue(item)
+		}
+	case []interface{}:
+		for i, item := range typed {
+			typed[i] = stripValue(item)
+		}
+	}
+	return value
+}
+
+// FromContainerApp creates a manifest from the raw Container App returned by the API.
+// Settings which cannot be expressed in the manifest are returned as warnings
+func FromContainerApp(raw []byte) (*Manifest, []string, error) {
+	var live domain.ContainerApp
+	if err := json.Unmarshal(raw, &live); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse Container App: %w", err)
+	}
+	if len(live.Template.Containers) == 0 {
+		return nil, nil, fmt.Errorf("Container App %s has no containers", live.Name)
+	}
+
+	container := live.Template.Containers[0]
+	m := &Manifest{
+		Kind:        KindContainerApp,
+		Name:        live.Name,
+		Description: optionalString(live.Description),
+		Image:       container.Image,
+		CPU:         optionalString(container.Resources.CPU),
+		Memory:      optionalString(container.Resources.Memory),
+		Privileged:  &live.Configuration.Privileged,
+		Protocol:    optionalString(live.Template.Protocol),
+		Timeout:     optionalString(live.Template.Timeout),
+		IdleTimeout: optionalString(live.Template.IdleTimeout),
+		Scaling: &Scaling{
+			MinInstanceCount: &live.Template.Scaling.MinInstanceCount,
+			MaxInstanceCount: &live.Template.Scaling.MaxInstanceCount,
+		},
+		Ingress: &Ingress{
+			PubliclyAccessible: &live.Configuration.Ingress.PubliclyAccessible,
+		},
+		AutoDeployments: &AutoDeployments{
+			Enabled: &live.Configuration.AutoDeployments.Enabled,
+			Pattern: optionalString(live.Configuration.AutoDeployments.Pattern),
+		},
+		Env:     envFromContainer(container.Env),
+		Command: formatValues(container.Command),
+		Args:    formatValues(container.Args),
+	}
+	if container.ContainerPort != 0 {
+		m.Port = &container.ContainerPort
+	}
+
+	var warnings []string
+	if len(live.Template.Containers) > 1 {
+		warnings = append(warnings, fmt.Sprintf("only the first of %d containers is exported", len(live.Template.Containers)))
+	}
+	if len(live.Template.InitContainers) > 0 {
+		warnings = append(warnings, "init containers are not exported")
+	}
+	if len(live.Template.Volumes) > 0 || len(container.VolumeMounts) > 0 {
+		warnings = append(warnings, "volumes and volume mounts are not exported")
+	}
+	return m, warnings, nil
+}
+
+// FromJob creates a manifest from the raw Job returned by the API.
+// retryCount, executionTimeout and runImmediately are exported from the template when the API returns them,
+// otherwise they are left out of the manifest with a warning
+func FromJob(raw []byte) (*Manifest, []string, error) {
+	var live domain.Job
+	if err := json.Unmarshal(raw, &live); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse Job: %w", err)
+	}
+	if len(live.Template.Containers) == 0 {
+		return nil, nil, fmt.Errorf("Job %s has no containers", live.Name)
+	}
+
+	container := live.Template.Containers[0]
+	m := &Manifest{
+		Kind:           KindJob,
+		Name:           live.Name,
+		Description:    optionalString(live.Description),
+		Image:          container.Image,
+		CPU:            optionalString(container.Resources.CPU),
+		Memory:         optionalString(container.Resources.Memory),
+		Privileged:     &live.Configuration.Privileged,
+		Env:            envFromContainer(container.Env),
+		Command:        formatValues(container.Command),
+		Args:           formatValues(container.Args),
+		RunImmediately: live.RunImmediately,
+	}
+
+	var warnings []string
+	if retryCount, ok := parseJobNumber(live.Template.MaxRetries); ok {
+		m.RetryCount = &retryCount
+	} else {
+		warnings = append(warnings, "retryCount is not returned by the API and is not exported")
+	}
+	if executionTimeout, ok := parseJobNumber(live.Template.Timeout); ok {
+		m.ExecutionTimeout = &executionTimeout
+	} else {
+		warnings = append(warnings, "executionTimeout is not returned by the API and is not exported")
+	}
+	if live.RunImmediately == nil {
+		warnings = append(warnings, "runImmediately is not returned by the API and is not exported")
+	}
+	if len(live.Template.Containers) > 1 {
+		warnings = append(warnings, fmt.Sprintf("only the first of %d containers is exported", len(live.Template.Containers)))
+	}
+	return m, warnings, nil
+}
+
+// Marshal encodes the manifest as cloudru.yaml
+func (m *Manifest) Marshal() ([]byte, error) {
+	var buffer bytes.Buffer
+	encoder := yaml.NewEncoder(&buffer)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(m); err != nil {
+		return nil, fmt.Errorf("failed to encode manifest: %w", err)
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, fmt.Errorf("failed to encode manifest: %w", err)
+	}
+	return buffer.Bytes(), nil
+}
+
+// envFromContainer converts live environment variables to the manifest env, plain types are omitted
+func envFromContainer(variables []domain.EnvironmentVariable) map[string]EnvValue {
+	env := make(map[string]EnvValue, len(variables))
+	for _, variable := range variables {
+		value := EnvValue{Value: variable.Value, Type: variable.Type}
+		if value.Type == domain.EnvironmentVariableTypePlain {
+			value.Type = ""
+		}
+		env[variable.Name] = value
+	}
+	return env
+}
+
+// optionalString returns nil for an empty live value, so the manifest does not declare it
+func optionalString(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return &value
+}
+
+// formatValues converts command or args values returned by the API to strings
+func formatValues(values []interface{}) []string {
+	if len(values) == 0 {
+		return nil
+	}
+	result := make([]string, 0, len(values))
+	for _, value := range values {
+		result = append(result, fmt.Sprint(value))
+	}
+	return result
+}
@@ -0,0 +1,332 @@
+package manifest
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/Nick1994209/cloudru-containerapps-mcp/internal/domain"
+	"github.com/Nick1994209/cloudru-containerapps-mcp/internal/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultPath is the manifest file name looked up in the current directory
+const DefaultPath = "cloudru.yaml"
+
+// Manifest kinds
+const (
+	KindContainerApp = "containerapp"
+	KindJob          = "job"
+)
+
+// Manifest describes a Container App or a Job checked in as cloudru.yaml.
+// Fields which are not set are left as is when an existing resource is patched
+type Manifest struct {
+	Kind        string              `yaml:"kind"`
+	Name        string              `yaml:"name"`
+	ProjectID   string              `yaml:"projectId,omitempty"`
+	Description *string             `yaml:"description,omitempty"`
+	Image       string              `yaml:"image"`
+	CPU         *string             `yaml:"cpu,omitempty"`
+	Memory      *string             `yaml:"memory,omitempty"`
+	Privileged  *bool               `yaml:"privileged,omitempty"`
+	Env         map[string]EnvValue `yaml:"env,omitempty"`
+	Command     []string            `yaml:"command,omitempty"`
+	Args        []string            `yaml:"args,omitempty"`
+
+	// Container App fields
+	Port            *int             `yaml:"port,omitempty"`
+	Protocol        *string          `yaml:"protocol,omitempty"`
+	Timeout         *string          `yaml:"timeout,omitempty"`
+	IdleTimeout     *string          `yaml:"idleTimeout,omitempty"`
+	Scaling         *Scaling         `yaml:"scaling,omitempty"`
+	Ingress         *Ingress         `yaml:"ingress,omitempty"`
+	AutoDeployments *AutoDeployments `yaml:"autoDeployments,omitempty"`
+
+	// Job fields
+	RetryCount       *uint32 `yaml:"retryCount,omitempty"`
+	ExecutionTimeout *uint32 `yaml:"executionTimeout,omitempty"`
+	RunImmediately   *bool   `yaml:"runImmediately,omitempty"`
+}
+
+// Scaling describes the number of Container App instances
+type Scaling struct {
+	MinInstanceCount *int `yaml:"minInstanceCount,omitempty"`
+	MaxInstanceCount *int `yaml:"maxInstanceCount,omitempty"`
+}
+
+// Ingress describes the Container App network access
+type Ingress struct {
+	PubliclyAccessible *bool `yaml:"publiclyAccessible,omitempty"`
+}
+
+// AutoDeployments describes redeploying the Container App when a new image is pushed
+type AutoDeployments struct {
+	Enabled *bool   `yaml:"enabled,omitempty"`
+	Pattern *string `yaml:"pattern,omitempty"`
+}
+
+// EnvValue is an environment variable value declared as a string, or as {value, type} for typed variables,
+// e.g. {value: <secret reference>, type: secret}. Variables without a type keep the type of the live variable
+type EnvValue struct {
+	Value string
+	Type  string
+}
+
+// typedEnvValue is the YAML mapping form of EnvValue
+type typedEnvValue struct {
+	Value string `yaml:"value"`
+	Type  string `yaml:"type,omitempty"`
+}
+
+// UnmarshalYAML decodes a string or a {value, type} mapping
+func (v *EnvValue) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		v.Type = ""
+		return node.Decode(&v.Value)
+	}
+
+	var typed typedEnvValue
+	if err := node.Decode(&typed); err != nil {
+		return err
+	}
+	v.Value, v.Type = typed.Value, typed.Type
+	return nil
+}
+
+// MarshalYAML encodes plain values as strings and typed values as {value, type} mappings
+func (v EnvValue) MarshalYAML() (interface{}, error) {
+	if v.Type == "" {
+		return v.Value, nil
+	}
+	return typedEnvValue{Value: v.Value, Type: v.Type}, nil
+}
+
+// Load reads and validates a manifest file
+func Load(path string) (*Manifest, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read manifest: %w", err)
+	}
+
+	m, err := Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid manifest %s: %w", path, err)
+	}
+	return m, nil
+}
+
+// Parse parses and validates manifest YAML, unknown fields are rejected to catch typos
+func Parse(data []byte) (*Manifest, error) {
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+
+	var m Manifest
+	if err := decoder.Decode(&m); err != nil {
+		return nil, fmt.Errorf("failed to parse YAML: %w", err)
+	}
+	if err := m.validate(); err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+// validate checks required fields and fields which do not belong to the manifest kind
+func (m *Manifest) validate() error {
+	m.Kind = strings.ToLower(strings.TrimSpace(m.Kind))
+	if m.Kind == "" {
+		m.Kind = KindContainerApp
+	}
+
+	var errs []error
+	if m.Kind != KindContainerApp && m.Kind != KindJob {
+		errs = append(errs, fmt.Errorf("kind must be '%s' or '%s', got: %s", KindContainerApp, KindJob, m.Kind))
+	}
+	if m.Name == "" {
+		errs = append(errs, errors.New("name is required"))
+	}
+	if m.Image == "" {
+		errs = append(errs, errors.New("image is required"))
+	}
+	for name, value := range m.Env {
+		if value.Type != "" && value.Type != domain.EnvironmentVariableTypePlain && value.Type != domain.EnvironmentVariableTypeSecret {
+			errs = append(errs, fmt.Errorf("env %s: type must be '%s' or '%s', got: %s", name, domain.EnvironmentVariableTypePlain, domain.EnvironmentVariableTypeSecret, value.Type))
+		}
+		if value.Type == domain.EnvironmentVariableTypeSecret && (value.Value == "" || value.Value == domain.EnvironmentVariableMask) {
+			errs = append(errs, fmt.Errorf("env %s: the value of a secret variable must be a Secret Manager secret reference, got: %q", name, value.Value))
+		}
+	}
+	if _, err := m.expectedMemory(); err != nil {
+		errs = append(errs, err)
+	}
+
+	if m.Kind == KindContainerApp {
+		if m.RetryCount != nil || m.ExecutionTimeout != nil || m.RunImmediately != nil {
+			errs = append(errs, errors.New("retryCount, executionTimeout and runImmediately are only supported for jobs"))
+		}
+	}
+	if m.Kind == KindJob {
+		if m.Port != nil || m.Protocol != nil || m.Timeout != nil || m.IdleTimeout != nil || m.Scaling != nil || m.Ingress != nil || m.AutoDeployments != nil {
+			errs = append(errs, errors.New("port, protocol, timeout, idleTimeout, scaling, ingress and autoDeployments are only supported for container apps"))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// CreateContainerAppRequest converts the manifest to a create request, unset fields get the create tool defaults
+func (m *Manifest) CreateContainerAppRequest(projectID string) (domain.CreateContainerAppRequest, error) {
+	if m.Port == nil {
+		return domain.CreateContainerAppRequest{}, errors.New("port is required to create a Container App")
+	}
+
+	request := domain.CreateContainerAppRequest{
+		ProjectID:              projectID,
+		ContainerAppName:       m.Name,
+		ContainerAppPort:       *m.Port,
+		ContainerAppImage:      m.Image,
+		AutoDeploymentsPattern: "latest",
+		Privileged:             valueOr(m.Privileged, false),
+		IdleTimeout:            valueOr(m.IdleTimeout, "600s"),
+		Timeout:                valueOr(m.Timeout, "60s"),
+		CPU:                    valueOr(m.CPU, "0.1"),
+		Memory:                 valueOr(m.Memory, ""),
+		MinInstanceCount:       0,
+		MaxInstanceCount:       1,
+		Description:            valueOr(m.Description, ""),
+		PubliclyAccessible:     true,
+		Protocol:               valueOr(m.Protocol, "http_1"),
+		EnvironmentVariables:   formatEnvironmentVariables(m.Env),
+		Command:                m.Command,
+		Args:                   m.Args,
+	}
+	if m.Scaling != nil {
+		request.MinInstanceCount = valueOr(m.Scaling.MinInstanceCount, request.MinInstanceCount)
+		request.MaxInstanceCount = valueOr(m.Scaling.MaxInstanceCount, request.MaxInstanceCount)
+	}
+	if m.Ingress != nil {
+		request.PubliclyAccessible = valueOr(m.Ingress.PubliclyAccessible, request.PubliclyAccessible)
+	}
+	if m.AutoDeployments != nil {
+		request.AutoDeploymentsEnabled = valueOr(m.AutoDeployments.Enabled, false)
+		request.AutoDeploymentsPattern = valueOr(m.AutoDeployments.Pattern, request.AutoDeploymentsPattern)
+	}
+	return request, nil
+}
+
+// PatchContainerAppRequest converts the manifest to a patch request which sets only the declared fields
+func (m *Manifest) PatchContainerAppRequest(projectID string) domain.PatchContainerAppRequest {
+	request := domain.PatchContainerAppRequest{
+		ProjectID:         projectID,
+		ContainerAppName:  m.Name,
+		ContainerAppPort:  m.Port,
+		ContainerAppImage: &m.Image,
+		IdleTimeout:       m.IdleTimeout,
+		Timeout:           m.Timeout,
+		CPU:               m.CPU,
+		Memory:            m.Memory,
+		Description:       m.Description,
+		Privileged:        m.Privileged,
+		Protocol:          m.Protocol,
+		Command:           m.Command,
+		Args:              m.Args,
+	}
+	if m.Env != nil {
+		env := formatEnvironmentVariables(m.Env)
+		request.EnvironmentVariables = &env
+	}
+	if m.Scaling != nil {
+		request.MinInstanceCount = m.Scaling.MinInstanceCount
+		request.MaxInstanceCount = m.Scaling.MaxInstanceCount
+	}
+	if m.Ingress != nil {
+		request.PubliclyAccessible = m.Ingress.PubliclyAccessible
+	}
+	if m.AutoDeployments != nil {
+		request.AutoDeploymentsEnabled = m.AutoDeployments.Enabled
+		request.AutoDeploymentsPattern = m.AutoDeployments.Pattern
+	}
+	return request
+}
+
+// CreateJobRequest converts the manifest to a create request, unset fields get the create tool defaults
+func (m *Manifest) CreateJobRequest(projectID string) domain.CreateJobRequest {
+	return domain.CreateJobRequest{
+		ProjectID:               projectID,
+		JobName:                 m.Name,
+		JobImage:                m.Image,
+		JobPrivileged:           valueOr(m.Privileged, false),
+		JobCPU:                  valueOr(m.CPU, "0.1"),
+		JobMemory:               valueOr(m.Memory, ""),
+		JobDescription:          valueOr(m.Description, ""),
+		JobEnvironmentVariables: formatEnvironmentVariables(m.Env),
+		JobCommand:              m.Command,
+		JobArgs:                 m.Args,
+		JobRetryCount:           valueOr(m.RetryCount, 3),
+		JobExecutionTimeout:     valueOr(m.ExecutionTimeout, 3600),
+		JobRunImmediately:       valueOr(m.RunImmediately, false),
+	}
+}
+
+// PatchJobRequest converts the manifest to a patch request which sets only the declared fields
+func (m *Manifest) PatchJobRequest(projectID string) domain.PatchJobRequest {
+	request := domain.PatchJobRequest{
+		ProjectID:           projectID,
+		JobName:             m.Name,
+		JobImage:            &m.Image,
+		JobPrivileged:       m.Privileged,
+		JobCPU:              m.CPU,
+		JobMemory:           m.Memory,
+		JobDescription:      m.Description,
+		JobCommand:          m.Command,
+		JobArgs:             m.Args,
+		JobRetryCount:       m.RetryCount,
+		JobExecutionTimeout: m.ExecutionTimeout,
+		JobRunImmediately:   m.RunImmediately,
+	}
+	if m.Env != nil {
+		env := formatEnvironmentVariables(m.Env)
+		request.JobEnvironmentVariables = &env
+	}
+	return request
+}
+
+// expectedMemory returns the memory the resource gets after apply, empty if neither cpu nor memory is declared.
+// A declared cpu without memory gets the default memory of the cpu
+func (m *Manifest) expectedMemory() (string, error) {
+	if m.CPU != nil {
+		_, memory, err := utils.ParseResources(*m.CPU, valueOr(m.Memory, ""))
+		return memory, err
+	}
+	if m.Memory != nil {
+		return utils.NormalizeMemory(*m.Memory)
+	}
+	return "", nil
+}
+
+// formatEnvironmentVariables formats env as a JSON array of {"name", "value", "type"} sorted by name
+func formatEnvironmentVariables(env map[string]EnvValue) string {
+	names := make([]string, 0, len(env))
+	for name := range env {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	variables := make([]domain.EnvironmentVariable, 0, len(names))
+	for _, name := range names {
+		variables = append(variables, domain.EnvironmentVariable{Name: name, Value: env[name].Value, Type: env[name].Type})
+	}
+	data, _ := json.Marshal(variables)
+	return string(data)
+}
+
+// valueOr returns the pointed value or the default when the field is not set
+func valueOr[T any](value *T, defaultValue T) T {
+	if value == nil {
+		return defaultValue
+	}
+	return *value
+}
@@ -0,0 +1,300 @@
+package manifest
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/Nick1994209/cloudru-containerapps-mcp/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const containerAppManifest = `
+kind: containerapp
+name: my-app
+image: my-registry.cr.cloud.ru/app:v2
+port: 8080
+cpu: "0.5"
+scaling:
+  minInstanceCount: 1
+  maxInstanceCount: 3
+privileged: true
+ingress:
+  publiclyAccessible: false
+env:
+  LOG_LEVEL: debug
+  DATABASE_URL: postgres://db/app
+command: ["/app/server"]
+`
+
+func TestParse_ContainerApp(t *testing.T) {
+	m, err := Parse([]byte(containerAppManifest))
+	require.NoError(t, err)
+
+	request, err := m.CreateContainerAppRequest("project-1")
+	require.NoError(t, err)
+	assert.Equal(t, "project-1", request.ProjectID)
+	assert.Equal(t, "my-app", request.ContainerAppName)
+	assert.Equal(t, 8080, request.ContainerAppPort)
+	assert.Equal(t, "0.5", request.CPU)
+	assert.Equal(t, 1, request.MinInstanceCount)
+	assert.Equal(t, 3, request.MaxInstanceCount)
+	assert.False(t, request.PubliclyAccessible)
+	assert.True(t, request.Privileged)
+	assert.Equal(t, "600s", request.IdleTimeout)
+	assert.JSONEq(t, `[{"name":"DATABASE_URL","value":"postgres://db/app"},{"name":"LOG_LEVEL","value":"debug"}]`, request.EnvironmentVariables)
+	assert.Equal(t, []string{"/app/server"}, request.Command)
+
+	patch := m.PatchContainerAppRequest("project-1")
+	assert.Equal(t, "my-registry.cr.cloud.ru/app:v2", *patch.ContainerAppImage)
+	assert.Nil(t, patch.IdleTimeout, "undeclared fields must not be patched")
+	assert.Nil(t, patch.AutoDeploymentsEnabled)
+	assert.False(t, *patch.PubliclyAccessible)
+	assert.True(t, *patch.Privileged)
+}
+
+func TestParse_Errors(t *testing.T) {
+	tests := []struct {
+		name     string
+		manifest string
+		expected string
+	}{
+		{name: "unknown field", manifest: "name: app\nimage: app\nportt: 80", expected: "field portt not found"},
+		{name: "missing fields", manifest: "kind: job", expected: "name is required\nimage is required"},
+		{name: "unknown kind", manifest: "kind: vm\nname: app\nimage: app", expected: "kind must be"},
+		{name: "job with port", manifest: "kind: job\nname: app\nimage: app\nport: 80", expected: "only supported for container apps"},
+		{name: "env with unknown type", manifest: "name: app\nimage: app\nenv:\n  A: {value: a, type: file}", expected: "env A: type must be"},
+		{name: "masked secret", manifest: "name: app\nimage: app\nenv:\n  A: {value: \"***\", type: secret}", expected: "env A: the value of a secret variable must be a Secret Manager secret reference"},
+		{name: "memory not allowed for cpu", manifest: "name: app\nimage: app\ncpu: \"0.1\"\nmemory: 4Gi", expected: "memory 4096Mi is not allowed with 0.1 CPU"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse([]byte(tt.manifest))
+			assert.ErrorContains(t, err, tt.expected)
+		})
+	}
+}
+
+func TestDiffContainerApp(t *testing.T) {
+	m, err := Parse([]byte(containerAppManifest))
+	require.NoError(t, err)
+
+	var live domain.ContainerApp
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"name": "my-app",
+		"configuration": {"ingress": {"publiclyAccessible": true}},
+		"template": {
+			"scaling": {"minInstanceCount": 1, "maxInstanceCount": 3},
+			"containers": [{
+				"image": "my-registry.cr.cloud.ru/app:v1",
+				"containerPort": 8080,
+				"resources": {"cpu": "0.5", "memory": "1024Mi"},
+				"env": [{"name": "LOG_LEVEL", "value": "info"}, {"name": "OLD", "value": "1"}],
+				"command": ["/app/server"]
+			}]
+		}
+	}`), &live))
+
+	assert.Equal(t, []Change{
+		{Field: "image", Live: "my-registry.cr.cloud.ru/app:v1", Manifest: "my-registry.cr.cloud.ru/app:v2"},
+		{Field: "privileged", Live: "false", Manifest: "true"},
+		{Field: "ingress.publiclyAccessible", Live: "true", Manifest: "false"},
+		{Field: "env.DATABASE_URL", Live: "", Manifest: "postgres://db/app"},
+		{Field: "env.LOG_LEVEL", Live: "info", Manifest: "debug"},
+		{Field: "env.OLD", Live: "1", Manifest: ""},
+	}, m.DiffContainerApp(&live))
+}
+
+func TestDiffJob_NotExisting(t *testing.T) {
+	m, err := Parse([]byte("kind: job\nname: nightly\nimage: app:v1\nprivileged: false"))
+	require.NoError(t, err)
+
+	assert.Equal(t, []Change{
+		{Field: "image", Live: "", Manifest: "app:v1"},
+		{Field: "privileged", Live: "", Manifest: "false"},
+	}, m.DiffJob(nil))
+}
+
+func TestDiffJob_ExecutionSettings(t *testing.T) {
+	var live domain.Job
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"runImmediately": false,
+		"template": {"maxRetries": 3, "timeout": "3600s", "containers": [{"image": "app:v1"}]}
+	}`), &live))
+
+	m, err := Parse([]byte("kind: job\nname: nightly\nimage: app:v1\nretryCount: 3\nexecutionTimeout: 3600\nrunImmediately: false\n"))
+	require.NoError(t, err)
+	assert.Empty(t, m.DiffJob(&live))
+
+	m, err = Parse([]byte("kind: job\nname: nightly\nimage: app:v1\nretryCount: 5\nexecutionTimeout: 600\nrunImmediately: true\n"))
+	require.NoError(t, err)
+	assert.Equal(t, []Change{
+		{Field: "retryCount", Live: "3", Manifest: "5"},
+		{Field: "executionTimeout", Live: "3600", Manifest: "600"},
+		{Field: "runImmediately", Live: "false", Manifest: "true"},
+	}, m.DiffJob(&live))
+
+	// Settings which the API did not return are reported, so apply sends them
+	var withoutSettings domain.Job
+	require.NoError(t, json.Unmarshal([]byte(`{"template": {"containers": [{"image": "app:v1"}]}}`), &withoutSettings))
+	assert.Len(t, m.DiffJob(&withoutSettings), 3)
+}
+
+func TestFromJob_ExecutionSettings(t *testing.T) {
+	exported, warnings, err := FromJob([]byte(`{
+		"name": "nightly",
+		"runImmediately": false,
+		"template": {"maxRetries": 5, "timeout": "600s", "containers": [{"image": "job:v1"}]}
+	}`))
+	require.NoError(t, err)
+	assert.Empty(t, warnings)
+	assert.Equal(t, uint32(5), *exported.RetryCount)
+	assert.Equal(t, uint32(600), *exported.ExecutionTimeout)
+	assert.False(t, *exported.RunImmediately)
+
+	// Settings which the API does not return are left out instead of exporting the create defaults
+	exported, warnings, err = FromJob([]byte(`{"name": "nightly", "template": {"containers": [{"image": "job:v1"}]}}`))
+	require.NoError(t, err)
+	assert.Nil(t, exported.RetryCount)
+	assert.Nil(t, exported.ExecutionTimeout)
+	assert.Nil(t, exported.RunImmediately)
+	assert.Len(t, warnings, 3)
+}
+
+func TestDiffContainerApp_EmptyEnvAndCommand(t *testing.T) {
+	m, err := Parse([]byte("name: my-app\nimage: app:v1\nenv: {}\ncommand: []\n"))
+	require.NoError(t, err)
+
+	var live domain.ContainerApp
+	require.NoError(t, json.Unmarshal([]byte(`{"template": {"containers": [{
+		"image": "app:v1", "env": [{"name": "A", "value": "1"}], "command": ["/app/server"]
+	}]}}`), &live))
+
+	// Empty env and command are reported as removed and are sent as empty values to reset them
+	assert.Equal(t, []Change{
+		{Field: "env.A", Live: "1", Manifest: ""},
+		{Field: "command", Live: "/app/server", Manifest: ""},
+	}, m.DiffContainerApp(&live))
+	request := m.PatchContainerAppRequest("project-1")
+	assert.Equal(t, "[]", *request.EnvironmentVariables)
+	assert.NotNil(t, request.Command)
+	assert.Empty(t, request.Command)
+}
+
+const liveContainerApp = `{
+	"id": "app-id",
+	"projectId": "project-1",
+	"name": "my-app",
+	"status": "RUNNING",
+	"createdAt": "2024-01-01T00:00:00Z",
+	"configuration": {"privileged": true, "ingress": {"publiclyAccessible": true, "publicUri": "https://my-app.example"}, "autoDeployments": {"enabled": false}},
+	"template": {
+		"timeout": "60s",
+		"scaling": {"minInstanceCount": 0, "maxInstanceCount": 2},
+		"containers": [{
+			"image": "my-registry.cr.cloud.ru/app:v1",
+			"containerPort": 8080,
+			"resources": {"cpu": "0.5", "memory": "1024Mi"},
+			"env": [{"name": "LOG_LEVEL", "value": "info"}],
+			"command": ["/app/server"]
+		}]
+	}
+}`
+
+func TestFromContainerApp_RoundTrip(t *testing.T) {
+	exported, warnings, err := FromContainerApp([]byte(liveContainerApp))
+	require.NoError(t, err)
+	assert.Empty(t, warnings)
+
+	data, err := exported.Marshal()
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "projectId")
+
+	m, err := Parse(data)
+	require.NoError(t, err)
+	assert.Equal(t, 8080, *m.Port)
+	assert.True(t, *m.Privileged)
+	assert.Equal(t, map[string]EnvValue{"LOG_LEVEL": {Value: "info"}}, m.Env)
+
+	var live domain.ContainerApp
+	require.NoError(t, json.Unmarshal([]byte(liveContainerApp), &live))
+	assert.Empty(t, m.DiffContainerApp(&live), "the exported manifest must match the live Container App")
+}
+
+func TestStripServerFields(t *testing.T) {
+	object, err := StripServerFields([]byte(liveContainerApp))
+	require.NoError(t, err)
+
+	for _, field := range []string{`"id"`, `"projectId"`, `"status"`, `"createdAt"`, `"publicUri"`} {
+		assert.NotContains(t, string(object), field)
+	}
+	assert.Contains(t, string(object), `"publiclyAccessible":true`)
+}
+
+func TestDiffContainerApp_EnvTypes(t *testing.T) {
+	m, err := Parse([]byte("name: my-app\nimage: app:v1\nenv:\n  A: a;b\n  TOKEN: token-ref\n  PASSWORD: {value: password-ref, type: secret}\n"))
+	require.NoError(t, err)
+
+	var live domain.ContainerApp
+	require.NoError(t, json.Unmarshal([]byte(`{"template": {"containers": [{
+		"image": "app:v1",
+		"env": [{"name": "A", "value": "a;b"}, {"name": "TOKEN", "value": "token-ref", "type": "secret"}, {"name": "PASSWORD", "value": "password"}]
+	}]}}`), &live))
+
+	// TOKEN keeps the live secret type, PASSWORD turns into a secret
+	assert.Equal(t, []Change{
+		{Field: "env.PASSWORD", Live: "password", Manifest: "sha256:024afd39 (secret)"},
+	}, m.DiffContainerApp(&live))
+	assert.JSONEq(t, `[{"name":"A","value":"a;b"},{"name":"PASSWORD","value":"password-ref","type":"secret"},{"name":"TOKEN","value":"token-ref"}]`, *m.PatchContainerAppRequest("project-1").EnvironmentVariables)
+
+	// A masked secret value exported by a tool output is not a change
+	masked, err := Parse([]byte("name: my-app\nimage: app:v1\nenv:\n  A: a;b\n  TOKEN: '***'\n  PASSWORD: password\n"))
+	require.NoError(t, err)
+	assert.Empty(t, masked.DiffContainerApp(&live))
+}
+
+func TestCloneObject_KeepsSettingsOutsideOfManifest(t *testing.T) {
+	raw := `{
+		"id": "app-id",
+		"projectId": "staging",
+		"name": "my-app",
+		"configuration": {"privileged": true},
+		"template": {
+			"scaling": {"minInstanceCount": 0, "maxInstanceCount": 2},
+			"volumes": [{"name": "data"}],
+			"initContainers": [{"name": "migrate", "image": "migrate:v1"}],
+			"containers": [
+				{"name": "my-app", "image": "app:v1", "env": [{"name": "TOKEN", "value": "secret-ref", "type": "secret"}], "volumeMounts": [{"name": "data", "mountPath": "/data"}]},
+				{"name": "proxy", "image": "proxy:v1"}
+			]
+		}
+	}`
+	image := "app:v2"
+	env := "TOKEN='other-ref'; DEBUG='1'"
+	minInstanceCount := 1
+
+	object, err := CloneObject([]byte(raw), "my-app-copy", Overrides{Image: &image, EnvironmentVariables: &env, MinInstanceCount: &minInstanceCount})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"name": "my-app-copy",
+		"configuration": {"privileged": true},
+		"template": {
+			"scaling": {"minInstanceCount": 1, "maxInstanceCount": 2},
+			"volumes": [{"name": "data"}],
+			"initContainers": [{"name": "migrate", "image": "migrate:v1"}],
+			"containers": [
+				{"name": "my-app", "image": "app:v2", "env": [{"name": "TOKEN", "value": "other-ref", "type": "secret"}, {"name": "DEBUG", "value": "1"}], "volumeMounts": [{"name": "data", "mountPath": "/data"}]},
+				{"name": "proxy", "image": "proxy:v1"}
+			]
+		}
+	}`, string(object))
+
+	// A masked secret value sent back keeps the source secret reference
+	masked := "TOKEN='***'"
+	object, err = CloneObject([]byte(raw), "my-app-copy", Overrides{EnvironmentVariables: &masked})
+	require.NoError(t, err)
+	assert.Contains(t, string(object), `{"name":"TOKEN","type":"secret","value":"secret-ref"}`)
+
+	_, err = CloneObject([]byte(`{"name": "empty", "template": {}}`), "copy", Overrides{})
+	assert.ErrorContains(t, err, "empty has no containers")
+}
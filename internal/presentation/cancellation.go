@@ -0,0 +1,114 @@
+package presentation
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"sync"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// requestIDMetaKey is the request _meta field used to pass the JSON-RPC request id from the
+// before-call hook to the tool handler middleware
+const requestIDMetaKey = "cloudru-containerapps-mcp/requestId"
+
+// methodNotificationCancelled is sent by clients to abort an in-flight request
+const methodNotificationCancelled = "notifications/cancelled"
+
+// RequestCanceller cancels the context of in-flight tool calls when the client sends
+// a notifications/cancelled notification, so slow API requests and docker processes are aborted
+type RequestCanceller struct {
+	mu       sync.Mutex
+	inFlight map[string]context.CancelFunc
+}
+
+// NewRequestCanceller creates a new RequestCanceller
+func NewRequestCanceller() *RequestCanceller {
+	return &RequestCanceller{
+		inFlight: make(map[string]context.CancelFunc),
+	}
+}
+
+// ServerOptions returns the MCP server options that track in-flight tool calls
+func (c *RequestCanceller) ServerOptions() []server.ServerOption {
+	hooks := &server.Hooks{}
+	hooks.AddBeforeCallTool(func(ctx context.Context, id any, message *mcp.CallToolRequest) {
+		// The handler receives a copy of the request, so the id is passed through its _meta
+		if message.Params.Meta == nil {
+			message.Params.Meta = &mcp.Meta{}
+		}
+		if message.Params.Meta.AdditionalFields == nil {
+			message.Params.Meta.AdditionalFields = map[string]any{}
+		}
+		message.Params.Meta.AdditionalFields[requestIDMetaKey] = requestKey(ctx, id)
+	})
+
+	return []server.ServerOption{
+		server.WithHooks(hooks),
+		server.WithToolHandlerMiddleware(c.middleware),
+	}
+}
+
+// Register registers the notifications/cancelled handler with the MCP server
+func (c *RequestCanceller) Register(mcpServer *server.MCPServer) {
+	mcpServer.AddNotificationHandler(methodNotificationCancelled, func(ctx context.Context, notification mcp.JSONRPCNotification) {
+		requestID, ok := notification.Params.AdditionalFields["requestId"]
+		if !ok {
+			return
+		}
+
+		key := requestKey(ctx, requestID)
+		c.mu.Lock()
+		cancel, ok := c.inFlight[key]
+		c.mu.Unlock()
+		if ok {
+			log.Printf("Cancelling tool call %s: %v", key, notification.Params.AdditionalFields["reason"])
+			cancel()
+		}
+	})
+}
+
+// middleware wraps tool handlers with a cancellable context registered under the request key
+func (c *RequestCanceller) middleware(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		if request.Params.Meta == nil {
+			return next(ctx, request)
+		}
+		key, ok := request.Params.Meta.AdditionalFields[requestIDMetaKey].(string)
+		if !ok {
+			return next(ctx, request)
+		}
+		delete(request.Params.Meta.AdditionalFields, requestIDMetaKey)
+
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		c.mu.Lock()
+		c.inFlight[key] = cancel
+		c.mu.Unlock()
+		defer func() {
+			c.mu.Lock()
+			delete(c.inFlight, key)
+			c.mu.Unlock()
+		}()
+
+		return next(ctx, request)
+	}
+}
+
+// requestKey identifies a request by client session and JSON-RPC id
+func requestKey(ctx context.Context, id any) string {
+	sessionID := ""
+	if session := server.ClientSessionFromContext(ctx); session != nil {
+		sessionID = session.SessionID()
+	}
+
+	// Ids are compared in their JSON form, so numeric ids match regardless of their Go type
+	idJSON, err := json.Marshal(id)
+	if err != nil {
+		return sessionID + "/" + mcp.NewRequestId(id).String()
+	}
+	return sessionID + "/" + string(idJSON)
+}
@@ -0,0 +1,60 @@
+package presentation
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequestCanceller_CancelsInFlightToolCall(t *testing.T) {
+	canceller := NewRequestCanceller()
+	options := append([]server.ServerOption{server.WithToolCapabilities(true)}, canceller.ServerOptions()...)
+	mcpServer := server.NewMCPServer("test", "0.0.0", options...)
+	canceller.Register(mcpServer)
+
+	started := make(chan struct{})
+	mcpServer.AddTool(mcp.NewTool("slow_tool"), func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		close(started)
+		<-ctx.Done()
+		return mcp.NewToolResultError(ctx.Err().Error()), nil
+	})
+
+	done := make(chan mcp.JSONRPCMessage, 1)
+	go func() {
+		done <- mcpServer.HandleMessage(context.Background(), []byte(`{"jsonrpc":"2.0","id":7,"method":"tools/call","params":{"name":"slow_tool","arguments":{}}}`))
+	}()
+
+	select {
+	case <-started:
+	case <-time.After(5 * time.Second):
+		t.Fatal("tool call did not start")
+	}
+
+	mcpServer.HandleMessage(context.Background(), []byte(`{"jsonrpc":"2.0","method":"notifications/cancelled","params":{"requestId":7,"reason":"test"}}`))
+
+	select {
+	case response := <-done:
+		result, ok := response.(mcp.JSONRPCResponse)
+		require.True(t, ok, "unexpected response %#v", response)
+		callResult, ok := result.Result.(*mcp.CallToolResult)
+		require.True(t, ok, "unexpected result %#v", result.Result)
+		assert.True(t, callResult.IsError)
+	case <-time.After(5 * time.Second):
+		t.Fatal("tool call was not cancelled")
+	}
+}
+
+func TestRequestCanceller_IgnoresUnknownRequest(t *testing.T) {
+	canceller := NewRequestCanceller()
+	mcpServer := server.NewMCPServer("test", "0.0.0", canceller.ServerOptions()...)
+	canceller.Register(mcpServer)
+
+	assert.NotPanics(t, func() {
+		mcpServer.HandleMessage(context.Background(), []byte(`{"jsonrpc":"2.0","method":"notifications/cancelled","params":{"requestId":"unknown"}}`))
+	})
+}
@@ -4,9 +4,12 @@ import (
 	"encoding/json"
 	"fmt"
 	"reflect"
+	"slices"
+	"strings"
 
 	"github.com/Nick1994209/cloudru-containerapps-mcp/internal/config"
 	"github.com/Nick1994209/cloudru-containerapps-mcp/internal/domain"
+	"github.com/Nick1994209/cloudru-containerapps-mcp/internal/manifest"
 
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
@@ -40,6 +43,8 @@ type MCPServer struct {
 	containerAppsService  domain.ContainerAppsService
 	dockerRegistryService domain.ArtifactRegistryService
 	jobsService           domain.JobsService
+	operationsService     domain.OperationsService
+	secretsService        domain.SecretsService
 
 	mappedFields map[string]struct {
 		envValue     string
@@ -52,7 +57,7 @@ type MCPServer struct {
 }
 
 // NewMCPServer creates a new MCP server with the required services
-func NewMCPServer(descriptionService domain.DescriptionService, dockerService domain.DockerService, containerAppsService domain.ContainerAppsService, dockerRegistryService domain.ArtifactRegistryService, jobsService domain.JobsService) *MCPServer {
+func NewMCPServer(descriptionService domain.DescriptionService, dockerService domain.DockerService, containerAppsService domain.ContainerAppsService, dockerRegistryService domain.ArtifactRegistryService, jobsService domain.JobsService, operationsService domain.OperationsService, secretsService domain.SecretsService) *MCPServer {
 	cfg := config.LoadConfig()
 
 	defaultRepoName := cfg.CurrentDir
@@ -67,6 +72,8 @@ func NewMCPServer(descriptionService domain.DescriptionService, dockerService do
 		containerAppsService:  containerAppsService,
 		dockerRegistryService: dockerRegistryService,
 		jobsService:           jobsService,
+		operationsService:     operationsService,
+		secretsService:        secretsService,
 		cfg:                   cfg,
 
 		mappedFields: map[string]struct {
@@ -91,6 +98,19 @@ func NewMCPServer(descriptionService domain.DescriptionService, dockerService do
 				required:     false,
 				defaultValue: "false",
 			},
+			"secret_name": {
+				description: "Name of the Secret Manager secret",
+				required:    true,
+			},
+			"secret_value": {
+				description: "Value of the secret, e.g. a database password. It is sent only to Secret Manager and never returned",
+				required:    true,
+			},
+			"secret_description": {
+				description:  "Description of the secret",
+				defaultValue: "",
+				required:     false,
+			},
 			"repository_name": {
 				envValue:     cfg.RepositoryName,
 				description:  "Repository name",
@@ -169,10 +189,15 @@ func NewMCPServer(descriptionService domain.DescriptionService, dockerService do
 				required:     false,
 			},
 			"containerapp_cpu": {
-				description:  "CPU allocation (0.1 CPU - 256 Mi RAM, 0.2 CPU - 512 Mi RAM, 0.3 CPU - 768 RAM, 0.5 CPU - 1Gb RAM ...)",
+				description:  "CPU allocation, an invalid value is rejected. Allowed memory per CPU: 0.1 - 128Mi, 256Mi (default), 512Mi; 0.2 - 256Mi, 512Mi (default), 1024Mi; 0.3 - 512Mi, 768Mi (default), 1024Mi; 0.5 - 512Mi, 1024Mi (default), 2048Mi; 1 - 1024Mi, 2048Mi, 4096Mi (default); 2 - 2048Mi, 4096Mi, 8192Mi (default); 4 - 4096Mi, 8192Mi, 16384Mi (default)",
 				defaultValue: "0.1",
 				required:     false,
-				title:        "Options: 0.1, 0.2, 0.3, 0.5, 1",
+				title:        "Options: 0.1, 0.2, 0.3, 0.5, 1, 2, 4",
+			},
+			"containerapp_memory": {
+				description: "Memory allocation in Mi or Gi, must be allowed for the CPU (see the CPU description). Defaults to the default memory of the CPU",
+				required:    false,
+				title:       "For example: 512Mi, 1Gi",
 			},
 			"containerapp_min_instance_count": {
 				description:  "Minimum number of instances for scaling",
@@ -201,22 +226,77 @@ func NewMCPServer(descriptionService domain.DescriptionService, dockerService do
 				title:        "Options: http_1, http_2",
 			},
 			"containerapp_environment_variables": {
-				description:  "Environment variables in format <name>='<value>';<next_name>='value2'",
+				description:  "Environment variables as a JSON object {\"NAME\": \"value\", \"PASSWORD\": {\"value\": \"<secret reference>\", \"type\": \"secret\"}}, a JSON array [{\"name\": \"NAME\", \"value\": \"value\", \"type\": \"plain\"}] or in format <name>='<value>';<next_name>='value2' where a backslash escapes the next character. On patch, variables without a type keep the type of the current variable",
 				defaultValue: "",
 				required:     false,
 			},
 			"containerapp_command": {
-				description:  "Command to run in the container (comma-separated values)",
+				description:  "Command to run in the container, as a JSON array of strings or comma-separated values. On patch an empty JSON array [] resets the command to the image entrypoint",
 				defaultValue: "",
 				required:     false,
 			},
 			"containerapp_args": {
-				description:  "Arguments for the command (comma-separated values)",
+				description:  "Arguments for the command, as a JSON array of strings or comma-separated values. Use a JSON array when values contain commas, e.g. [\"-c\", \"echo a,b\"]. On patch an empty JSON array [] removes the arguments",
+				defaultValue: "",
+				required:     false,
+			},
+			"env_set": {
+				description:  "Environment variables to add or update, keeping all other variables. Accepts the same formats as the environment variables field, variables without a type keep the type of the current variable",
+				defaultValue: "",
+				required:     false,
+			},
+			"env_unset": {
+				description:  "Names of environment variables to remove (comma-separated values)",
+				defaultValue: "",
+				required:     false,
+			},
+			"env_rename": {
+				description:  "Environment variables to rename, keeping their values and types, in format <old_name>=<new_name> (comma-separated values)",
+				defaultValue: "",
+				required:     false,
+				title:        "For example: DB_URL=DATABASE_URL",
+			},
+			"containerapp_container_name": {
+				description:  "Name of the container or init container whose image, port, CPU, memory, environment variables, command and args are patched. Defaults to the main (first) container",
+				defaultValue: "",
+				required:     false,
+			},
+			"containerapp_sidecars": {
+				description:  "Sidecar containers started next to the main container, as a JSON array of objects {\"name\", \"image\", \"cpu\", \"memory\", \"port\", \"env\", \"command\", \"args\", \"volumeMounts\"}. name and image are required, env accepts any environment variables format. On patch containers with the same name are replaced and others are added, the main container cannot be replaced by a sidecar",
+				defaultValue: "",
+				required:     false,
+				title:        "For example: [{\"name\": \"proxy\", \"image\": \"nginx:1.27\", \"port\": 8081}]",
+			},
+			"containerapp_init_containers": {
+				description:  "Init containers which run to completion before the containers are started, as a JSON array of objects {\"name\", \"image\", \"cpu\", \"memory\", \"env\", \"command\", \"args\", \"volumeMounts\"}. On patch init containers with the same name are replaced and others are added",
+				defaultValue: "",
+				required:     false,
+				title:        "For example: [{\"name\": \"migrate\", \"image\": \"my-app:v1\", \"command\": [\"/app/migrate\"]}]",
+			},
+			"containerapp_remove_containers": {
+				description:  "Names of sidecars or init containers to remove (comma-separated values). The main container cannot be removed",
+				defaultValue: "",
+				required:     false,
+			},
+			"containerapp_volumes": {
+				description:  "S3-compatible Object Storage buckets which can be mounted into the containers, as a JSON array of objects {\"name\", \"bucketName\", \"tenantId\", \"region\", \"entrypoint\", \"readOnly\"}. name, bucketName and tenantId are required, region and entrypoint default to Cloud.ru Object Storage. On patch volumes with the same name are replaced and others are added",
+				defaultValue: "",
+				required:     false,
+				title:        "For example: [{\"name\": \"data\", \"bucketName\": \"my-bucket\", \"tenantId\": \"<tenant id>\"}]",
+			},
+			"containerapp_volume_mounts": {
+				description:  "Volume mounts of the main container (or containerapp_container_name on patch), as a JSON array of objects {\"name\", \"mountPath\", \"readOnly\"}. Every mount must reference a declared volume. On patch the mounts of the container are replaced",
+				defaultValue: "",
+				required:     false,
+				title:        "For example: [{\"name\": \"data\", \"mountPath\": \"/data\", \"readOnly\": true}]",
+			},
+			"containerapp_remove_volumes": {
+				description:  "Names of volumes to remove (comma-separated values). A volume which is still mounted cannot be removed",
 				defaultValue: "",
 				required:     false,
 			},
 			"page_size": {
-				description:  "Page size for pagination",
+				description:  "Page size for pagination, list tools with a limit request at most limit records per page",
 				defaultValue: "100",
 				required:     false,
 			},
@@ -235,6 +315,100 @@ func NewMCPServer(descriptionService domain.DescriptionService, dockerService do
 				defaultValue: "",
 				required:     false,
 			},
+			"view": {
+				description:  "Output view: summary returns a compact table and compact records (name, status, image and, for Container Apps, public URI and scaling), full returns all fields as JSON",
+				defaultValue: "summary",
+				required:     false,
+				title:        "Options: summary, full",
+			},
+			"limit": {
+				description:  "Maximum number of records to return",
+				defaultValue: "50",
+				required:     false,
+			},
+			"fetch_all": {
+				description:  "If true, fetch all pages starting from page_token and return them as a single list",
+				defaultValue: "false",
+				required:     false,
+			},
+			"since": {
+				description:  "Return log entries not older than this time: an RFC3339 timestamp or a duration before now. The logs API has no time filter: older pages are fetched while the API returns page tokens, and the result is marked partial when the returned entries do not reach back to this time",
+				defaultValue: "",
+				required:     false,
+				title:        "For example: 15m, 2h or 2024-05-01T10:00:00Z",
+			},
+			"until": {
+				description:  "Return log entries not newer than this time: an RFC3339 timestamp or a duration before now",
+				defaultValue: "",
+				required:     false,
+				title:        "For example: 5m or 2024-05-01T11:00:00Z",
+			},
+			"level": {
+				description:  "Return only log entries with these levels (comma-separated values, case-insensitive)",
+				defaultValue: "",
+				required:     false,
+				title:        "For example: error,warn",
+			},
+			"pod_name": {
+				description:  "Return only log entries of pods whose name contains this value",
+				defaultValue: "",
+				required:     false,
+			},
+			"version_id": {
+				description:  "Return only log entries of this Container App version (revision) ID",
+				defaultValue: "",
+				required:     false,
+			},
+			"container_name": {
+				description:  "Return only log entries of this container, e.g. a sidecar",
+				defaultValue: "",
+				required:     false,
+			},
+			"search": {
+				description:  "Return only log entries whose message contains this text (case-insensitive)",
+				defaultValue: "",
+				required:     false,
+			},
+			"tail_duration": {
+				description:  "How long to follow the logs, at most 10m",
+				defaultValue: "60s",
+				required:     false,
+				title:        "For example: 90s or 5m",
+			},
+			"tail_interval": {
+				description:  "How often to poll the logs, at least 1s",
+				defaultValue: "5s",
+				required:     false,
+				title:        "For example: 2s or 10s",
+			},
+			"event_type": {
+				description:  "Return only system events of this type (case-insensitive)",
+				defaultValue: "",
+				required:     false,
+				title:        "For example: Warning or Normal",
+			},
+			"component": {
+				description:  "Return only system events reported by this component (case-insensitive)",
+				defaultValue: "",
+				required:     false,
+				title:        "For example: scheduler or kubelet",
+			},
+			"revision_name": {
+				description:  "Return only system events of this Container App revision",
+				defaultValue: "",
+				required:     false,
+			},
+			"max_lines": {
+				description:  "Maximum number of the latest matching log entries to return",
+				defaultValue: "200",
+				required:     false,
+			},
+			"log_format": {
+				description:  "Log output format: json returns log entries as JSON, text returns one line per entry, e.g. <timestamp> <level> <pod>: <message> for application logs",
+				defaultValue: logFormatJSON,
+				required:     false,
+				title:        "Options: json, text",
+			},
 			"job_id": {
 				description: "Job ID (deprecated - use job_name instead)",
 				required:    false,
@@ -244,6 +418,10 @@ func NewMCPServer(descriptionService domain.DescriptionService, dockerService do
 				required:    true,
 				title:       "You can use example: my-job",
 			},
+			"execution_name": {
+				description: "Name of a Job execution (the executionName field returned by cloudru_job_executions_list and cloudru_execute_job)",
+				required:    true,
+			},
 			"params": {
 				description: "Job execution parameters in JSON format",
 				required:    false,
@@ -259,10 +437,15 @@ func NewMCPServer(descriptionService domain.DescriptionService, dockerService do
 				required:     false,
 			},
 			"job_cpu": {
-				description:  "CPU allocation (0.1 CPU - 256 Mi RAM, 0.2 CPU - 512 Mi RAM, 0.3 CPU - 768 RAM, 0.5 CPU - 1Gb RAM ...)",
+				description:  "CPU allocation, an invalid value is rejected. Allowed memory per CPU: 0.1 - 128Mi, 256Mi (default), 512Mi; 0.2 - 256Mi, 512Mi (default), 1024Mi; 0.3 - 512Mi, 768Mi (default), 1024Mi; 0.5 - 512Mi, 1024Mi (default), 2048Mi; 1 - 1024Mi, 2048Mi, 4096Mi (default); 2 - 2048Mi, 4096Mi, 8192Mi (default); 4 - 4096Mi, 8192Mi, 16384Mi (default)",
 				defaultValue: "0.1",
 				required:     false,
-				title:        "Options: 0.1, 0.2, 0.3, 0.5, 1",
+				title:        "Options: 0.1, 0.2, 0.3, 0.5, 1, 2, 4",
+			},
+			"job_memory": {
+				description: "Memory allocation in Mi or Gi, must be allowed for the CPU (see the CPU description). Defaults to the default memory of the CPU",
+				required:    false,
+				title:       "For example: 512Mi, 1Gi",
 			},
 			"job_description": {
 				description:  "Description of the job",
@@ -270,17 +453,17 @@ func NewMCPServer(descriptionService domain.DescriptionService, dockerService do
 				required:     false,
 			},
 			"job_environment_variables": {
-				description:  "Environment variables in format <name>='<value>';<next_name>='value2'",
+				description:  "Environment variables as a JSON object {\"NAME\": \"value\", \"PASSWORD\": {\"value\": \"<secret reference>\", \"type\": \"secret\"}}, a JSON array [{\"name\": \"NAME\", \"value\": \"value\", \"type\": \"plain\"}] or in format <name>='<value>';<next_name>='value2' where a backslash escapes the next character. On patch, variables without a type keep the type of the current variable",
 				defaultValue: "",
 				required:     false,
 			},
 			"job_command": {
-				description:  "Command to run in the job (comma-separated values)",
+				description:  "Command to run in the job, as a JSON array of strings or comma-separated values. On patch an empty JSON array [] resets the command to the image entrypoint",
 				defaultValue: "",
 				required:     false,
 			},
 			"job_args": {
-				description:  "Arguments for the command (comma-separated values)",
+				description:  "Arguments for the command, as a JSON array of strings or comma-separated values. Use a JSON array when values contain commas, e.g. [\"-c\", \"echo a,b\"]. On patch an empty JSON array [] removes the arguments",
 				defaultValue: "",
 				required:     false,
 			},
@@ -294,11 +477,64 @@ func NewMCPServer(descriptionService domain.DescriptionService, dockerService do
 				defaultValue: "3600",
 				required:     false,
 			},
+			"job_schedule": {
+				description: "How often the Job should run",
+				required:    true,
+				title:       "For example: every day at 03:00 UTC",
+			},
+			"rollback_image": {
+				description: "Image to roll back to, the previously deployed image is looked up when empty",
+				required:    false,
+				title:       "Example image: " + containerappImage,
+			},
 			"job_run_immediately": {
 				description:  "Run job immediately after creation",
 				defaultValue: "false",
 				required:     false,
 			},
+			"dry_run": {
+				description:  "If true, return the exact request payload and a diff against the current object without calling the API. Delete, start and stop return the current object instead of a diff",
+				defaultValue: "false",
+				required:     false,
+			},
+			"manifest_path": {
+				description:  "Path to the manifest file describing a Container App or a Job, relative to the working directory of the server. Absolute paths and paths outside of the working directory are rejected",
+				defaultValue: manifest.DefaultPath,
+				required:     false,
+			},
+			"export_format": {
+				description:  "Export format: manifest returns cloudru.yaml, tool_call returns arguments of the create tool",
+				defaultValue: exportFormatManifest,
+				required:     false,
+				title:        "Options: manifest, tool_call",
+			},
+			"target_project_id": {
+				description: "Project ID of the target project, e.g. to migrate or promote a Container App or a Job to another project",
+				required:    false,
+			},
+			"target_containerapp_name": {
+				description: "Name of the target Container App, defaults to the source name",
+				required:    false,
+			},
+			"target_job_name": {
+				description: "Name of the target Job, defaults to the source name",
+				required:    false,
+			},
+			"operation_id": {
+				description: "ID of a long-running operation (the id field returned by create, patch, delete, start and stop functions)",
+				required:    true,
+			},
+			"wait": {
+				description:  "If true, wait until the operation is done and return its final state or error",
+				defaultValue: "false",
+				required:     false,
+			},
+			"wait_timeout": {
+				description:  "Maximum time to wait for the operation to complete",
+				defaultValue: "600s",
+				required:     false,
+				title:        "For example: 90s or 10m",
+			},
 		},
 	}
 }
@@ -388,6 +624,112 @@ func (s *MCPServer) getMCPBooleanFieldValue(field string, request mcp.CallToolRe
 	}
 }
 
+// getMCPListFieldValue gets a list field given as a JSON array of strings or as comma-separated values, nil if the field is empty.
+// Values of a JSON array are kept as is, comma-separated values are trimmed
+func (s *MCPServer) getMCPListFieldValue(field string, request mcp.CallToolRequest) ([]string, error) {
+	fieldValueStr, err := s.getMCPFieldValue(field, request)
+	if err != nil {
+		return nil, err
+	}
+	if fieldValueStr == "" {
+		return nil, nil
+	}
+
+	if strings.HasPrefix(strings.TrimSpace(fieldValueStr), "[") {
+		values := []string{}
+		if err := json.Unmarshal([]byte(fieldValueStr), &values); err != nil {
+			return nil, fmt.Errorf("field %s must be a JSON array of strings or comma-separated values: %w", field, err)
+		}
+		return values, nil
+	}
+
+	values := strings.Split(fieldValueStr, ",")
+	for i, value := range values {
+		values[i] = strings.TrimSpace(value)
+	}
+	return values, nil
+}
+
+// formatListFieldValue formats values as a JSON array accepted by list fields, empty if there are no values
+func formatListFieldValue(values []string) string {
+	if len(values) == 0 {
+		return ""
+	}
+	result, _ := json.Marshal(values)
+	return string(result)
+}
+
+// getListOptions gets pagination, filtering and sorting parameters of list tools
+func (s *MCPServer) getListOptions(request mcp.CallToolRequest) (domain.ListOptions, error) {
+	pageSize, err := s.getMCPFieldValue("page_size", request)
+	if err != nil {
+		return domain.ListOptions{}, err
+	}
+	pageToken, err := s.getMCPFieldValue("page_token", request)
+	if err != nil {
+		return domain.ListOptions{}, err
+	}
+	filter, err := s.getMCPFieldValue("filter", request)
+	if err != nil {
+		return domain.ListOptions{}, err
+	}
+	orderBy, err := s.getMCPFieldValue("order_by", request)
+	if err != nil {
+		return domain.ListOptions{}, err
+	}
+	fetchAll, err := s.getMCPBooleanFieldValue("fetch_all", request)
+	if err != nil {
+		return domain.ListOptions{}, err
+	}
+
+	return domain.ListOptions{
+		PageSize:  pageSize,
+		PageToken: pageToken,
+		Filter:    filter,
+		OrderBy:   orderBy,
+		FetchAll:  fetchAll,
+	}, nil
+}
+
+// getEnvironmentVariableChanges gets incremental environment variable changes of patch tools
+func (s *MCPServer) getEnvironmentVariableChanges(request mcp.CallToolRequest) (domain.EnvironmentVariableChanges, error) {
+	set, err := s.getMCPFieldValue("env_set", request)
+	if err != nil {
+		return domain.EnvironmentVariableChanges{}, err
+	}
+	changes := domain.EnvironmentVariableChanges{Set: set}
+
+	unset, err := s.getMCPFieldValue("env_unset", request)
+	if err != nil {
+		return domain.EnvironmentVariableChanges{}, err
+	}
+	for _, name := range strings.Split(unset, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			changes.Unset = append(changes.Unset, name)
+		}
+	}
+
+	rename, err := s.getMCPFieldValue("env_rename", request)
+	if err != nil {
+		return domain.EnvironmentVariableChanges{}, err
+	}
+	for _, pair := range strings.Split(rename, ",") {
+		if strings.TrimSpace(pair) == "" {
+			continue
+		}
+		oldName, newName, ok := strings.Cut(pair, "=")
+		oldName, newName = strings.TrimSpace(oldName), strings.TrimSpace(newName)
+		if !ok || oldName == "" || newName == "" {
+			return domain.EnvironmentVariableChanges{}, fmt.Errorf("field env_rename must be in format <old_name>=<new_name>, got: %s", pair)
+		}
+		if changes.Rename == nil {
+			changes.Rename = map[string]string{}
+		}
+		changes.Rename[oldName] = newName
+	}
+	return changes, nil
+}
+
 // checkRequestHasKey checks if a request has a specific key in its arguments
 func checkRequestHasKey(r mcp.CallToolRequest, key string) bool {
 	args := r.GetArguments()
@@ -395,6 +737,13 @@ func checkRequestHasKey(r mcp.CallToolRequest, key string) bool {
 	return ok
 }
 
+// optionalFields removes fields from the required tool parameters, for fields which are needed only in some cases
+func optionalFields(tool *mcp.Tool, fields ...string) {
+	tool.InputSchema.Required = slices.DeleteFunc(tool.InputSchema.Required, func(field string) bool {
+		return slices.Contains(fields, field)
+	})
+}
+
 // RegisterAllTools registers all tools with the MCP server
 func (s *MCPServer) RegisterAllTools(mcpServer *server.MCPServer) {
 	s.RegisterDescriptionTool(mcpServer)
@@ -408,9 +757,12 @@ func (s *MCPServer) RegisterAllTools(mcpServer *server.MCPServer) {
 	s.RegisterStartContainerAppTool(mcpServer)
 	s.RegisterStopContainerAppTool(mcpServer)
 	s.RegisterGetContainerAppLogsTool(mcpServer)
+	s.RegisterTailContainerAppLogsTool(mcpServer)
 	s.RegisterGetContainerAppSystemLogsTool(mcpServer)
 	s.RegisterGetListDockerRegistriesTool(mcpServer)
 	s.RegisterCreateDockerRegistryTool(mcpServer)
+	s.RegisterGetListSecretsTool(mcpServer)
+	s.RegisterCreateSecretTool(mcpServer)
 	s.RegisterGetRegistryImagesTool(mcpServer)
 	s.RegisterGetListJobsTool(mcpServer)
 	s.RegisterGetJobTool(mcpServer)
@@ -418,4 +770,15 @@ func (s *MCPServer) RegisterAllTools(mcpServer *server.MCPServer) {
 	s.RegisterDeleteJobTool(mcpServer)
 	s.RegisterExecuteJobTool(mcpServer)
 	s.RegisterGetListExecutionsTool(mcpServer)
+	s.RegisterGetJobExecutionTool(mcpServer)
+	s.RegisterGetJobExecutionLogsTool(mcpServer)
+	s.RegisterGetOperationTool(mcpServer)
+	s.RegisterWaitOperationTool(mcpServer)
+	s.RegisterDeployTool(mcpServer)
+	s.RegisterApplyTool(mcpServer)
+	s.RegisterDiffTool(mcpServer)
+	s.RegisterExportContainerAppTool(mcpServer)
+	s.RegisterExportJobTool(mcpServer)
+	s.RegisterCloneContainerAppTool(mcpServer)
+	s.RegisterCloneJobTool(mcpServer)
 }
@@ -0,0 +1,159 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/Nick1994209/cloudru-containerapps-mcp/internal/manifest"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// RegisterCloneContainerAppTool registers the clone container app tool with the MCP server
+func (s *MCPServer) RegisterCloneContainerAppTool(mcpServer *server.MCPServer) {
+	// Prepare tool options including description and fields
+	toolOptions := s.getMCPFieldsOptions(
+		"Clone a Container App in Cloud.ru into another project or under another name, e.g. to promote staging to production. All fields including privileged mode, sidecars, init containers and volumes are copied from the source, the image, environment variables and scaling of the main container can be overridden",
+		"project_id",
+		"containerapp_name",
+		"target_project_id",
+		"target_containerapp_name",
+		"containerapp_image",
+		"containerapp_environment_variables",
+		"containerapp_min_instance_count",
+		"containerapp_max_instance_count",
+		"wait",
+		"wait_timeout",
+		"dry_run",
+	)
+	toolOptions = append(toolOptions, outputSchema[operationOutput]())
+	cloneTool := mcp.NewTool("cloudru_clone_containerapp", toolOptions...)
+	// The image is copied from the source when it is not overridden
+	optionalFields(&cloneTool, "containerapp_image")
+
+	mcpServer.AddTool(cloneTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		// Get project ID
+		projectID, err := s.getMCPFieldValue("project_id", request)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		// Get container app name
+		containerAppName, err := s.getMCPFieldValue("containerapp_name", request)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		targetProjectID, targetName, err := s.getCloneTarget(request, "target_containerapp_name", projectID, containerAppName)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		overrides, err := s.getCloneOverrides(request, "containerapp_image", "containerapp_environment_variables")
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		if overrides.MinInstanceCount, err = s.getCloneIntOverride(request, "containerapp_min_instance_count"); err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		if overrides.MaxInstanceCount, err = s.getCloneIntOverride(request, "containerapp_max_instance_count"); err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		// Get the source Container App
+		raw, err := s.containerAppsService.GetContainerAppRaw(ctx, projectID, containerAppName)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		// The create payload is the source object itself, so settings the manifest cannot express are not lost
+		object, err := manifest.CloneObject(raw, targetName, overrides)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		// Record the request instead of sending it if dry_run=true
+		ctx, dryRun, err := s.getDryRunContext(ctx, request)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		// Call the service
+		operation, err := s.containerAppsService.CreateContainerAppFromObject(ctx, targetProjectID, object)
+		if result, ok := dryRunToolResult(dryRun, err, dryRunOperationOutput); ok {
+			return result, nil
+		}
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		// Wait for the operation to complete if requested
+		operation, err = s.waitOperationIfRequested(ctx, request, operation)
+		if err != nil {
+			return mcp.NewToolResultError(operationErrorText(operation, err)), nil
+		}
+
+		message := fmt.Sprintf("Successfully cloned Container App %s/%s to %s/%s", projectID, containerAppName, targetProjectID, targetName)
+		return newToolResultJSON(operation, message), nil
+	})
+}
+
+// getCloneTarget gets the target project and name of a clone, at least one of them must differ from the source
+func (s *MCPServer) getCloneTarget(request mcp.CallToolRequest, nameField string, projectID string, name string) (string, string, error) {
+	targetProjectID, err := s.getMCPFieldValue("target_project_id", request)
+	if err != nil {
+		return "", "", err
+	}
+	if targetProjectID == "" {
+		targetProjectID = projectID
+	}
+
+	targetName, err := s.getMCPFieldValue(nameField, request)
+	if err != nil {
+		return "", "", err
+	}
+	if targetName == "" {
+		targetName = name
+	}
+
+	if targetProjectID == projectID && targetName == name {
+		return "", "", fmt.Errorf("target_project_id or %s must differ from the source", nameField)
+	}
+	return targetProjectID, targetName, nil
+}
+
+// getCloneOverrides gets the image and environment variables overrides, fields which are not passed are copied from the source
+func (s *MCPServer) getCloneOverrides(request mcp.CallToolRequest, imageField string, environmentVariablesField string) (manifest.Overrides, error) {
+	var overrides manifest.Overrides
+	if checkRequestHasKey(request, imageField) {
+		image, err := s.getMCPFieldValue(imageField, request)
+		if err != nil {
+			return overrides, err
+		}
+		overrides.Image = &image
+	}
+	if checkRequestHasKey(request, environmentVariablesField) {
+		environmentVariables, err := s.getMCPFieldValue(environmentVariablesField, request)
+		if err != nil {
+			return overrides, err
+		}
+		overrides.EnvironmentVariables = &environmentVariables
+	}
+	return overrides, nil
+}
+
+// getCloneIntOverride gets a number override, nil if the field is not passed
+func (s *MCPServer) getCloneIntOverride(request mcp.CallToolRequest, field string) (*int, error) {
+	if !checkRequestHasKey(request, field) {
+		return nil, nil
+	}
+	valueStr, err := s.getMCPFieldValue(field, request)
+	if err != nil {
+		return nil, err
+	}
+	value, err := strconv.Atoi(valueStr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %s", field, valueStr)
+	}
+	return &value, nil
+}
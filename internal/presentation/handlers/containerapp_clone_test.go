@@ -0,0 +1,78 @@
+package handlers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Nick1994209/cloudru-containerapps-mcp/internal/domain"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// cloneContainerAppsService records the project and the object of the clone
+type cloneContainerAppsService struct {
+	exportContainerAppsService
+	projectID string
+	created   []byte
+}
+
+func (f *cloneContainerAppsService) CreateContainerAppFromObject(ctx context.Context, projectID string, object []byte) (*domain.Operation, error) {
+	f.projectID = projectID
+	f.created = object
+	return &domain.Operation{ID: "operation-1"}, nil
+}
+
+func callCloneContainerAppTool(t *testing.T, containerApps *cloneContainerAppsService, arguments map[string]any) *mcp.CallToolResult {
+	t.Setenv("CLOUDRU_KEY_ID", "key-id")
+	t.Setenv("CLOUDRU_KEY_SECRET", "key-secret")
+
+	s := NewMCPServer(nil, nil, containerApps, nil, nil, nil, nil)
+	mcpServer := server.NewMCPServer("test", "0.0.0", server.WithToolCapabilities(false))
+	s.RegisterCloneContainerAppTool(mcpServer)
+
+	request := mcp.CallToolRequest{}
+	request.Params.Name = "cloudru_clone_containerapp"
+	request.Params.Arguments = arguments
+	result, err := mcpServer.GetTool("cloudru_clone_containerapp").Handler(context.Background(), request)
+	require.NoError(t, err)
+	return result
+}
+
+func TestCloneContainerAppTool_OverridesFields(t *testing.T) {
+	containerApps := &cloneContainerAppsService{}
+
+	result := callCloneContainerAppTool(t, containerApps, map[string]any{
+		"project_id":                         "staging",
+		"containerapp_name":                  "my-app",
+		"target_project_id":                  "production",
+		"containerapp_image":                 "app:v2",
+		"containerapp_environment_variables": "B='2'",
+		"containerapp_max_instance_count":    "5",
+	})
+
+	require.False(t, result.IsError, "%v", result.Content)
+	require.NotNil(t, containerApps.created)
+	assert.Equal(t, "production", containerApps.projectID)
+	assert.JSONEq(t, `{
+		"name": "my-app",
+		"configuration": {"ingress": {"publiclyAccessible": true}},
+		"template": {
+			"scaling": {"maxInstanceCount": 5},
+			"containers": [{"image": "app:v2", "containerPort": 8080, "env": [{"name": "A", "value": "1"}, {"name": "B", "value": "2"}]}]
+		}
+	}`, string(containerApps.created))
+}
+
+func TestCloneContainerAppTool_RequiresAnotherTarget(t *testing.T) {
+	containerApps := &cloneContainerAppsService{}
+
+	result := callCloneContainerAppTool(t, containerApps, map[string]any{
+		"project_id":        "staging",
+		"containerapp_name": "my-app",
+	})
+
+	assert.True(t, result.IsError)
+	assert.Nil(t, containerApps.created)
+}
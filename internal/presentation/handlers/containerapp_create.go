@@ -2,9 +2,7 @@ package handlers
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
-	"strings"
 
 	"github.com/Nick1994209/cloudru-containerapps-mcp/internal/domain"
 	"github.com/mark3labs/mcp-go/mcp"
@@ -26,6 +24,7 @@ func (s *MCPServer) RegisterCreateContainerAppTool(mcpServer *server.MCPServer)
 		"containerapp_idle_timeout",
 		"containerapp_timeout",
 		"containerapp_cpu",
+		"containerapp_memory",
 		"containerapp_min_instance_count",
 		"containerapp_max_instance_count",
 		"containerapp_description",
@@ -34,7 +33,15 @@ func (s *MCPServer) RegisterCreateContainerAppTool(mcpServer *server.MCPServer)
 		"containerapp_environment_variables",
 		"containerapp_command",
 		"containerapp_args",
+		"containerapp_sidecars",
+		"containerapp_init_containers",
+		"containerapp_volumes",
+		"containerapp_volume_mounts",
+		"wait",
+		"wait_timeout",
+		"dry_run",
 	)
+	toolOptions = append(toolOptions, outputSchema[operationOutput]())
 	createContainerAppTool := mcp.NewTool("cloudru_create_containerapp", toolOptions...)
 
 	mcpServer.AddTool(createContainerAppTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -99,6 +106,9 @@ func (s *MCPServer) RegisterCreateContainerAppTool(mcpServer *server.MCPServer)
 			cpu = "0.1"
 		}
 
+		// Get memory
+		memory, _ := s.getMCPFieldValue("containerapp_memory", request)
+
 		// Get min instance count
 		minInstanceCountStr, _ := s.getMCPFieldValue("containerapp_min_instance_count", request)
 		var minInstanceCount int
@@ -132,29 +142,25 @@ func (s *MCPServer) RegisterCreateContainerAppTool(mcpServer *server.MCPServer)
 		environmentVariables, _ := s.getMCPFieldValue("containerapp_environment_variables", request)
 
 		// Get command
-		commandStr, _ := s.getMCPFieldValue("containerapp_command", request)
-		var command []string
-		if commandStr != "" {
-			// Split by comma
-			command = strings.Split(commandStr, ",")
-			// Trim spaces from each command
-			for i, cmd := range command {
-				command[i] = strings.TrimSpace(cmd)
-			}
+		command, err := s.getMCPListFieldValue("containerapp_command", request)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
 		}
 
 		// Get args
-		argsStr, _ := s.getMCPFieldValue("containerapp_args", request)
-		var args []string
-		if argsStr != "" {
-			// Split by comma
-			args = strings.Split(argsStr, ",")
-			// Trim spaces from each arg
-			for i, arg := range args {
-				args[i] = strings.TrimSpace(arg)
-			}
+		args, err := s.getMCPListFieldValue("containerapp_args", request)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
 		}
 
+		// Get sidecars and init containers
+		sidecars, _ := s.getMCPFieldValue("containerapp_sidecars", request)
+		initContainers, _ := s.getMCPFieldValue("containerapp_init_containers", request)
+
+		// Get volumes and volume mounts of the main container
+		volumes, _ := s.getMCPFieldValue("containerapp_volumes", request)
+		volumeMounts, _ := s.getMCPFieldValue("containerapp_volume_mounts", request)
+
 		// Create the request struct
 		createRequest := domain.CreateContainerAppRequest{
 			ProjectID:              projectID,
@@ -167,6 +173,7 @@ func (s *MCPServer) RegisterCreateContainerAppTool(mcpServer *server.MCPServer)
 			IdleTimeout:            idleTimeout,
 			Timeout:                timeout,
 			CPU:                    cpu,
+			Memory:                 memory,
 			MinInstanceCount:       minInstanceCount,
 			MaxInstanceCount:       maxInstanceCount,
 			Description:            description,
@@ -175,20 +182,33 @@ func (s *MCPServer) RegisterCreateContainerAppTool(mcpServer *server.MCPServer)
 			EnvironmentVariables:   environmentVariables,
 			Command:                command,
 			Args:                   args,
+			Sidecars:               sidecars,
+			InitContainers:         initContainers,
+			Volumes:                volumes,
+			VolumeMounts:           volumeMounts,
+		}
+
+		// Record the request instead of sending it if dry_run=true
+		ctx, dryRun, err := s.getDryRunContext(ctx, request)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
 		}
 
 		// Call the service
-		operation, err := s.containerAppsService.CreateContainerApp(createRequest)
+		operation, err := s.containerAppsService.CreateContainerApp(ctx, createRequest)
+		if result, ok := dryRunToolResult(dryRun, err, dryRunOperationOutput); ok {
+			return result, nil
+		}
 		if err != nil {
 			return mcp.NewToolResultError(err.Error()), nil
 		}
 
-		// Convert to JSON for output
-		result, err := json.MarshalIndent(operation, "", "  ")
+		// Wait for the operation to complete if requested
+		operation, err = s.waitOperationIfRequested(ctx, request, operation)
 		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("Failed to format result: %v", err)), nil
+			return mcp.NewToolResultError(operationErrorText(operation, err)), nil
 		}
 
-		return mcp.NewToolResultText(fmt.Sprintf("Successfully created Container App: %s\n%s", containerAppName, string(result))), nil
+		return newToolResultJSON(operation, fmt.Sprintf("Successfully created Container App: %s", containerAppName)), nil
 	})
 }
@@ -2,7 +2,6 @@ package handlers
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 
 	"github.com/mark3labs/mcp-go/mcp"
@@ -16,7 +15,11 @@ func (s *MCPServer) RegisterDeleteContainerAppTool(mcpServer *server.MCPServer)
 		"Delete a Container App from Cloud.ru. WARNING: This action cannot be undone!",
 		"project_id",
 		"containerapp_name",
+		"wait",
+		"wait_timeout",
+		"dry_run",
 	)
+	toolOptions = append(toolOptions, outputSchema[operationOutput]())
 	deleteContainerAppTool := mcp.NewTool("cloudru_delete_containerapp", toolOptions...)
 
 	mcpServer.AddTool(deleteContainerAppTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -35,18 +38,27 @@ func (s *MCPServer) RegisterDeleteContainerAppTool(mcpServer *server.MCPServer)
 		// Confirmation prompt - in MCP context, we'll add a warning in the description
 		// but the actual confirmation would typically happen in the client UI
 
+		// Record the request instead of sending it if dry_run=true
+		ctx, dryRun, err := s.getDryRunContext(ctx, request)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
 		// Call the service
-		operation, err := s.containerAppsService.DeleteContainerApp(projectID, containerAppName)
+		operation, err := s.containerAppsService.DeleteContainerApp(ctx, projectID, containerAppName)
+		if result, ok := dryRunToolResult(dryRun, err, dryRunOperationOutput); ok {
+			return result, nil
+		}
 		if err != nil {
 			return mcp.NewToolResultError(err.Error()), nil
 		}
 
-		// Convert to JSON for output
-		result, err := json.MarshalIndent(operation, "", "  ")
+		// Wait for the operation to complete if requested
+		operation, err = s.waitOperationIfRequested(ctx, request, operation)
 		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("Failed to format result: %v", err)), nil
+			return mcp.NewToolResultError(operationErrorText(operation, err)), nil
 		}
 
-		return mcp.NewToolResultText(fmt.Sprintf("Successfully deleted Container App: %s\n%s", containerAppName, string(result))), nil
+		return newToolResultJSON(operation, fmt.Sprintf("Successfully deleted Container App: %s", containerAppName)), nil
 	})
 }
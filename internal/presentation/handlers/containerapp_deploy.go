@@ -0,0 +1,221 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Nick1994209/cloudru-containerapps-mcp/internal/domain"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// Deploy tool actions
+const (
+	deployActionCreated = "created"
+	deployActionPatched = "patched"
+)
+
+// RegisterDeployTool registers the deploy tool with the MCP server
+func (s *MCPServer) RegisterDeployTool(mcpServer *server.MCPServer) {
+	// Prepare tool options including description and fields
+	toolOptions := s.getMCPFieldsOptions(
+		"Deploy the current directory to Cloud.ru: build and push the Docker image, create the Container App or update its image (pinned by digest), wait for the operation and return the public URI and status. With dry_run=true only report the planned build, push and create or update",
+		"project_id",
+		"containerapp_name",
+		"registry_name",
+		"repository_name",
+		"image_version",
+		"dockerfile_path",
+		"dockerfile_target",
+		"dockerfile_folder",
+		"containerapp_port",
+		"containerapp_publicly_accessible",
+		"wait_timeout",
+		"dry_run",
+	)
+	toolOptions = append(toolOptions, outputSchema[deployOutput]())
+	deployTool := mcp.NewTool("cloudru_deploy", toolOptions...)
+	// The port is needed only when the Container App does not exist yet
+	optionalFields(&deployTool, "containerapp_port")
+
+	mcpServer.AddTool(deployTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		// Get project ID
+		projectID, err := s.getMCPFieldValue("project_id", request)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		// Get container app name
+		containerAppName, err := s.getMCPFieldValue("containerapp_name", request)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		registryName, err := s.getMCPFieldValue("registry_name", request)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		repositoryName, err := s.getMCPFieldValue("repository_name", request)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		imageVersion, _ := s.getMCPFieldValue("image_version", request)
+		dockerfilePath, _ := request.RequireString("dockerfile_path")
+		dockerfileTarget, _ := request.RequireString("dockerfile_target")
+		dockerfileFolder, _ := request.RequireString("dockerfile_folder")
+
+		// Get wait timeout
+		timeout, err := s.getMCPDurationFieldValue("wait_timeout", request)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		dryRun, err := s.getMCPBooleanFieldValue("dry_run", request)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		// Check whether the Container App exists before the build, so a missing port fails fast
+		_, err = s.containerAppsService.GetContainerApp(ctx, projectID, containerAppName)
+		exists := err == nil
+		if err != nil && !errors.Is(err, domain.ErrNotFound) {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		var createRequest domain.CreateContainerAppRequest
+		if !exists {
+			createRequest, err = s.getDeployCreateRequest(request, projectID, containerAppName)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+		}
+
+		dockerImage := domain.DockerImage{
+			RegistryName:     registryName,
+			RepositoryName:   repositoryName,
+			ImageVersion:     imageVersion,
+			DockerfilePath:   dockerfilePath,
+			DockerfileTarget: dockerfileTarget,
+			DockerfileFolder: dockerfileFolder,
+		}
+		if dryRun {
+			return s.deployDryRunResult(dockerImage, containerAppName, exists, createRequest), nil
+		}
+
+		// Build and push the image
+		imageTag, err := s.dockerService.BuildAndPush(ctx, dockerImage)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		// Pin the image by digest, so the Container App runs exactly the pushed image
+		image, err := s.dockerService.GetImageDigestReference(ctx, imageTag)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		// Create the Container App or update its image
+		var operation *domain.Operation
+		action := deployActionPatched
+		if exists {
+			operation, err = s.containerAppsService.PatchContainerApp(ctx, projectID, containerAppName, domain.PatchContainerAppRequest{
+				ProjectID:         projectID,
+				ContainerAppName:  containerAppName,
+				ContainerAppImage: &image,
+			})
+		} else {
+			action = deployActionCreated
+			createRequest.ContainerAppImage = image
+			operation, err = s.containerAppsService.CreateContainerApp(ctx, createRequest)
+		}
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("image %s was pushed, but the Container App was not %s: %s", image, action, err.Error())), nil
+		}
+
+		// Wait for the operation to complete
+		if operation != nil {
+			operation, err = s.waitOperation(ctx, operation, timeout)
+			if err != nil {
+				return mcp.NewToolResultError(operationErrorText(operation, err)), nil
+			}
+		}
+
+		// Get the final state of the Container App
+		containerApp, err := s.containerAppsService.GetContainerApp(ctx, projectID, containerAppName)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Container App %s was %s, but its state is unknown: %s", containerAppName, action, err.Error())), nil
+		}
+
+		output := deployOutput{
+			Image:     image,
+			Action:    action,
+			Status:    containerApp.Status,
+			PublicURI: containerApp.Configuration.Ingress.PublicUri,
+			Operation: operation,
+		}
+		return newToolResultJSON(output, fmt.Sprintf("Successfully deployed Container App %s (%s) with image %s", containerAppName, action, image)), nil
+	})
+}
+
+// deployDryRunResult reports the planned build, push and create or update of the Container App without running them.
+// The image digest is known only after the push, so the plan refers to the image tag
+func (s *MCPServer) deployDryRunResult(image domain.DockerImage, containerAppName string, exists bool, createRequest domain.CreateContainerAppRequest) *mcp.CallToolResult {
+	imageTag, buildCommand, pushCommand := s.dockerService.GetBuildAndPushCommands(image)
+
+	plan := &deployPlanOutput{BuildCommand: buildCommand, PushCommand: pushCommand}
+	action := deployActionPatched
+	step := fmt.Sprintf("patch the image of Container App %s to %s pinned by digest", containerAppName, imageTag)
+	if !exists {
+		action = deployActionCreated
+		createRequest.ContainerAppImage = imageTag
+		plan.Create = &createRequest
+		step = fmt.Sprintf("create Container App %s with image %s pinned by digest", containerAppName, imageTag)
+	}
+
+	output := deployOutput{Image: imageTag, Action: action, DryRun: plan}
+	message := fmt.Sprintf("Dry run: nothing was built, pushed or changed. Planned steps:\n1. %s\n2. %s\n3. %s", buildCommand, pushCommand, step)
+	return newToolResultJSON(output, message)
+}
+
+// getDeployCreateRequest builds the request to create a Container App from the deploy tool fields and the create defaults
+func (s *MCPServer) getDeployCreateRequest(request mcp.CallToolRequest, projectID string, containerAppName string) (domain.CreateContainerAppRequest, error) {
+	containerAppPortStr, err := s.getMCPFieldValue("containerapp_port", request)
+	if err != nil {
+		return domain.CreateContainerAppRequest{}, fmt.Errorf("Container App %s does not exist, containerapp_port is required to create it", containerAppName)
+	}
+	var containerAppPort int
+	if n, err := fmt.Sscanf(containerAppPortStr, "%d", &containerAppPort); err != nil || n != 1 {
+		return domain.CreateContainerAppRequest{}, fmt.Errorf("failed to parse containerapp_port: %s", containerAppPortStr)
+	}
+
+	publiclyAccessible, err := s.getMCPBooleanFieldValue("containerapp_publicly_accessible", request)
+	if err != nil {
+		return domain.CreateContainerAppRequest{}, err
+	}
+
+	// Fields which are not exposed by the deploy tool fall back to the create tool defaults
+	autoDeploymentsPattern, _ := s.getMCPFieldValue("containerapp_auto_deployments_pattern", request)
+	idleTimeout, _ := s.getMCPFieldValue("containerapp_idle_timeout", request)
+	timeout, _ := s.getMCPFieldValue("containerapp_timeout", request)
+	cpu, _ := s.getMCPFieldValue("containerapp_cpu", request)
+	description, _ := s.getMCPFieldValue("containerapp_description", request)
+	protocol, _ := s.getMCPFieldValue("containerapp_protocol", request)
+
+	return domain.CreateContainerAppRequest{
+		ProjectID:              projectID,
+		ContainerAppName:       containerAppName,
+		ContainerAppPort:       containerAppPort,
+		AutoDeploymentsPattern: autoDeploymentsPattern,
+		IdleTimeout:            idleTimeout,
+		Timeout:                timeout,
+		CPU:                    cpu,
+		MinInstanceCount:       0,
+		MaxInstanceCount:       1,
+		Description:            description,
+		PubliclyAccessible:     publiclyAccessible,
+		Protocol:               protocol,
+	}, nil
+}
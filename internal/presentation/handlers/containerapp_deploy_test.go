@@ -0,0 +1,179 @@
+package handlers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Nick1994209/cloudru-containerapps-mcp/internal/domain"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeDockerService pretends to build and push images
+type fakeDockerService struct {
+	domain.DockerService
+}
+
+func (f *fakeDockerService) BuildAndPush(ctx context.Context, image domain.DockerImage) (string, error) {
+	return image.RegistryName + ".cr.cloud.ru/" + image.RepositoryName + ":" + image.ImageVersion, nil
+}
+
+func (f *fakeDockerService) GetBuildAndPushCommands(image domain.DockerImage) (string, string, string) {
+	imageTag := image.RegistryName + ".cr.cloud.ru/" + image.RepositoryName + ":latest"
+	return imageTag, "docker build -t " + imageTag + " .", "docker push " + imageTag
+}
+
+func (f *fakeDockerService) GetImageDigestReference(ctx context.Context, imageTag string) (string, error) {
+	return "my-registry.cr.cloud.ru/app@sha256:abc", nil
+}
+
+// deployContainerAppsService records create and patch requests of a single Container App
+type deployContainerAppsService struct {
+	domain.ContainerAppsService
+	exists  bool
+	created *domain.CreateContainerAppRequest
+	patched *domain.PatchContainerAppRequest
+}
+
+func (f *deployContainerAppsService) GetContainerApp(ctx context.Context, projectID string, containerAppName string) (*domain.ContainerApp, error) {
+	if !f.exists {
+		return nil, domain.ErrNotFound
+	}
+	containerApp := &domain.ContainerApp{Name: containerAppName, Status: "RUNNING"}
+	containerApp.Configuration.Ingress.PublicUri = "https://my-app.containers.cloud.ru"
+	return containerApp, nil
+}
+
+func (f *deployContainerAppsService) CreateContainerApp(ctx context.Context, request domain.CreateContainerAppRequest) (*domain.Operation, error) {
+	f.created = &request
+	f.exists = true
+	return &domain.Operation{ID: "op-1"}, nil
+}
+
+func (f *deployContainerAppsService) PatchContainerApp(ctx context.Context, projectID string, containerAppName string, request domain.PatchContainerAppRequest) (*domain.Operation, error) {
+	// Like the real service, stop before sending the request in dry-run mode
+	if dryRun := domain.DryRunFromContext(ctx); dryRun != nil {
+		dryRun.Request = &domain.DryRunRequest{Method: "PATCH", URL: "/v2/containers/" + containerAppName}
+		return nil, domain.ErrDryRun
+	}
+	f.patched = &request
+	return &domain.Operation{ID: "op-1"}, nil
+}
+
+// doneOperationsService completes every operation immediately
+type doneOperationsService struct {
+	domain.OperationsService
+}
+
+func (f *doneOperationsService) WaitOperation(ctx context.Context, operationID string, timeout time.Duration) (*domain.Operation, error) {
+	return &domain.Operation{ID: operationID, Done: true}, nil
+}
+
+func callDeployTool(t *testing.T, containerApps *deployContainerAppsService, arguments map[string]any) *mcp.CallToolResult {
+	t.Setenv("CLOUDRU_KEY_ID", "key-id")
+	t.Setenv("CLOUDRU_KEY_SECRET", "key-secret")
+
+	s := NewMCPServer(nil, &fakeDockerService{}, containerApps, nil, nil, &doneOperationsService{}, nil)
+	mcpServer := server.NewMCPServer("test", "0.0.0", server.WithToolCapabilities(false))
+	s.RegisterDeployTool(mcpServer)
+
+	tool := mcpServer.GetTool("cloudru_deploy")
+	require.NotNil(t, tool)
+	assert.NotContains(t, tool.Tool.InputSchema.Required, "containerapp_port")
+
+	request := mcp.CallToolRequest{}
+	request.Params.Name = "cloudru_deploy"
+	request.Params.Arguments = arguments
+	result, err := tool.Handler(context.Background(), request)
+	require.NoError(t, err)
+	return result
+}
+
+func TestDeployTool_PatchesExistingContainerAppWithPinnedImage(t *testing.T) {
+	containerApps := &deployContainerAppsService{exists: true}
+
+	result := callDeployTool(t, containerApps, map[string]any{
+		"project_id":        "project-1",
+		"containerapp_name": "my-app",
+		"registry_name":     "my-registry",
+		"repository_name":   "app",
+	})
+
+	require.False(t, result.IsError, "%v", result.Content)
+	require.NotNil(t, containerApps.patched)
+	assert.Nil(t, containerApps.created)
+	assert.Equal(t, "my-registry.cr.cloud.ru/app@sha256:abc", *containerApps.patched.ContainerAppImage)
+
+	output, ok := result.StructuredContent.(deployOutput)
+	require.True(t, ok)
+	assert.Equal(t, deployActionPatched, output.Action)
+	assert.Equal(t, "RUNNING", output.Status)
+	assert.Equal(t, "https://my-app.containers.cloud.ru", output.PublicURI)
+}
+
+func TestDeployTool_CreatesMissingContainerApp(t *testing.T) {
+	containerApps := &deployContainerAppsService{}
+
+	result := callDeployTool(t, containerApps, map[string]any{
+		"project_id":        "project-1",
+		"containerapp_name": "my-app",
+		"registry_name":     "my-registry",
+		"repository_name":   "app",
+		"containerapp_port": "8080",
+	})
+
+	require.False(t, result.IsError, "%v", result.Content)
+	require.NotNil(t, containerApps.created)
+	assert.Equal(t, 8080, containerApps.created.ContainerAppPort)
+	assert.Equal(t, "my-registry.cr.cloud.ru/app@sha256:abc", containerApps.created.ContainerAppImage)
+	assert.True(t, containerApps.created.PubliclyAccessible)
+	assert.Equal(t, "0.1", containerApps.created.CPU)
+
+	output, ok := result.StructuredContent.(deployOutput)
+	require.True(t, ok)
+	assert.Equal(t, deployActionCreated, output.Action)
+}
+
+func TestDeployTool_RequiresPortForMissingContainerApp(t *testing.T) {
+	containerApps := &deployContainerAppsService{}
+
+	result := callDeployTool(t, containerApps, map[string]any{
+		"project_id":        "project-1",
+		"containerapp_name": "my-app",
+		"registry_name":     "my-registry",
+		"repository_name":   "app",
+	})
+
+	require.True(t, result.IsError)
+	assert.Contains(t, result.Content[0].(mcp.TextContent).Text, "containerapp_port is required")
+	assert.Nil(t, containerApps.created)
+}
+
+func TestDeployTool_DryRunReportsPlanWithoutChanges(t *testing.T) {
+	containerApps := &deployContainerAppsService{}
+
+	result := callDeployTool(t, containerApps, map[string]any{
+		"project_id":        "project-1",
+		"containerapp_name": "my-app",
+		"registry_name":     "my-registry",
+		"repository_name":   "app",
+		"containerapp_port": "8080",
+		"dry_run":           "true",
+	})
+
+	require.False(t, result.IsError, "%v", result.Content)
+	assert.Nil(t, containerApps.created)
+	assert.Nil(t, containerApps.patched)
+
+	output, ok := result.StructuredContent.(deployOutput)
+	require.True(t, ok)
+	assert.Equal(t, deployActionCreated, output.Action)
+	require.NotNil(t, output.DryRun)
+	assert.Equal(t, "docker push my-registry.cr.cloud.ru/app:latest", output.DryRun.PushCommand)
+	require.NotNil(t, output.DryRun.Create)
+	assert.Equal(t, 8080, output.DryRun.Create.ContainerAppPort)
+	assert.Contains(t, result.Content[0].(mcp.TextContent).Text, "create Container App my-app")
+}
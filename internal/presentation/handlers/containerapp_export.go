@@ -0,0 +1,152 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/Nick1994209/cloudru-containerapps-mcp/internal/domain"
+	"github.com/Nick1994209/cloudru-containerapps-mcp/internal/manifest"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// Export formats
+const (
+	exportFormatManifest = "manifest"
+	exportFormatToolCall = "tool_call"
+)
+
+// RegisterExportContainerAppTool registers the export container app tool with the MCP server
+func (s *MCPServer) RegisterExportContainerAppTool(mcpServer *server.MCPServer) {
+	// Prepare tool options including description and fields
+	toolOptions := s.getMCPFieldsOptions(
+		"Export an existing Container App from Cloud.ru as a cloudru.yaml manifest or a ready-to-run cloudru_create_containerapp call. Server-managed fields (ids, status, timestamps, public URI) are stripped, so the result can be applied in another project",
+		"project_id",
+		"containerapp_name",
+		"export_format",
+		"target_project_id",
+	)
+	toolOptions = append(toolOptions, outputSchema[exportOutput]())
+	exportTool := mcp.NewTool("cloudru_export_containerapp", toolOptions...)
+
+	mcpServer.AddTool(exportTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		// Get project ID
+		projectID, err := s.getMCPFieldValue("project_id", request)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		// Get container app name
+		containerAppName, err := s.getMCPFieldValue("containerapp_name", request)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		format, targetProjectID, err := s.getExportOptions(request)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		// Call the service
+		raw, err := s.containerAppsService.GetContainerAppRaw(ctx, projectID, containerAppName)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		m, warnings, err := manifest.FromContainerApp(raw)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		output := exportOutput{Kind: m.Kind, Name: m.Name, Format: format, Warnings: warnings}
+		if format == exportFormatToolCall {
+			if targetProjectID == "" {
+				targetProjectID = projectID
+			}
+			createRequest, err := m.CreateContainerAppRequest(targetProjectID)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			output.ToolCall = &exportToolCall{
+				Name: "cloudru_create_containerapp",
+				Arguments: map[string]string{
+					"project_id":                            createRequest.ProjectID,
+					"containerapp_name":                     createRequest.ContainerAppName,
+					"containerapp_port":                     strconv.Itoa(createRequest.ContainerAppPort),
+					"containerapp_image":                    createRequest.ContainerAppImage,
+					"containerapp_auto_deployments_enabled": strconv.FormatBool(createRequest.AutoDeploymentsEnabled),
+					"containerapp_auto_deployments_pattern": createRequest.AutoDeploymentsPattern,
+					"containerapp_privileged":               strconv.FormatBool(createRequest.Privileged),
+					"containerapp_idle_timeout":             createRequest.IdleTimeout,
+					"containerapp_timeout":                  createRequest.Timeout,
+					"containerapp_cpu":                      createRequest.CPU,
+					"containerapp_memory":                   createRequest.Memory,
+					"containerapp_min_instance_count":       strconv.Itoa(createRequest.MinInstanceCount),
+					"containerapp_max_instance_count":       strconv.Itoa(createRequest.MaxInstanceCount),
+					"containerapp_description":              createRequest.Description,
+					"containerapp_publicly_accessible":      strconv.FormatBool(createRequest.PubliclyAccessible),
+					"containerapp_protocol":                 createRequest.Protocol,
+					"containerapp_environment_variables":    createRequest.EnvironmentVariables,
+					"containerapp_command":                  formatListFieldValue(createRequest.Command),
+					"containerapp_args":                     formatListFieldValue(createRequest.Args),
+				},
+			}
+		}
+
+		return s.exportToolResult(output, m, raw, targetProjectID)
+	})
+}
+
+// getExportOptions gets the export format and the project ID to put into the exported resource
+func (s *MCPServer) getExportOptions(request mcp.CallToolRequest) (string, string, error) {
+	format, err := s.getMCPFieldValue("export_format", request)
+	if err != nil {
+		return "", "", err
+	}
+	if format != exportFormatManifest && format != exportFormatToolCall {
+		return "", "", fmt.Errorf("field export_format must be '%s' or '%s', got: %s", exportFormatManifest, exportFormatToolCall, format)
+	}
+
+	targetProjectID, err := s.getMCPFieldValue("target_project_id", request)
+	if err != nil {
+		return "", "", err
+	}
+	return format, targetProjectID, nil
+}
+
+// exportToolResult completes the export output with the stripped object and the manifest for the manifest format
+func (s *MCPServer) exportToolResult(output exportOutput, m *manifest.Manifest, raw []byte, targetProjectID string) (*mcp.CallToolResult, error) {
+	object, err := manifest.StripServerFields(raw)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	output.Object = object
+
+	// Values of secret variables are masked in the output and have to be filled in before applying
+	for _, value := range m.Env {
+		if value.Type == domain.EnvironmentVariableTypeSecret {
+			output.Warnings = append(output.Warnings, fmt.Sprintf("values of secret environment variables are masked as %s, replace them with the Secret Manager secret references before applying", secretMask))
+			break
+		}
+	}
+
+	var message string
+	if output.Format == exportFormatToolCall {
+		message = fmt.Sprintf("Exported %s %s as a %s call", m.Kind, m.Name, output.ToolCall.Name)
+	} else {
+		// Without a target project the manifest is applied to the project of cloudru_apply
+		m.ProjectID = targetProjectID
+		data, err := m.Marshal()
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		output.Manifest = string(data)
+		message = fmt.Sprintf("Exported %s %s, save the manifest as %s and run cloudru_apply:\n%s", m.Kind, m.Name, manifest.DefaultPath, output.Manifest)
+	}
+	if len(output.Warnings) > 0 {
+		message = fmt.Sprintf("%s\nWarnings:\n- %s", message, strings.Join(output.Warnings, "\n- "))
+	}
+	return newToolResultJSON(output, message), nil
+}
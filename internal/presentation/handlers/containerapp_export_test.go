@@ -0,0 +1,136 @@
+package handlers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// exportContainerAppsService returns a raw Container App with server-managed fields
+type exportContainerAppsService struct {
+	fakeContainerAppsService
+}
+
+func (f *exportContainerAppsService) GetContainerAppRaw(ctx context.Context, projectID string, containerAppName string) ([]byte, error) {
+	return []byte(`{
+		"id": "app-id",
+		"projectId": "` + projectID + `",
+		"name": "` + containerAppName + `",
+		"status": "RUNNING",
+		"configuration": {"ingress": {"publiclyAccessible": true, "publicUri": "https://my-app.example"}},
+		"template": {"containers": [{"image": "app:v1", "containerPort": 8080, "env": [{"name": "A", "value": "1"}]}]}
+	}`), nil
+}
+
+// commandContainerAppsService returns a raw Container App with a command and args containing commas and spaces
+type commandContainerAppsService struct {
+	fakeContainerAppsService
+}
+
+func (f *commandContainerAppsService) GetContainerAppRaw(ctx context.Context, projectID string, containerAppName string) ([]byte, error) {
+	return []byte(`{
+		"projectId": "` + projectID + `",
+		"name": "` + containerAppName + `",
+		"template": {"containers": [{"image": "app:v1", "containerPort": 8080, "command": ["sh", "-c"], "args": ["echo a,b", " padded "]}]}
+	}`), nil
+}
+
+func TestExportContainerAppTool_ToolCallKeepsCommandAndArgs(t *testing.T) {
+	t.Setenv("CLOUDRU_KEY_ID", "key-id")
+	t.Setenv("CLOUDRU_KEY_SECRET", "key-secret")
+
+	s := NewMCPServer(nil, nil, &commandContainerAppsService{}, nil, nil, nil, nil)
+	mcpServer := server.NewMCPServer("test", "0.0.0", server.WithToolCapabilities(false))
+	s.RegisterExportContainerAppTool(mcpServer)
+
+	request := mcp.CallToolRequest{}
+	request.Params.Name = "cloudru_export_containerapp"
+	request.Params.Arguments = map[string]any{
+		"project_id":        "project-1",
+		"containerapp_name": "my-app",
+		"export_format":     "tool_call",
+	}
+	result, err := mcpServer.GetTool("cloudru_export_containerapp").Handler(context.Background(), request)
+	require.NoError(t, err)
+	require.False(t, result.IsError, "%v", result.Content)
+
+	output, ok := result.StructuredContent.(exportOutput)
+	require.True(t, ok)
+	require.NotNil(t, output.ToolCall)
+
+	// Command and args with commas and spaces survive the create tool parsing
+	createRequest := mcp.CallToolRequest{}
+	arguments := map[string]any{}
+	for key, value := range output.ToolCall.Arguments {
+		arguments[key] = value
+	}
+	createRequest.Params.Arguments = arguments
+	command, err := s.getMCPListFieldValue("containerapp_command", createRequest)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"sh", "-c"}, command)
+	args, err := s.getMCPListFieldValue("containerapp_args", createRequest)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"echo a,b", " padded "}, args)
+}
+
+func TestExportContainerAppTool_ToolCallForAnotherProject(t *testing.T) {
+	t.Setenv("CLOUDRU_KEY_ID", "key-id")
+	t.Setenv("CLOUDRU_KEY_SECRET", "key-secret")
+
+	s := NewMCPServer(nil, nil, &exportContainerAppsService{}, nil, nil, nil, nil)
+	mcpServer := server.NewMCPServer("test", "0.0.0", server.WithToolCapabilities(false))
+	s.RegisterExportContainerAppTool(mcpServer)
+
+	request := mcp.CallToolRequest{}
+	request.Params.Name = "cloudru_export_containerapp"
+	request.Params.Arguments = map[string]any{
+		"project_id":        "project-1",
+		"containerapp_name": "my-app",
+		"export_format":     "tool_call",
+		"target_project_id": "project-2",
+	}
+	result, err := mcpServer.GetTool("cloudru_export_containerapp").Handler(context.Background(), request)
+	require.NoError(t, err)
+	require.False(t, result.IsError, "%v", result.Content)
+
+	output, ok := result.StructuredContent.(exportOutput)
+	require.True(t, ok)
+	require.NotNil(t, output.ToolCall)
+	assert.Equal(t, "cloudru_create_containerapp", output.ToolCall.Name)
+	assert.Equal(t, "project-2", output.ToolCall.Arguments["project_id"])
+	assert.Equal(t, "8080", output.ToolCall.Arguments["containerapp_port"])
+	assert.JSONEq(t, `[{"name":"A","value":"1"}]`, output.ToolCall.Arguments["containerapp_environment_variables"])
+	assert.NotContains(t, string(output.Object), "publicUri")
+
+}
+
+func TestGetMCPListFieldValue(t *testing.T) {
+	t.Setenv("CLOUDRU_KEY_ID", "key-id")
+	t.Setenv("CLOUDRU_KEY_SECRET", "key-secret")
+
+	s := NewMCPServer(nil, nil, nil, nil, nil, nil, nil)
+	request := mcp.CallToolRequest{}
+
+	request.Params.Arguments = map[string]any{"job_args": "--verbose, --dry-run"}
+	values, err := s.getMCPListFieldValue("job_args", request)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"--verbose", "--dry-run"}, values)
+
+	request.Params.Arguments = map[string]any{"job_args": `["-c", "echo a,b"]`}
+	values, err = s.getMCPListFieldValue("job_args", request)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"-c", "echo a,b"}, values)
+
+	request.Params.Arguments = map[string]any{"job_args": `["-c", 1]`}
+	_, err = s.getMCPListFieldValue("job_args", request)
+	assert.ErrorContains(t, err, "field job_args must be a JSON array of strings or comma-separated values")
+
+	request.Params.Arguments = map[string]any{}
+	values, err = s.getMCPListFieldValue("job_args", request)
+	require.NoError(t, err)
+	assert.Nil(t, values)
+}
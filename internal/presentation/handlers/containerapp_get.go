@@ -2,8 +2,8 @@ package handlers
 
 import (
 	"context"
-	"encoding/json"
-	"fmt"
+
+	"github.com/Nick1994209/cloudru-containerapps-mcp/internal/domain"
 
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
@@ -17,6 +17,7 @@ func (s *MCPServer) RegisterGetContainerAppTool(mcpServer *server.MCPServer) {
 		"project_id",
 		"containerapp_name",
 	)
+	toolOptions = append(toolOptions, outputSchema[domain.ContainerApp]())
 	getContainerAppTool := mcp.NewTool("cloudru_get_containerapp", toolOptions...)
 
 	mcpServer.AddTool(getContainerAppTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -33,17 +34,11 @@ func (s *MCPServer) RegisterGetContainerAppTool(mcpServer *server.MCPServer) {
 		}
 
 		// Call the service
-		containerApp, err := s.containerAppsService.GetContainerApp(projectID, containerAppName)
+		containerApp, err := s.containerAppsService.GetContainerApp(ctx, projectID, containerAppName)
 		if err != nil {
 			return mcp.NewToolResultError(err.Error()), nil
 		}
 
-		// Convert to JSON for output
-		result, err := json.MarshalIndent(containerApp, "", "  ")
-		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("Failed to format result: %v", err)), nil
-		}
-
-		return mcp.NewToolResultText(string(result)), nil
+		return newToolResultJSON(containerApp, ""), nil
 	})
 }
@@ -2,8 +2,8 @@ package handlers
 
 import (
 	"context"
-	"encoding/json"
-	"fmt"
+
+	"github.com/Nick1994209/cloudru-containerapps-mcp/internal/domain"
 
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
@@ -13,10 +13,20 @@ import (
 func (s *MCPServer) RegisterGetContainerAppLogsTool(mcpServer *server.MCPServer) {
 	// Prepare tool options including description and fields
 	toolOptions := s.getMCPFieldsOptions(
-		"Get logs for a specific Container App from Cloud.ru by name. Project ID can be set via PROJECT_ID environment variable and obtained from console.cloud.ru",
+		"Get logs for a specific Container App from Cloud.ru by name. Filter by time window, level, pod, version, container and message text to find errors, e.g. since=15m level=error log_format=text. The logs API does not filter, so filters are applied by this server to the pages the API returns; if those pages do not reach back to since, the result is marked partial with availableSince. Project ID can be set via PROJECT_ID environment variable and obtained from console.cloud.ru",
 		"project_id",
 		"containerapp_name",
+		"since",
+		"until",
+		"level",
+		"pod_name",
+		"version_id",
+		"container_name",
+		"search",
+		"max_lines",
+		"log_format",
 	)
+	toolOptions = append(toolOptions, outputSchema[domain.ContainerAppLogs]())
 	getContainerAppLogsTool := mcp.NewTool("cloudru_get_containerapp_logs", toolOptions...)
 
 	mcpServer.AddTool(getContainerAppLogsTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -32,18 +42,27 @@ func (s *MCPServer) RegisterGetContainerAppLogsTool(mcpServer *server.MCPServer)
 			return mcp.NewToolResultError(err.Error()), nil
 		}
 
-		// Call the service
-		containerAppLogs, err := s.containerAppsService.GetContainerAppLogs(projectID, containerAppName)
+		// Get log filters and output format
+		logOptions, err := s.getLogOptions(request)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		logFormat, err := s.getLogFormat(request)
 		if err != nil {
 			return mcp.NewToolResultError(err.Error()), nil
 		}
 
-		// Convert to JSON for output
-		result, err := json.MarshalIndent(containerAppLogs, "", "  ")
+		// Call the service
+		containerAppLogs, err := s.containerAppsService.GetContainerAppLogs(ctx, projectID, containerAppName, logOptions)
 		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("Failed to format result: %v", err)), nil
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		// One line per entry for the text format
+		if logFormat == logFormatText {
+			return logsTextResult(formatLogsText(containerAppLogs), logsTextOutputOf(containerAppLogs)), nil
 		}
 
-		return mcp.NewToolResultText(string(result)), nil
+		return newToolResultJSON(containerAppLogs, partialLogsNote(containerAppLogs)), nil
 	})
 }
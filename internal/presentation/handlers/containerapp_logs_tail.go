@@ -0,0 +1,214 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/Nick1994209/cloudru-containerapps-mcp/internal/domain"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// Bounds of the tail parameters, so a forgotten tail does not poll the API forever
+const (
+	maxTailDuration = 10 * time.Minute
+	minTailInterval = time.Second
+)
+
+// tailToolName is also the logger name of the logging notifications
+const tailToolName = "cloudru_tail_containerapp_logs"
+
+// RegisterTailContainerAppLogsTool registers the tail container app logs tool with the MCP server
+func (s *MCPServer) RegisterTailContainerAppLogsTool(mcpServer *server.MCPServer) {
+	// Prepare tool options including description and fields
+	toolOptions := s.getMCPFieldsOptions(
+		"Follow logs of a Container App in Cloud.ru for a bounded time, e.g. while a new revision rolls out. New log lines are pushed as progress notifications (or logging notifications when the request has no progress token), the result contains the latest max_lines received entries. Without since only entries logged after the tail started are followed. Cancel the request to stop early",
+		"project_id",
+		"containerapp_name",
+		"tail_duration",
+		"tail_interval",
+		"since",
+		"level",
+		"pod_name",
+		"version_id",
+		"container_name",
+		"search",
+		"max_lines",
+		"log_format",
+	)
+	toolOptions = append(toolOptions, outputSchema[domain.ContainerAppLogs]())
+	tailContainerAppLogsTool := mcp.NewTool(tailToolName, toolOptions...)
+
+	mcpServer.AddTool(tailContainerAppLogsTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		// Get project ID
+		projectID, err := s.getMCPFieldValue("project_id", request)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		// Get container app name
+		containerAppName, err := s.getMCPFieldValue("containerapp_name", request)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		// Get tail duration and poll interval
+		duration, err := s.getMCPDurationFieldValue("tail_duration", request)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		if duration > maxTailDuration {
+			return mcp.NewToolResultError(fmt.Sprintf("field tail_duration must not exceed %s, got: %s", maxTailDuration, duration)), nil
+		}
+		interval, err := s.getMCPDurationFieldValue("tail_interval", request)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		if interval < minTailInterval {
+			return mcp.NewToolResultError(fmt.Sprintf("field tail_interval must be at least %s, got: %s", minTailInterval, interval)), nil
+		}
+
+		// Get log filters and output format
+		logOptions, err := s.getLogOptions(request)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		logFormat, err := s.getLogFormat(request)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		// Poll the logs until the duration is over or the client cancels the request.
+		// Polls are not limited to max_lines, so a burst of lines between two polls is not lost,
+		// and without since the history logged before the tail started is not reported as new
+		tail := newLogTail()
+		started := time.Now()
+		pollOptions := logOptions
+		pollOptions.MaxLines = 0
+		if pollOptions.Since == "" {
+			pollOptions.Since = started.UTC().Format(time.RFC3339Nano)
+		}
+		cancelled := false
+		for polls := 0; ; polls++ {
+			logs, err := s.containerAppsService.GetContainerAppLogs(ctx, projectID, containerAppName, pollOptions)
+			if ctx.Err() != nil {
+				cancelled = true
+				break
+			}
+			if err != nil && polls == 0 {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			// A failed poll is reported and retried, the next poll returns the missed entries
+			if err != nil {
+				notifyTail(ctx, request, time.Since(started), duration, fmt.Sprintf("Failed to get logs, retrying: %v\n", err))
+			} else if entries := tail.add(logs.Data); len(entries) > 0 {
+				var lines strings.Builder
+				for _, entry := range entries {
+					lines.WriteString(formatLogLine(entry))
+				}
+				notifyTail(ctx, request, time.Since(started), duration, lines.String())
+			}
+
+			if time.Since(started)+interval > duration {
+				break
+			}
+			if !waitInterval(ctx, interval) {
+				cancelled = true
+				break
+			}
+		}
+
+		containerAppLogs := tail.result(logOptions.MaxLines)
+		message := fmt.Sprintf("Followed logs of Container App %s for %s, received %d entries", containerAppName, time.Since(started).Round(time.Second), containerAppLogs.Total)
+		if cancelled {
+			message += ", stopped by the client"
+		}
+
+		// One line per entry for the text format
+		if logFormat == logFormatText {
+			return logsTextResult(message+"\n\n"+formatLogsText(containerAppLogs), logsTextOutputOf(containerAppLogs)), nil
+		}
+
+		return newToolResultJSON(containerAppLogs, message), nil
+	})
+}
+
+// logTail remembers the received log entries, so every poll reports only new entries.
+// Entries are identified by timestamp, pod, container and message, because the API has no entry ids
+type logTail struct {
+	seen    map[string]bool
+	entries []domain.ContainerAppLogEntry
+}
+
+func newLogTail() *logTail {
+	return &logTail{seen: map[string]bool{}}
+}
+
+// add records entries and returns the ones which were not received before
+func (t *logTail) add(entries []domain.ContainerAppLogEntry) []domain.ContainerAppLogEntry {
+	var added []domain.ContainerAppLogEntry
+	for _, entry := range entries {
+		key := strings.Join([]string{entry.Timestamp, entry.PodName, entry.ContainerName, entry.Message}, "\x00")
+		if t.seen[key] {
+			continue
+		}
+		t.seen[key] = true
+		added = append(added, entry)
+	}
+	t.entries = append(t.entries, added...)
+	return added
+}
+
+// result returns the latest maxLines received entries
+func (t *logTail) result(maxLines int) *domain.ContainerAppLogs {
+	logs := &domain.ContainerAppLogs{Data: t.entries, Total: len(t.entries)}
+	if logs.Data == nil {
+		logs.Data = []domain.ContainerAppLogEntry{}
+	}
+	if maxLines > 0 && len(logs.Data) > maxLines {
+		logs.Data = logs.Data[len(logs.Data)-maxLines:]
+		logs.Truncated = true
+	}
+	return logs
+}
+
+// notifyTail pushes log lines to the client as a progress notification when the request has a progress token,
+// otherwise as a logging notification. Progress is the elapsed part of the tail duration in seconds
+func notifyTail(ctx context.Context, request mcp.CallToolRequest, elapsed time.Duration, duration time.Duration, lines string) {
+	mcpServer := server.ServerFromContext(ctx)
+	if mcpServer == nil {
+		return
+	}
+
+	var err error
+	if request.Params.Meta != nil && request.Params.Meta.ProgressToken != nil {
+		err = mcpServer.SendNotificationToClient(ctx, "notifications/progress", map[string]any{
+			"progressToken": request.Params.Meta.ProgressToken,
+			"progress":      elapsed.Seconds(),
+			"total":         duration.Seconds(),
+			"message":       lines,
+		})
+	} else {
+		err = mcpServer.SendLogMessageToClient(ctx, mcp.NewLoggingMessageNotification(mcp.LoggingLevelInfo, tailToolName, lines))
+	}
+	if err != nil {
+		log.Printf("Failed to send %s notification: %v", tailToolName, err)
+	}
+}
+
+// waitInterval waits for the poll interval, false means the context was cancelled
+func waitInterval(ctx context.Context, interval time.Duration) bool {
+	timer := time.NewTimer(interval)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
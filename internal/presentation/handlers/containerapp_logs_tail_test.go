@@ -0,0 +1,169 @@
+package handlers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Nick1994209/cloudru-containerapps-mcp/internal/domain"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// tailContainerAppsService returns the next batch of logs on every call and cancels the request after the last one
+type tailContainerAppsService struct {
+	domain.ContainerAppsService
+	batches [][]domain.ContainerAppLogEntry
+	calls   int
+	cancel  context.CancelFunc
+	options []domain.LogOptions
+}
+
+func (f *tailContainerAppsService) GetContainerAppLogs(ctx context.Context, projectID string, containerAppName string, options domain.LogOptions) (*domain.ContainerAppLogs, error) {
+	f.calls++
+	f.options = append(f.options, options)
+	if f.calls > len(f.batches) {
+		f.cancel()
+		return nil, ctx.Err()
+	}
+	return &domain.ContainerAppLogs{Data: f.batches[f.calls-1]}, nil
+}
+
+func TestLogTail(t *testing.T) {
+	tail := newLogTail()
+	first := domain.ContainerAppLogEntry{Timestamp: "2024-05-01T12:00:00Z", PodName: "app-abc", Message: "starting"}
+	second := domain.ContainerAppLogEntry{Timestamp: "2024-05-01T12:00:00Z", PodName: "app-abc", Message: "listening"}
+	third := domain.ContainerAppLogEntry{Timestamp: "2024-05-01T12:00:01Z", PodName: "app-def", Message: "starting"}
+
+	assert.Equal(t, []domain.ContainerAppLogEntry{first, second}, tail.add([]domain.ContainerAppLogEntry{first, second}))
+	assert.Equal(t, []domain.ContainerAppLogEntry{third}, tail.add([]domain.ContainerAppLogEntry{first, second, third}))
+	assert.Empty(t, tail.add([]domain.ContainerAppLogEntry{second, third}))
+
+	result := tail.result(2)
+	assert.Equal(t, []domain.ContainerAppLogEntry{second, third}, result.Data)
+	assert.Equal(t, 3, result.Total)
+	assert.True(t, result.Truncated)
+}
+
+func TestTailContainerAppLogsTool_StopsOnCancellation(t *testing.T) {
+	t.Setenv("CLOUDRU_KEY_ID", "key-id")
+	t.Setenv("CLOUDRU_KEY_SECRET", "key-secret")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	entry := domain.ContainerAppLogEntry{Timestamp: "2024-05-01T12:00:00Z", PodName: "app-abc", Message: "starting"}
+	containerApps := &tailContainerAppsService{batches: [][]domain.ContainerAppLogEntry{{entry}}, cancel: cancel}
+
+	s := NewMCPServer(nil, nil, containerApps, nil, nil, nil, nil)
+	mcpServer := server.NewMCPServer("test", "0.0.0", server.WithToolCapabilities(false))
+	s.RegisterTailContainerAppLogsTool(mcpServer)
+	tool := mcpServer.GetTool(tailToolName)
+	require.NotNil(t, tool)
+
+	request := mcp.CallToolRequest{}
+	request.Params.Name = tailToolName
+	request.Params.Arguments = map[string]any{
+		"project_id":        "project",
+		"containerapp_name": "app",
+		"tail_duration":     "10m",
+		"tail_interval":     "1s",
+	}
+	result, err := tool.Handler(ctx, request)
+	require.NoError(t, err)
+	require.False(t, result.IsError, result.Content)
+
+	assert.Equal(t, 2, containerApps.calls)
+	logs, ok := result.StructuredContent.(*domain.ContainerAppLogs)
+	require.True(t, ok)
+	assert.Equal(t, []domain.ContainerAppLogEntry{entry}, logs.Data)
+	assert.Contains(t, result.Content[0].(mcp.TextContent).Text, "received 1 entries, stopped by the client")
+}
+
+func TestTailContainerAppLogsTool_TextFormatReturnsOnlyCounters(t *testing.T) {
+	t.Setenv("CLOUDRU_KEY_ID", "key-id")
+	t.Setenv("CLOUDRU_KEY_SECRET", "key-secret")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	entry := domain.ContainerAppLogEntry{Timestamp: "2024-05-01T12:00:00Z", PodName: "app-abc", Message: "starting"}
+	containerApps := &tailContainerAppsService{batches: [][]domain.ContainerAppLogEntry{{entry}}, cancel: cancel}
+
+	s := NewMCPServer(nil, nil, containerApps, nil, nil, nil, nil)
+	mcpServer := server.NewMCPServer("test", "0.0.0", server.WithToolCapabilities(false))
+	s.RegisterTailContainerAppLogsTool(mcpServer)
+
+	request := mcp.CallToolRequest{}
+	request.Params.Arguments = map[string]any{
+		"project_id":        "project",
+		"containerapp_name": "app",
+		"tail_interval":     "1s",
+		"log_format":        "text",
+	}
+	result, err := mcpServer.GetTool(tailToolName).Handler(ctx, request)
+	require.NoError(t, err)
+	require.False(t, result.IsError, result.Content)
+
+	assert.Equal(t, logsTextOutput{Total: 1}, result.StructuredContent)
+	assert.Contains(t, result.Content[0].(mcp.TextContent).Text, "app-abc: starting")
+}
+
+func TestTailContainerAppLogsTool_RejectsUnboundedDuration(t *testing.T) {
+	t.Setenv("CLOUDRU_KEY_ID", "key-id")
+	t.Setenv("CLOUDRU_KEY_SECRET", "key-secret")
+
+	s := NewMCPServer(nil, nil, &tailContainerAppsService{}, nil, nil, nil, nil)
+	mcpServer := server.NewMCPServer("test", "0.0.0", server.WithToolCapabilities(false))
+	s.RegisterTailContainerAppLogsTool(mcpServer)
+
+	request := mcp.CallToolRequest{}
+	request.Params.Arguments = map[string]any{
+		"project_id":        "project",
+		"containerapp_name": "app",
+		"tail_duration":     "1h",
+	}
+	result, err := mcpServer.GetTool(tailToolName).Handler(context.Background(), request)
+	require.NoError(t, err)
+	assert.True(t, result.IsError)
+	assert.Contains(t, result.Content[0].(mcp.TextContent).Text, "must not exceed 10m0s")
+}
+
+func TestTailContainerAppLogsTool_PollsWithoutLineLimit(t *testing.T) {
+	t.Setenv("CLOUDRU_KEY_ID", "key-id")
+	t.Setenv("CLOUDRU_KEY_SECRET", "key-secret")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	first := domain.ContainerAppLogEntry{Timestamp: "2024-05-01T12:00:00Z", PodName: "app-abc", Message: "first"}
+	second := domain.ContainerAppLogEntry{Timestamp: "2024-05-01T12:00:01Z", PodName: "app-abc", Message: "second"}
+	third := domain.ContainerAppLogEntry{Timestamp: "2024-05-01T12:00:02Z", PodName: "app-abc", Message: "third"}
+	containerApps := &tailContainerAppsService{batches: [][]domain.ContainerAppLogEntry{{first, second, third}}, cancel: cancel}
+
+	s := NewMCPServer(nil, nil, containerApps, nil, nil, nil, nil)
+	mcpServer := server.NewMCPServer("test", "0.0.0", server.WithToolCapabilities(false))
+	s.RegisterTailContainerAppLogsTool(mcpServer)
+
+	request := mcp.CallToolRequest{}
+	request.Params.Arguments = map[string]any{
+		"project_id":        "project",
+		"containerapp_name": "app",
+		"tail_interval":     "1s",
+		"max_lines":         "2",
+	}
+	result, err := mcpServer.GetTool(tailToolName).Handler(ctx, request)
+	require.NoError(t, err)
+	require.False(t, result.IsError, result.Content)
+
+	// Every poll gets all new lines since the tail started, the limit applies only to the result
+	require.NotEmpty(t, containerApps.options)
+	for _, options := range containerApps.options {
+		assert.Zero(t, options.MaxLines)
+		assert.NotEmpty(t, options.Since)
+		assert.Equal(t, containerApps.options[0].Since, options.Since)
+	}
+	logs, ok := result.StructuredContent.(*domain.ContainerAppLogs)
+	require.True(t, ok)
+	assert.Equal(t, []domain.ContainerAppLogEntry{second, third}, logs.Data)
+	assert.Equal(t, 3, logs.Total)
+	assert.True(t, logs.Truncated)
+}
@@ -2,7 +2,6 @@ package handlers
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"strings"
 
@@ -25,15 +24,30 @@ func (s *MCPServer) RegisterPatchContainerAppTool(mcpServer *server.MCPServer) {
 		"containerapp_idle_timeout",
 		"containerapp_timeout",
 		"containerapp_cpu",
+		"containerapp_memory",
 		"containerapp_min_instance_count",
 		"containerapp_max_instance_count",
 		"containerapp_description",
 		"containerapp_publicly_accessible",
 		"containerapp_protocol",
 		"containerapp_environment_variables",
+		"env_set",
+		"env_unset",
+		"env_rename",
 		"containerapp_command",
 		"containerapp_args",
+		"containerapp_container_name",
+		"containerapp_sidecars",
+		"containerapp_init_containers",
+		"containerapp_remove_containers",
+		"containerapp_volumes",
+		"containerapp_volume_mounts",
+		"containerapp_remove_volumes",
+		"wait",
+		"wait_timeout",
+		"dry_run",
 	)
+	toolOptions = append(toolOptions, outputSchema[operationOutput]())
 	patchContainerAppTool := mcp.NewTool("cloudru_patch_containerapp", toolOptions...)
 
 	mcpServer.AddTool(patchContainerAppTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -90,6 +104,9 @@ func (s *MCPServer) RegisterPatchContainerAppTool(mcpServer *server.MCPServer) {
 		// Get CPU
 		cpu, _ := s.getMCPFieldValue("containerapp_cpu", request)
 
+		// Get memory
+		memory, _ := s.getMCPFieldValue("containerapp_memory", request)
+
 		// Get min instance count
 		minInstanceCountStr, _ := s.getMCPFieldValue("containerapp_min_instance_count", request)
 		var minInstanceCount *int
@@ -136,27 +153,15 @@ func (s *MCPServer) RegisterPatchContainerAppTool(mcpServer *server.MCPServer) {
 		environmentVariables, _ := s.getMCPFieldValue("containerapp_environment_variables", request)
 
 		// Get command
-		commandStr, _ := s.getMCPFieldValue("containerapp_command", request)
-		var command []string
-		if commandStr != "" {
-			// Split by comma
-			command = strings.Split(commandStr, ",")
-			// Trim spaces from each command
-			for i, cmd := range command {
-				command[i] = strings.TrimSpace(cmd)
-			}
+		command, err := s.getMCPListFieldValue("containerapp_command", request)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
 		}
 
 		// Get args
-		argsStr, _ := s.getMCPFieldValue("containerapp_args", request)
-		var args []string
-		if argsStr != "" {
-			// Split by comma
-			args = strings.Split(argsStr, ",")
-			// Trim spaces from each arg
-			for i, arg := range args {
-				args[i] = strings.TrimSpace(arg)
-			}
+		args, err := s.getMCPListFieldValue("containerapp_args", request)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
 		}
 
 		// Create the patch request
@@ -205,6 +210,12 @@ func (s *MCPServer) RegisterPatchContainerAppTool(mcpServer *server.MCPServer) {
 				}
 				return nil
 			}(),
+			Memory: func() *string {
+				if checkRequestHasKey(request, "containerapp_memory") {
+					return &memory
+				}
+				return nil
+			}(),
 			MinInstanceCount: func() *int {
 				if checkRequestHasKey(request, "containerapp_min_instance_count") {
 					return minInstanceCount
@@ -255,18 +266,98 @@ func (s *MCPServer) RegisterPatchContainerAppTool(mcpServer *server.MCPServer) {
 			}(),
 		}
 
+		// Get incremental environment variable changes
+		patchRequest.EnvironmentVariableChanges, err = s.getEnvironmentVariableChanges(request)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		// Get the target container and sidecar and init container changes
+		if err := s.getContainerChanges(request, &patchRequest); err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		// Record the request instead of sending it if dry_run=true
+		ctx, dryRun, err := s.getDryRunContext(ctx, request)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
 		// Call the service
-		operation, err := s.containerAppsService.PatchContainerApp(projectID, containerAppName, patchRequest)
+		operation, err := s.containerAppsService.PatchContainerApp(ctx, projectID, containerAppName, patchRequest)
+		if result, ok := dryRunToolResult(dryRun, err, dryRunOperationOutput); ok {
+			return result, nil
+		}
 		if err != nil {
 			return mcp.NewToolResultError(err.Error()), nil
 		}
 
-		// Convert to JSON for output
-		result, err := json.MarshalIndent(operation, "", "  ")
+		// Wait for the operation to complete if requested
+		operation, err = s.waitOperationIfRequested(ctx, request, operation)
 		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("Failed to format result: %v", err)), nil
+			return mcp.NewToolResultError(operationErrorText(operation, err)), nil
 		}
 
-		return mcp.NewToolResultText(fmt.Sprintf("Successfully patched Container App: %s\n%s", containerAppName, string(result))), nil
+		return newToolResultJSON(operation, fmt.Sprintf("Successfully patched Container App: %s", containerAppName)), nil
 	})
 }
+
+// getContainerChanges gets the container to patch, sidecars, init containers and volumes to add or replace and the ones to remove
+func (s *MCPServer) getContainerChanges(request mcp.CallToolRequest, patchRequest *domain.PatchContainerAppRequest) error {
+	containerName, err := s.getMCPFieldValue("containerapp_container_name", request)
+	if err != nil {
+		return err
+	}
+	patchRequest.ContainerName = strings.TrimSpace(containerName)
+
+	if checkRequestHasKey(request, "containerapp_sidecars") {
+		sidecars, err := s.getMCPFieldValue("containerapp_sidecars", request)
+		if err != nil {
+			return err
+		}
+		patchRequest.Sidecars = &sidecars
+	}
+	if checkRequestHasKey(request, "containerapp_init_containers") {
+		initContainers, err := s.getMCPFieldValue("containerapp_init_containers", request)
+		if err != nil {
+			return err
+		}
+		patchRequest.InitContainers = &initContainers
+	}
+
+	removeContainers, err := s.getMCPFieldValue("containerapp_remove_containers", request)
+	if err != nil {
+		return err
+	}
+	for _, name := range strings.Split(removeContainers, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			patchRequest.RemoveContainers = append(patchRequest.RemoveContainers, name)
+		}
+	}
+
+	if checkRequestHasKey(request, "containerapp_volumes") {
+		volumes, err := s.getMCPFieldValue("containerapp_volumes", request)
+		if err != nil {
+			return err
+		}
+		patchRequest.Volumes = &volumes
+	}
+	if checkRequestHasKey(request, "containerapp_volume_mounts") {
+		volumeMounts, err := s.getMCPFieldValue("containerapp_volume_mounts", request)
+		if err != nil {
+			return err
+		}
+		patchRequest.VolumeMounts = &volumeMounts
+	}
+
+	removeVolumes, err := s.getMCPFieldValue("containerapp_remove_volumes", request)
+	if err != nil {
+		return err
+	}
+	for _, name := range strings.Split(removeVolumes, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			patchRequest.RemoveVolumes = append(patchRequest.RemoveVolumes, name)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,38 @@
+package handlers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPatchContainerAppTool_EmptyArrayResetsCommandAndArgs(t *testing.T) {
+	t.Setenv("CLOUDRU_KEY_ID", "key-id")
+	t.Setenv("CLOUDRU_KEY_SECRET", "key-secret")
+
+	containerApps := &deployContainerAppsService{exists: true}
+	s := NewMCPServer(nil, nil, containerApps, nil, nil, &doneOperationsService{}, nil)
+	mcpServer := server.NewMCPServer("test", "0.0.0", server.WithToolCapabilities(false))
+	s.RegisterPatchContainerAppTool(mcpServer)
+
+	request := mcp.CallToolRequest{}
+	request.Params.Name = "cloudru_patch_containerapp"
+	request.Params.Arguments = map[string]any{
+		"project_id":           "project-1",
+		"containerapp_name":    "my-app",
+		"containerapp_command": "[]",
+		"containerapp_args":    "",
+	}
+	result, err := mcpServer.GetTool("cloudru_patch_containerapp").Handler(context.Background(), request)
+	require.NoError(t, err)
+
+	require.False(t, result.IsError, "%v", result.Content)
+	require.NotNil(t, containerApps.patched)
+	// [] resets the command, an empty value leaves the args unchanged
+	assert.Equal(t, []string{}, containerApps.patched.Command)
+	assert.Nil(t, containerApps.patched.Args)
+}
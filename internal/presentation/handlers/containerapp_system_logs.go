@@ -2,8 +2,8 @@ package handlers
 
 import (
 	"context"
-	"encoding/json"
-	"fmt"
+
+	"github.com/Nick1994209/cloudru-containerapps-mcp/internal/domain"
 
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
@@ -13,10 +13,16 @@ import (
 func (s *MCPServer) RegisterGetContainerAppSystemLogsTool(mcpServer *server.MCPServer) {
 	// Prepare tool options including description and fields
 	toolOptions := s.getMCPFieldsOptions(
-		"Get system logs for a specific Container App from Cloud.ru by name. Project ID can be set via PROJECT_ID environment variable and obtained from console.cloud.ru",
+		"Get system logs (platform events) for a specific Container App from Cloud.ru by name, with a short diagnosis of scheduling failures, image pull errors and OOM kills. Use it when the application logs are empty because the container does not start. Project ID can be set via PROJECT_ID environment variable and obtained from console.cloud.ru",
 		"project_id",
 		"containerapp_name",
+		"event_type",
+		"component",
+		"revision_name",
+		"max_lines",
+		"log_format",
 	)
+	toolOptions = append(toolOptions, outputSchema[domain.ContainerAppSystemLogs]())
 	getContainerAppSystemLogsTool := mcp.NewTool("cloudru_get_containerapp_system_logs", toolOptions...)
 
 	mcpServer.AddTool(getContainerAppSystemLogsTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -32,29 +38,27 @@ func (s *MCPServer) RegisterGetContainerAppSystemLogsTool(mcpServer *server.MCPS
 			return mcp.NewToolResultError(err.Error()), nil
 		}
 
-		// Call the service
-		containerAppSystemLogs, err := s.containerAppsService.GetContainerAppSystemLogs(projectID, containerAppName)
+		// Get event filters and output format
+		systemLogOptions, err := s.getSystemLogOptions(request)
 		if err != nil {
 			return mcp.NewToolResultError(err.Error()), nil
 		}
-
-		// Convert to JSON for output with limit of 200 records
-		limitedData, err := marshalJSONWithLimit(containerAppSystemLogs.Data, 200)
+		logFormat, err := s.getLogFormat(request)
 		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("Failed to format result: %v", err)), nil
+			return mcp.NewToolResultError(err.Error()), nil
 		}
 
-		// Create the response structure with limited data
-		response := map[string]interface{}{
-			"data": limitedData,
+		// Call the service
+		containerAppSystemLogs, err := s.containerAppsService.GetContainerAppSystemLogs(ctx, projectID, containerAppName, systemLogOptions)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
 		}
 
-		// Marshal the final response
-		result, err := json.MarshalIndent(response, "", "  ")
-		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("Failed to format result: %v", err)), nil
+		// Diagnosis followed by one line per event for the text format
+		if logFormat == logFormatText {
+			return logsTextResult(formatSystemLogsText(containerAppSystemLogs), logsTextOutput{Total: containerAppSystemLogs.Total, Truncated: containerAppSystemLogs.Truncated}), nil
 		}
 
-		return mcp.NewToolResultText(string(result)), nil
+		return newToolResultJSON(containerAppSystemLogs, systemLogsDiagnosis(containerAppSystemLogs)), nil
 	})
 }
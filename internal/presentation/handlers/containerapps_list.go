@@ -2,8 +2,8 @@ package handlers
 
 import (
 	"context"
-	"encoding/json"
-	"fmt"
+
+	"github.com/Nick1994209/cloudru-containerapps-mcp/internal/domain"
 
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
@@ -13,9 +13,17 @@ import (
 func (s *MCPServer) RegisterGetListContainerAppsTool(mcpServer *server.MCPServer) {
 	// Prepare tool options including description and fields
 	toolOptions := s.getMCPFieldsOptions(
-		"Get list of Container Apps from Cloud.ru. Project ID can be set via PROJECT_ID environment variable and obtained from console.cloud.ru",
+		"Get paginated list of Container Apps from Cloud.ru. By default returns a compact summary table, use view=full or cloudru_get_containerapp for all fields. Pass nextPageToken from the response as page_token to get the next page. Project ID can be set via PROJECT_ID environment variable and obtained from console.cloud.ru",
 		"project_id",
+		"page_size",
+		"page_token",
+		"filter",
+		"order_by",
+		"fetch_all",
+		"view",
+		"limit",
 	)
+	toolOptions = append(toolOptions, listViewOutputSchema[containerAppSummary, domain.ContainerApp]())
 	getListContainerAppsTool := mcp.NewTool("cloudru_get_list_containerapps", toolOptions...)
 
 	mcpServer.AddTool(getListContainerAppsTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -25,18 +33,31 @@ func (s *MCPServer) RegisterGetListContainerAppsTool(mcpServer *server.MCPServer
 			return mcp.NewToolResultError(err.Error()), nil
 		}
 
-		// Call the service
-		containerApps, err := s.containerAppsService.GetListContainerApps(projectID)
+		// Get pagination, filtering and sorting parameters
+		listOptions, err := s.getListOptions(request)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		// Get output view and record limit
+		view, err := s.getListView(request)
 		if err != nil {
 			return mcp.NewToolResultError(err.Error()), nil
 		}
+		listOptions = view.pageOptions(listOptions)
 
-		// Convert to JSON for output
-		result, err := json.MarshalIndent(containerApps, "", "  ")
+		// Call the service
+		containerApps, err := s.containerAppsService.GetListContainerApps(ctx, projectID, listOptions)
 		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("Failed to format result: %v", err)), nil
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		// Compact rows and table for the summary view
+		if view.view == viewSummary {
+			output := newListOutput(summarizeContainerApps(containerApps.Data), containerApps.NextPageToken, view.limit)
+			return mcp.NewToolResultStructured(output, containerAppsSummary(containerApps, view.limit)), nil
 		}
 
-		return mcp.NewToolResultText(string(result)), nil
+		return newToolResultJSON(newListOutput(containerApps.Data, containerApps.NextPageToken, view.limit), ""), nil
 	})
 }
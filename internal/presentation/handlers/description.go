@@ -11,9 +11,11 @@ import (
 func (s *MCPServer) RegisterDescriptionTool(mcpServer *server.MCPServer) {
 	descriptionTool := mcp.NewTool("cloudru_containerapps_description",
 		mcp.WithDescription("Returns usage instructions for Cloud.ru Container Apps MCP"),
+		outputSchema[descriptionOutput](),
 	)
 
 	mcpServer.AddTool(descriptionTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		return mcp.NewToolResultText(s.descriptionService.GetDescription()), nil
+		description := s.descriptionService.GetDescription()
+		return mcp.NewToolResultStructured(descriptionOutput{Description: description}, description), nil
 	})
 }
@@ -22,6 +22,7 @@ func (s *MCPServer) RegisterDockerBuildAndPushTool(mcpServer *server.MCPServer)
 		"dockerfile_folder",
 		"show_commands",
 	)
+	toolOptions = append(toolOptions, outputSchema[dockerBuildAndPushOutput]())
 	dockerPushTool := mcp.NewTool("cloudru_docker_build_and_push", toolOptions...)
 
 	mcpServer.AddTool(dockerPushTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -56,19 +57,19 @@ func (s *MCPServer) RegisterDockerBuildAndPushTool(mcpServer *server.MCPServer)
 		}
 
 		if showCommands {
-			buildCmd, pushCmd, err := s.dockerService.ShowBuildAndPushCommands(image)
+			buildCmd, pushCmd, err := s.dockerService.ShowBuildAndPushCommands(ctx, image)
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
 			combined := fmt.Sprintf("Run Docker build command:\n'%s'\n and then run docker push command:\n'%s'. IMPORTANT! Use platform for building image.", buildCmd, pushCmd)
-			return mcp.NewToolResultText(combined), nil
+			return mcp.NewToolResultStructured(dockerBuildAndPushOutput{BuildCommand: buildCmd, PushCommand: pushCmd}, combined), nil
 		}
 
-		result, err := s.dockerService.BuildAndPush(image)
+		result, err := s.dockerService.BuildAndPush(ctx, image)
 		if err != nil {
 			return mcp.NewToolResultError(err.Error()), nil
 		}
 
-		return mcp.NewToolResultText(fmt.Sprintf("Successfully built and pushed Docker image to Cloud.ru Artifact Registry: %s", result)), nil
+		return mcp.NewToolResultStructured(dockerBuildAndPushOutput{Image: result}, fmt.Sprintf("Successfully built and pushed Docker image to Cloud.ru Artifact Registry: %s", result)), nil
 	})
 }
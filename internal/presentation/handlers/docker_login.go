@@ -12,6 +12,7 @@ import (
 func (s *MCPServer) RegisterDockerLoginTool(mcpServer *server.MCPServer) {
 	// Prepare tool options including description and fields
 	toolOptions := s.getMCPFieldsOptions("Login to Cloud.ru Artifact registry (Docker registry)", "registry_name")
+	toolOptions = append(toolOptions, outputSchema[dockerLoginOutput]())
 	dockerLoginTool := mcp.NewTool("cloudru_docker_login", toolOptions...)
 
 	mcpServer.AddTool(dockerLoginTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -21,10 +22,10 @@ func (s *MCPServer) RegisterDockerLoginTool(mcpServer *server.MCPServer) {
 			return mcp.NewToolResultError(err.Error()), nil
 		}
 
-		result, err := s.dockerService.Login(registryName)
+		result, err := s.dockerService.Login(ctx, registryName)
 		if err != nil {
 			return mcp.NewToolResultError(err.Error()), nil
 		}
-		return mcp.NewToolResultText(fmt.Sprintf("Successfully login to Cloud.ru Artifact Registry: %s", result)), nil
+		return mcp.NewToolResultStructured(dockerLoginOutput{Registry: result}, fmt.Sprintf("Successfully login to Cloud.ru Artifact Registry: %s", result)), nil
 	})
 }
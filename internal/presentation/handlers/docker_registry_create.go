@@ -2,7 +2,6 @@ package handlers
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 
 	"github.com/mark3labs/mcp-go/mcp"
@@ -13,11 +12,15 @@ import (
 func (s *MCPServer) RegisterCreateDockerRegistryTool(mcpServer *server.MCPServer) {
 	// Prepare tool options including description and fields
 	toolOptions := s.getMCPFieldsOptions(
-		"Create a new Docker Registry in Cloud.ru",
+		"Create a new Docker Registry in Cloud.ru. With wait=true the tool waits for the operation of the create request when the API returns one",
 		"project_id",
 		"registry_name",
 		"registry_is_public",
+		"wait",
+		"wait_timeout",
+		"dry_run",
 	)
+	toolOptions = append(toolOptions, outputSchema[dockerRegistryOutput]())
 	createDockerRegistryTool := mcp.NewTool("cloudru_create_docker_registry", toolOptions...)
 
 	mcpServer.AddTool(createDockerRegistryTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -39,18 +42,29 @@ func (s *MCPServer) RegisterCreateDockerRegistryTool(mcpServer *server.MCPServer
 			return mcp.NewToolResultError(err.Error()), nil
 		}
 
-		// Call the service
-		dockerRegistry, err := s.dockerRegistryService.CreateDockerRegistry(projectID, registryName, isPublic)
+		// Record the request instead of sending it if dry_run=true
+		ctx, dryRun, err := s.getDryRunContext(ctx, request)
 		if err != nil {
 			return mcp.NewToolResultError(err.Error()), nil
 		}
 
-		// Convert to JSON for output
-		result, err := json.MarshalIndent(dockerRegistry, "", "  ")
+		// Call the service
+		dockerRegistry, err := s.dockerRegistryService.CreateDockerRegistry(ctx, projectID, registryName, isPublic)
+		if result, ok := dryRunToolResult(dryRun, err, dryRunDockerRegistryOutput); ok {
+			return result, nil
+		}
 		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("Failed to format result: %v", err)), nil
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		// Wait for the operation to complete if requested
+		if dockerRegistry.Operation != nil {
+			dockerRegistry.Operation, err = s.waitOperationIfRequested(ctx, request, dockerRegistry.Operation)
+			if err != nil {
+				return mcp.NewToolResultError(operationErrorText(dockerRegistry.Operation, err)), nil
+			}
 		}
 
-		return mcp.NewToolResultText(fmt.Sprintf("Successfully created Docker Registry: %s\n%s", registryName, string(result))), nil
+		return newToolResultJSON(dockerRegistry, fmt.Sprintf("Successfully created Docker Registry: %s", registryName)), nil
 	})
 }
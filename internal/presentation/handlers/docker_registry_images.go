@@ -2,8 +2,8 @@ package handlers
 
 import (
 	"context"
-	"encoding/json"
-	"fmt"
+
+	"github.com/Nick1994209/cloudru-containerapps-mcp/internal/domain"
 
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
@@ -16,6 +16,7 @@ func (s *MCPServer) RegisterGetRegistryImagesTool(mcpServer *server.MCPServer) {
 		"Get list of images from a Docker registry in Cloud.ru",
 		"registry_name",
 	)
+	toolOptions = append(toolOptions, outputSchema[domain.RegistryImagesResponse]())
 	getRegistryImagesTool := mcp.NewTool("cloudru_get_registry_images", toolOptions...)
 
 	mcpServer.AddTool(getRegistryImagesTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -26,17 +27,11 @@ func (s *MCPServer) RegisterGetRegistryImagesTool(mcpServer *server.MCPServer) {
 		}
 
 		// Call the service
-		images, err := s.dockerService.GetRegistryImages(registryName)
+		images, err := s.dockerService.GetRegistryImages(ctx, registryName)
 		if err != nil {
 			return mcp.NewToolResultError(err.Error()), nil
 		}
 
-		// Convert to JSON for output
-		result, err := json.MarshalIndent(images, "", "  ")
-		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("Failed to format result: %v", err)), nil
-		}
-
-		return mcp.NewToolResultText(string(result)), nil
+		return newToolResultJSON(images, ""), nil
 	})
 }
@@ -2,8 +2,6 @@ package handlers
 
 import (
 	"context"
-	"encoding/json"
-	"fmt"
 
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
@@ -16,6 +14,7 @@ func (s *MCPServer) RegisterGetListDockerRegistriesTool(mcpServer *server.MCPSer
 		"Get list of Docker Registries from Cloud.ru. Project ID can be set via PROJECT_ID environment variable and obtained from console.cloud.ru",
 		"project_id",
 	)
+	toolOptions = append(toolOptions, outputSchema[dockerRegistriesOutput]())
 	getListDockerRegistriesTool := mcp.NewTool("cloudru_get_list_docker_registries", toolOptions...)
 
 	mcpServer.AddTool(getListDockerRegistriesTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -26,17 +25,11 @@ func (s *MCPServer) RegisterGetListDockerRegistriesTool(mcpServer *server.MCPSer
 		}
 
 		// Call the service
-		dockerRegistries, err := s.dockerRegistryService.GetListDockerRegistries(projectID)
+		dockerRegistries, err := s.dockerRegistryService.GetListDockerRegistries(ctx, projectID)
 		if err != nil {
 			return mcp.NewToolResultError(err.Error()), nil
 		}
 
-		// Convert to JSON for output
-		result, err := json.MarshalIndent(dockerRegistries, "", "  ")
-		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("Failed to format result: %v", err)), nil
-		}
-
-		return mcp.NewToolResultText(string(result)), nil
+		return newToolResultJSON(dockerRegistriesOutput{Data: dockerRegistries}, ""), nil
 	})
 }
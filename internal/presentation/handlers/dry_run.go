@@ -0,0 +1,192 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/Nick1994209/cloudru-containerapps-mcp/internal/domain"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// dryRunOutput describes the request a mutating tool would send in dry-run mode
+type dryRunOutput struct {
+	Method  string          `json:"method"`
+	URL     string          `json:"url"`
+	Payload json.RawMessage `json:"payload,omitempty"`
+	Diff    []string        `json:"diff"`
+	// Current is the object a request without a payload (delete, start, stop) acts on
+	Current json.RawMessage `json:"current,omitempty"`
+}
+
+// operationOutput is the structured output of tools returning an operation, DryRun is set instead of the operation fields in dry-run mode
+type operationOutput struct {
+	*domain.Operation
+	DryRun *dryRunOutput `json:"dryRun,omitempty"`
+}
+
+// jobExecutionOutput is the structured output of the execute job tool, DryRun is set instead of the execution fields in dry-run mode
+type jobExecutionOutput struct {
+	*domain.JobExecution
+	DryRun *dryRunOutput `json:"dryRun,omitempty"`
+}
+
+// dockerRegistryOutput is the structured output of the create docker registry tool, DryRun is set instead of the registry fields in dry-run mode
+type dockerRegistryOutput struct {
+	*domain.DockerRegistry
+	DryRun *dryRunOutput `json:"dryRun,omitempty"`
+}
+
+// dryRunOperationOutput wraps the dry-run output of tools returning an operation
+func dryRunOperationOutput(output *dryRunOutput) interface{} {
+	return operationOutput{DryRun: output}
+}
+
+// dryRunJobExecutionOutput wraps the dry-run output of the execute job tool
+func dryRunJobExecutionOutput(output *dryRunOutput) interface{} {
+	return jobExecutionOutput{DryRun: output}
+}
+
+// dryRunDockerRegistryOutput wraps the dry-run output of the create docker registry tool
+func dryRunDockerRegistryOutput(output *dryRunOutput) interface{} {
+	return dockerRegistryOutput{DryRun: output}
+}
+
+// getDryRunContext returns a context which records the mutating API request instead of sending it when the request has dry_run=true.
+// The returned recorder is nil when dry-run mode is off
+func (s *MCPServer) getDryRunContext(ctx context.Context, request mcp.CallToolRequest) (context.Context, *domain.DryRun, error) {
+	enabled, err := s.getMCPBooleanFieldValue("dry_run", request)
+	if err != nil || !enabled {
+		return ctx, nil, err
+	}
+
+	dryRun := &domain.DryRun{}
+	return domain.WithDryRun(ctx, dryRun), dryRun, nil
+}
+
+// dryRunToolResult returns the recorded request and its diff against the current object, if the service stopped because of dry-run mode.
+// wrap puts the dry-run output into the structured output of the tool
+func dryRunToolResult(dryRun *domain.DryRun, err error, wrap func(*dryRunOutput) interface{}) (*mcp.CallToolResult, bool) {
+	if dryRun == nil || dryRun.Request == nil || !errors.Is(err, domain.ErrDryRun) {
+		return nil, false
+	}
+
+	output := &dryRunOutput{
+		Method:  dryRun.Request.Method,
+		URL:     dryRun.Request.URL,
+		Payload: dryRun.Request.Payload,
+		Diff:    diffJSON(dryRun.Current, dryRun.Request.Payload),
+	}
+
+	// Current values of secret variables are not part of the output, so they are masked in the diff here
+	currentSecrets := secretValuesOf(dryRun.Current)
+	for i, line := range output.Diff {
+		output.Diff[i] = maskText(line, currentSecrets)
+	}
+
+	message := fmt.Sprintf("Dry run: %s %s was not sent, nothing was changed.", output.Method, output.URL)
+	switch {
+	case len(output.Diff) > 0:
+		message = fmt.Sprintf("%s Changes against the current object:\n%s", message, strings.Join(output.Diff, "\n"))
+	case len(output.Payload) > 0:
+		message += " The payload does not change the current object."
+	case len(dryRun.Current) > 0:
+		output.Current = dryRun.Current
+		message += " " + currentObjectSummary(dryRun.Current)
+	}
+	return newToolResultJSON(wrap(output), message), true
+}
+
+// currentObjectSummary describes the name and status of the current object of a request without a payload
+func currentObjectSummary(current json.RawMessage) string {
+	var object struct {
+		Name   string `json:"name"`
+		Status string `json:"status"`
+	}
+	if err := json.Unmarshal(current, &object); err != nil || object.Status == "" {
+		return "The current object is returned in current."
+	}
+	return fmt.Sprintf("The current status of %s is %s, the object is returned in current.", object.Name, object.Status)
+}
+
+// diffJSON returns human-readable differences between the current object and the payload:
+// "+ path: value" for added, "- path: value" for removed and "~ path: old -> new" for changed fields.
+// Without the current object every payload field is added
+func diffJSON(current json.RawMessage, payload json.RawMessage) []string {
+	if len(payload) == 0 {
+		return []string{}
+	}
+
+	currentFields := map[string]string{}
+	if len(current) > 0 {
+		flattenJSON(current, currentFields)
+	}
+	payloadFields := map[string]string{}
+	flattenJSON(payload, payloadFields)
+
+	paths := make([]string, 0, len(payloadFields))
+	for path := range payloadFields {
+		paths = append(paths, path)
+	}
+	for path := range currentFields {
+		if _, ok := payloadFields[path]; !ok {
+			paths = append(paths, path)
+		}
+	}
+	sort.Strings(paths)
+
+	diff := []string{}
+	for _, path := range paths {
+		oldValue, hasOld := currentFields[path]
+		newValue, hasNew := payloadFields[path]
+		switch {
+		case !hasOld:
+			diff = append(diff, fmt.Sprintf("+ %s: %s", path, newValue))
+		case !hasNew:
+			diff = append(diff, fmt.Sprintf("- %s: %s", path, oldValue))
+		case oldValue != newValue:
+			diff = append(diff, fmt.Sprintf("~ %s: %s -> %s", path, oldValue, newValue))
+		}
+	}
+	return diff
+}
+
+// flattenJSON flattens a JSON document to dotted paths like template.containers[0].image with JSON encoded values
+func flattenJSON(data json.RawMessage, result map[string]string) {
+	var value interface{}
+	if err := json.Unmarshal(data, &value); err != nil {
+		result[""] = string(data)
+		return
+	}
+	flattenValue("", value, result)
+}
+
+func flattenValue(path string, value interface{}, result map[string]string) {
+	switch typed := value.(type) {
+	case map[string]interface{}:
+		if len(typed) == 0 {
+			result[path] = "{}"
+		}
+		for key, item := range typed {
+			itemPath := key
+			if path != "" {
+				itemPath = path + "." + key
+			}
+			flattenValue(itemPath, item, result)
+		}
+	case []interface{}:
+		if len(typed) == 0 {
+			result[path] = "[]"
+		}
+		for i, item := range typed {
+			flattenValue(fmt.Sprintf("%s[%d]", path, i), item, result)
+		}
+	default:
+		encoded, _ := json.Marshal(typed)
+		result[path] = string(encoded)
+	}
+}
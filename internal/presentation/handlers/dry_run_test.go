@@ -0,0 +1,42 @@
+package handlers
+
+import (
+	"testing"
+
+	"github.com/Nick1994209/cloudru-containerapps-mcp/internal/domain"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffJSON(t *testing.T) {
+	current := []byte(`{"name":"app","template":{"containers":[{"image":"app:v1","env":[{"name":"A","value":"1"}]}]}}`)
+	payload := []byte(`{"name":"app","description":"new","template":{"containers":[{"image":"app:v2"}]}}`)
+
+	assert.Equal(t, []string{
+		`+ description: "new"`,
+		`- template.containers[0].env[0].name: "A"`,
+		`- template.containers[0].env[0].value: "1"`,
+		`~ template.containers[0].image: "app:v1" -> "app:v2"`,
+	}, diffJSON(current, payload))
+}
+
+func TestDiffJSON_WithoutCurrentObject(t *testing.T) {
+	assert.Equal(t, []string{`+ name: "app"`, `+ port: 8080`}, diffJSON(nil, []byte(`{"name":"app","port":8080}`)))
+	assert.Empty(t, diffJSON([]byte(`{"name":"app"}`), nil))
+}
+
+func TestDryRunToolResult_RequestWithoutPayloadReturnsCurrentObject(t *testing.T) {
+	dryRun := &domain.DryRun{
+		Request: &domain.DryRunRequest{Method: "DELETE", URL: "/v2/containers/app"},
+		Current: []byte(`{"name":"app","status":"RUNNING","template":{"containers":[{"env":[{"name":"TOKEN","value":"token-ref","type":"secret"}]}]}}`),
+	}
+
+	result, ok := dryRunToolResult(dryRun, domain.ErrDryRun, dryRunOperationOutput)
+	require.True(t, ok)
+
+	text := result.Content[0].(mcp.TextContent).Text
+	assert.Contains(t, text, "The current status of app is RUNNING")
+	assert.Contains(t, text, `"current"`)
+	assert.NotContains(t, text, "token-ref")
+}
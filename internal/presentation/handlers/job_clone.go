@@ -0,0 +1,90 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Nick1994209/cloudru-containerapps-mcp/internal/manifest"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// RegisterCloneJobTool registers the clone job tool with the MCP server
+func (s *MCPServer) RegisterCloneJobTool(mcpServer *server.MCPServer) {
+	// Prepare tool options including description and fields
+	toolOptions := s.getMCPFieldsOptions(
+		"Clone a Job in Cloud.ru into another project or under another name, e.g. to promote staging to production. All fields including privileged mode, retries, timeout and runImmediately are copied from the source, the image and environment variables of the main container can be overridden",
+		"project_id",
+		"job_name",
+		"target_project_id",
+		"target_job_name",
+		"job_image",
+		"job_environment_variables",
+		"wait",
+		"wait_timeout",
+		"dry_run",
+	)
+	toolOptions = append(toolOptions, outputSchema[operationOutput]())
+	cloneTool := mcp.NewTool("cloudru_clone_job", toolOptions...)
+	// The image is copied from the source when it is not overridden
+	optionalFields(&cloneTool, "job_image")
+
+	mcpServer.AddTool(cloneTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		// Get project ID
+		projectID, err := s.getMCPFieldValue("project_id", request)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		// Get job name
+		jobName, err := s.getMCPFieldValue("job_name", request)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		targetProjectID, targetName, err := s.getCloneTarget(request, "target_job_name", projectID, jobName)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		overrides, err := s.getCloneOverrides(request, "job_image", "job_environment_variables")
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		// Get the source Job
+		raw, err := s.jobsService.GetJobRaw(ctx, projectID, jobName)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		// The create payload is the source object itself, so settings the manifest cannot express are not lost
+		object, err := manifest.CloneObject(raw, targetName, overrides)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		// Record the request instead of sending it if dry_run=true
+		ctx, dryRun, err := s.getDryRunContext(ctx, request)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		// Call the service
+		operation, err := s.jobsService.CreateJobFromObject(ctx, targetProjectID, object)
+		if result, ok := dryRunToolResult(dryRun, err, dryRunOperationOutput); ok {
+			return result, nil
+		}
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		// Wait for the operation to complete if requested
+		operation, err = s.waitOperationIfRequested(ctx, request, operation)
+		if err != nil {
+			return mcp.NewToolResultError(operationErrorText(operation, err)), nil
+		}
+
+		message := fmt.Sprintf("Successfully cloned Job %s/%s to %s/%s", projectID, jobName, targetProjectID, targetName)
+		return newToolResultJSON(operation, message), nil
+	})
+}
@@ -0,0 +1,68 @@
+package handlers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Nick1994209/cloudru-containerapps-mcp/internal/domain"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// cloneJobsService returns a raw Job and records the project and the object of the clone
+type cloneJobsService struct {
+	fakeJobsService
+	projectID string
+	created   []byte
+}
+
+func (f *cloneJobsService) GetJobRaw(ctx context.Context, projectID string, jobName string) ([]byte, error) {
+	return []byte(`{
+		"id": "job-id",
+		"projectId": "` + projectID + `",
+		"name": "` + jobName + `",
+		"status": "ACTIVE",
+		"runImmediately": true,
+		"configuration": {"privileged": true},
+		"template": {"maxRetries": 5, "timeout": "600s", "containers": [{"name": "nightly", "image": "job:v1", "env": [{"name": "TOKEN", "value": "token-ref", "type": "secret"}]}]}
+	}`), nil
+}
+
+func (f *cloneJobsService) CreateJobFromObject(ctx context.Context, projectID string, object []byte) (*domain.Operation, error) {
+	f.projectID = projectID
+	f.created = object
+	return &domain.Operation{ID: "operation-1"}, nil
+}
+
+func TestCloneJobTool_KeepsExecutionSettings(t *testing.T) {
+	t.Setenv("CLOUDRU_KEY_ID", "key-id")
+	t.Setenv("CLOUDRU_KEY_SECRET", "key-secret")
+
+	jobs := &cloneJobsService{}
+	s := NewMCPServer(nil, nil, nil, nil, jobs, nil, nil)
+	mcpServer := server.NewMCPServer("test", "0.0.0", server.WithToolCapabilities(false))
+	s.RegisterCloneJobTool(mcpServer)
+
+	request := mcp.CallToolRequest{}
+	request.Params.Name = "cloudru_clone_job"
+	request.Params.Arguments = map[string]any{
+		"project_id":                "staging",
+		"job_name":                  "nightly",
+		"target_project_id":         "production",
+		"job_image":                 "job:v2",
+		"job_environment_variables": "TOKEN='***'",
+	}
+	result, err := mcpServer.GetTool("cloudru_clone_job").Handler(context.Background(), request)
+	require.NoError(t, err)
+
+	require.False(t, result.IsError, "%v", result.Content)
+	assert.Equal(t, "production", jobs.projectID)
+	assert.JSONEq(t, `{
+		"name": "nightly",
+		"runImmediately": true,
+		"configuration": {"privileged": true},
+		"template": {"maxRetries": 5, "timeout": "600s", "containers": [{"name": "nightly", "image": "job:v2", "env": [{"name": "TOKEN", "value": "token-ref", "type": "secret"}]}]}
+	}`, string(jobs.created))
+}
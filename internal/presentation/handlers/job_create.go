@@ -2,10 +2,8 @@ package handlers
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"strconv"
-	"strings"
 
 	"github.com/Nick1994209/cloudru-containerapps-mcp/internal/domain"
 	"github.com/mark3labs/mcp-go/mcp"
@@ -22,6 +20,7 @@ func (s *MCPServer) RegisterCreateJobTool(mcpServer *server.MCPServer) {
 		"job_image",
 		"job_privileged",
 		"job_cpu",
+		"job_memory",
 		"job_description",
 		"job_environment_variables",
 		"job_command",
@@ -29,7 +28,11 @@ func (s *MCPServer) RegisterCreateJobTool(mcpServer *server.MCPServer) {
 		"job_retry_count",
 		"job_execution_timeout",
 		"job_run_immediately",
+		"wait",
+		"wait_timeout",
+		"dry_run",
 	)
+	toolOptions = append(toolOptions, outputSchema[operationOutput]())
 	createJobTool := mcp.NewTool("cloudru_create_job", toolOptions...)
 
 	mcpServer.AddTool(createJobTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -63,6 +66,9 @@ func (s *MCPServer) RegisterCreateJobTool(mcpServer *server.MCPServer) {
 			cpu = "0.1"
 		}
 
+		// Get memory
+		memory, _ := s.getMCPFieldValue("job_memory", request)
+
 		// Get description
 		description, _ := s.getMCPFieldValue("job_description", request)
 
@@ -70,27 +76,15 @@ func (s *MCPServer) RegisterCreateJobTool(mcpServer *server.MCPServer) {
 		environmentVariables, _ := s.getMCPFieldValue("job_environment_variables", request)
 
 		// Get command
-		commandStr, _ := s.getMCPFieldValue("job_command", request)
-		var command []string
-		if commandStr != "" {
-			// Split by comma
-			command = strings.Split(commandStr, ",")
-			// Trim spaces from each command
-			for i, cmd := range command {
-				command[i] = strings.TrimSpace(cmd)
-			}
+		command, err := s.getMCPListFieldValue("job_command", request)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
 		}
 
 		// Get args
-		argsStr, _ := s.getMCPFieldValue("job_args", request)
-		var args []string
-		if argsStr != "" {
-			// Split by comma
-			args = strings.Split(argsStr, ",")
-			// Trim spaces from each arg
-			for i, arg := range args {
-				args[i] = strings.TrimSpace(arg)
-			}
+		args, err := s.getMCPListFieldValue("job_args", request)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
 		}
 
 		// Get retry count
@@ -124,6 +118,7 @@ func (s *MCPServer) RegisterCreateJobTool(mcpServer *server.MCPServer) {
 			JobImage:                jobImage,
 			JobPrivileged:           privileged,
 			JobCPU:                  cpu,
+			JobMemory:               memory,
 			JobDescription:          description,
 			JobEnvironmentVariables: environmentVariables,
 			JobCommand:              command,
@@ -133,18 +128,27 @@ func (s *MCPServer) RegisterCreateJobTool(mcpServer *server.MCPServer) {
 			JobRunImmediately:       runImmediately,
 		}
 
+		// Record the request instead of sending it if dry_run=true
+		ctx, dryRun, err := s.getDryRunContext(ctx, request)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
 		// Call the service
-		operation, err := s.jobsService.CreateJob(createRequest)
+		operation, err := s.jobsService.CreateJob(ctx, createRequest)
+		if result, ok := dryRunToolResult(dryRun, err, dryRunOperationOutput); ok {
+			return result, nil
+		}
 		if err != nil {
 			return mcp.NewToolResultError(err.Error()), nil
 		}
 
-		// Convert to JSON for output
-		result, err := json.MarshalIndent(operation, "", "  ")
+		// Wait for the operation to complete if requested
+		operation, err = s.waitOperationIfRequested(ctx, request, operation)
 		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("Failed to format result: %v", err)), nil
+			return mcp.NewToolResultError(operationErrorText(operation, err)), nil
 		}
 
-		return mcp.NewToolResultText(fmt.Sprintf("Successfully created Job: %s\n%s", jobName, string(result))), nil
+		return newToolResultJSON(operation, fmt.Sprintf("Successfully created Job: %s", jobName)), nil
 	})
 }
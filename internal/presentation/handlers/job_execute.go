@@ -13,11 +13,15 @@ import (
 func (s *MCPServer) RegisterExecuteJobTool(mcpServer *server.MCPServer) {
 	// Prepare tool options including description and fields
 	toolOptions := s.getMCPFieldsOptions(
-		"Execute a Job in Cloud.ru by name. Project ID can be set via PROJECT_ID environment variable and obtained from console.cloud.ru",
+		"Execute a Job in Cloud.ru by name. With wait=true the tool waits for the operation of the execute request when the API returns one. Project ID can be set via PROJECT_ID environment variable and obtained from console.cloud.ru",
 		"project_id",
 		"job_name",
 		"params",
+		"wait",
+		"wait_timeout",
+		"dry_run",
 	)
+	toolOptions = append(toolOptions, outputSchema[jobExecutionOutput]())
 	executeJobTool := mcp.NewTool("cloudru_execute_job", toolOptions...)
 
 	mcpServer.AddTool(executeJobTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -44,18 +48,29 @@ func (s *MCPServer) RegisterExecuteJobTool(mcpServer *server.MCPServer) {
 			}
 		}
 
-		// Call the service
-		jobExecution, err := s.jobsService.ExecuteJob(projectID, jobName, params)
+		// Record the request instead of sending it if dry_run=true
+		ctx, dryRun, err := s.getDryRunContext(ctx, request)
 		if err != nil {
 			return mcp.NewToolResultError(err.Error()), nil
 		}
 
-		// Convert to JSON for output
-		result, err := json.MarshalIndent(jobExecution, "", "  ")
+		// Call the service
+		jobExecution, err := s.jobsService.ExecuteJob(ctx, projectID, jobName, params)
+		if result, ok := dryRunToolResult(dryRun, err, dryRunJobExecutionOutput); ok {
+			return result, nil
+		}
 		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("Failed to format result: %v", err)), nil
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		// Wait for the operation to complete if requested
+		if jobExecution.Operation != nil {
+			jobExecution.Operation, err = s.waitOperationIfRequested(ctx, request, jobExecution.Operation)
+			if err != nil {
+				return mcp.NewToolResultError(operationErrorText(jobExecution.Operation, err)), nil
+			}
 		}
 
-		return mcp.NewToolResultText(string(result)), nil
+		return newToolResultJSON(jobExecution, ""), nil
 	})
 }
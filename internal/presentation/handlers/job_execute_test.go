@@ -0,0 +1,47 @@
+package handlers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Nick1994209/cloudru-containerapps-mcp/internal/domain"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// executeJobsService starts every execution asynchronously and returns its operation
+type executeJobsService struct {
+	fakeJobsService
+}
+
+func (f *executeJobsService) ExecuteJob(ctx context.Context, projectID string, jobName string, params map[string]interface{}) (*domain.JobExecution, error) {
+	return &domain.JobExecution{Operation: &domain.Operation{ID: "op-1", ResourceName: jobName}}, nil
+}
+
+func TestExecuteJobTool_WaitsForOperation(t *testing.T) {
+	t.Setenv("CLOUDRU_KEY_ID", "key-id")
+	t.Setenv("CLOUDRU_KEY_SECRET", "key-secret")
+
+	s := NewMCPServer(nil, nil, nil, nil, &executeJobsService{}, &doneOperationsService{}, nil)
+	mcpServer := server.NewMCPServer("test", "0.0.0", server.WithToolCapabilities(false))
+	s.RegisterExecuteJobTool(mcpServer)
+
+	request := mcp.CallToolRequest{}
+	request.Params.Name = "cloudru_execute_job"
+	request.Params.Arguments = map[string]any{
+		"project_id": "project-1",
+		"job_name":   "nightly",
+		"wait":       "true",
+	}
+	result, err := mcpServer.GetTool("cloudru_execute_job").Handler(context.Background(), request)
+	require.NoError(t, err)
+
+	require.False(t, result.IsError, "%v", result.Content)
+	output, ok := result.StructuredContent.(*domain.JobExecution)
+	require.True(t, ok, "%T", result.StructuredContent)
+	require.NotNil(t, output.Operation)
+	assert.Equal(t, "op-1", output.Operation.ID)
+	assert.True(t, output.Operation.Done)
+}
@@ -0,0 +1,58 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Nick1994209/cloudru-containerapps-mcp/internal/domain"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// RegisterGetJobExecutionTool registers the get job execution tool with the MCP server
+func (s *MCPServer) RegisterGetJobExecutionTool(mcpServer *server.MCPServer) {
+	// Prepare tool options including description and fields
+	toolOptions := s.getMCPFieldsOptions(
+		"Get a specific Job execution from Cloud.ru with its status, start and finish time, exit code and failure reason. Project ID can be set via PROJECT_ID environment variable and obtained from console.cloud.ru",
+		"project_id",
+		"job_name",
+		"execution_name",
+	)
+	toolOptions = append(toolOptions, outputSchema[domain.JobExecution]())
+	getJobExecutionTool := mcp.NewTool("cloudru_get_job_execution", toolOptions...)
+
+	mcpServer.AddTool(getJobExecutionTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		// Get project ID
+		projectID, err := s.getMCPFieldValue("project_id", request)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		// Get job name
+		jobName, err := s.getMCPFieldValue("job_name", request)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		// Get execution name
+		executionName, err := s.getMCPFieldValue("execution_name", request)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		// Call the service
+		execution, err := s.jobsService.GetJobExecution(ctx, projectID, jobName, executionName)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		// Point to the logs of a failed execution
+		message := ""
+		if strings.Contains(strings.ToLower(execution.ExecutionStatus), "fail") {
+			message = fmt.Sprintf("Execution %s failed, read its output with cloudru_get_job_execution_logs (e.g. log_format=text)", executionName)
+		}
+		return newToolResultJSON(execution, message), nil
+	})
+}
@@ -0,0 +1,72 @@
+package handlers
+
+import (
+	"context"
+
+	"github.com/Nick1994209/cloudru-containerapps-mcp/internal/domain"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// RegisterGetJobExecutionLogsTool registers the get job execution logs tool with the MCP server
+func (s *MCPServer) RegisterGetJobExecutionLogsTool(mcpServer *server.MCPServer) {
+	// Prepare tool options including description and fields
+	toolOptions := s.getMCPFieldsOptions(
+		"Get logs of a specific Job execution from Cloud.ru, e.g. to find out why a batch run failed. Filter by time window, level and message text, e.g. level=error log_format=text. The logs API does not filter, so filters are applied by this server to the pages the API returns; if those pages do not reach back to since, the result is marked partial with availableSince. Project ID can be set via PROJECT_ID environment variable and obtained from console.cloud.ru",
+		"project_id",
+		"job_name",
+		"execution_name",
+		"since",
+		"until",
+		"level",
+		"search",
+		"max_lines",
+		"log_format",
+	)
+	toolOptions = append(toolOptions, outputSchema[domain.JobExecutionLogs]())
+	getJobExecutionLogsTool := mcp.NewTool("cloudru_get_job_execution_logs", toolOptions...)
+
+	mcpServer.AddTool(getJobExecutionLogsTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		// Get project ID
+		projectID, err := s.getMCPFieldValue("project_id", request)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		// Get job name
+		jobName, err := s.getMCPFieldValue("job_name", request)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		// Get execution name
+		executionName, err := s.getMCPFieldValue("execution_name", request)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		// Get log filters and output format
+		logOptions, err := s.getLogOptions(request)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		logFormat, err := s.getLogFormat(request)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		// Call the service
+		executionLogs, err := s.jobsService.GetJobExecutionLogs(ctx, projectID, jobName, executionName, logOptions)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		// One line per entry for the text format
+		if logFormat == logFormatText {
+			return logsTextResult(formatLogsText(executionLogs), logsTextOutputOf(executionLogs)), nil
+		}
+
+		return newToolResultJSON(executionLogs, partialLogsNote(executionLogs)), nil
+	})
+}
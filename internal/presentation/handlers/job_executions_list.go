@@ -2,8 +2,8 @@ package handlers
 
 import (
 	"context"
-	"encoding/json"
-	"fmt"
+
+	"github.com/Nick1994209/cloudru-containerapps-mcp/internal/domain"
 
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
@@ -13,12 +13,17 @@ import (
 func (s *MCPServer) RegisterGetListExecutionsTool(mcpServer *server.MCPServer) {
 	// Prepare tool options including description and fields
 	toolOptions := s.getMCPFieldsOptions(
-		"Get paginated list of job executions from Cloud.ru. Project ID can be set via PROJECT_ID environment variable and obtained from console.cloud.ru",
+		"Get paginated list of job executions from Cloud.ru. Pass nextPageToken from the response as page_token to get the next page. Use cloudru_get_job_execution and cloudru_get_job_execution_logs with the executionName to see why an execution failed. Project ID can be set via PROJECT_ID environment variable and obtained from console.cloud.ru",
 		"project_id",
 		"job_name",
 		"page_size",
+		"page_token",
+		"filter",
+		"order_by",
+		"fetch_all",
 	)
 
+	toolOptions = append(toolOptions, outputSchema[listOutput[domain.JobExecution]]())
 	getListExecutionsTool := mcp.NewTool("cloudru_job_executions_list", toolOptions...)
 
 	mcpServer.AddTool(getListExecutionsTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -34,25 +39,18 @@ func (s *MCPServer) RegisterGetListExecutionsTool(mcpServer *server.MCPServer) {
 			return mcp.NewToolResultError(err.Error()), nil
 		}
 
-		// Get optional parameters
-		pageSize, _ := s.getMCPFieldValue("page_size", request)
-
-		// Log the parameters for debugging
-		fmt.Printf("GetListExecutions called with projectID: %s, jobName: %s, pageSize: %s\n",
-			projectID, jobName, pageSize)
-
-		// Call the service
-		executions, err := s.jobsService.GetListExecutions(projectID, jobName, pageSize)
+		// Get pagination, filtering and sorting parameters
+		listOptions, err := s.getListOptions(request)
 		if err != nil {
 			return mcp.NewToolResultError(err.Error()), nil
 		}
 
-		// Convert to JSON for output
-		result, err := json.MarshalIndent(executions, "", "  ")
+		// Call the service
+		executions, err := s.jobsService.GetListExecutions(ctx, projectID, jobName, listOptions)
 		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("Failed to format result: %v", err)), nil
+			return mcp.NewToolResultError(err.Error()), nil
 		}
 
-		return mcp.NewToolResultText(string(result)), nil
+		return newToolResultJSON(newListOutput(executions.Data, executions.NextPageToken, 0), ""), nil
 	})
 }
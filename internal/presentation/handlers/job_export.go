@@ -0,0 +1,92 @@
+package handlers
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/Nick1994209/cloudru-containerapps-mcp/internal/manifest"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// RegisterExportJobTool registers the export job tool with the MCP server
+func (s *MCPServer) RegisterExportJobTool(mcpServer *server.MCPServer) {
+	// Prepare tool options including description and fields
+	toolOptions := s.getMCPFieldsOptions(
+		"Export an existing Job from Cloud.ru as a cloudru.yaml manifest or a ready-to-run cloudru_create_job call. Server-managed fields (ids, status, timestamps) are stripped, so the result can be applied in another project",
+		"project_id",
+		"job_name",
+		"export_format",
+		"target_project_id",
+	)
+	toolOptions = append(toolOptions, outputSchema[exportOutput]())
+	exportTool := mcp.NewTool("cloudru_export_job", toolOptions...)
+
+	mcpServer.AddTool(exportTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		// Get project ID
+		projectID, err := s.getMCPFieldValue("project_id", request)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		// Get job name
+		jobName, err := s.getMCPFieldValue("job_name", request)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		format, targetProjectID, err := s.getExportOptions(request)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		// Call the service
+		raw, err := s.jobsService.GetJobRaw(ctx, projectID, jobName)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		m, warnings, err := manifest.FromJob(raw)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		output := exportOutput{Kind: m.Kind, Name: m.Name, Format: format, Warnings: warnings}
+		if format == exportFormatToolCall {
+			if targetProjectID == "" {
+				targetProjectID = projectID
+			}
+			createRequest := m.CreateJobRequest(targetProjectID)
+			output.ToolCall = &exportToolCall{
+				Name: "cloudru_create_job",
+				Arguments: map[string]string{
+					"project_id":                createRequest.ProjectID,
+					"job_name":                  createRequest.JobName,
+					"job_image":                 createRequest.JobImage,
+					"job_privileged":            strconv.FormatBool(createRequest.JobPrivileged),
+					"job_cpu":                   createRequest.JobCPU,
+					"job_memory":                createRequest.JobMemory,
+					"job_description":           createRequest.JobDescription,
+					"job_environment_variables": createRequest.JobEnvironmentVariables,
+					"job_command":               formatListFieldValue(createRequest.JobCommand),
+					"job_args":                  formatListFieldValue(createRequest.JobArgs),
+					"job_retry_count":           strconv.FormatUint(uint64(createRequest.JobRetryCount), 10),
+					"job_execution_timeout":     strconv.FormatUint(uint64(createRequest.JobExecutionTimeout), 10),
+					"job_run_immediately":       strconv.FormatBool(createRequest.JobRunImmediately),
+				},
+			}
+			// Settings which are not exported get the create tool defaults
+			if m.RetryCount == nil {
+				delete(output.ToolCall.Arguments, "job_retry_count")
+			}
+			if m.ExecutionTimeout == nil {
+				delete(output.ToolCall.Arguments, "job_execution_timeout")
+			}
+			if m.RunImmediately == nil {
+				delete(output.ToolCall.Arguments, "job_run_immediately")
+			}
+		}
+
+		return s.exportToolResult(output, m, raw, targetProjectID)
+	})
+}
@@ -2,8 +2,8 @@ package handlers
 
 import (
 	"context"
-	"encoding/json"
-	"fmt"
+
+	"github.com/Nick1994209/cloudru-containerapps-mcp/internal/domain"
 
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
@@ -17,6 +17,7 @@ func (s *MCPServer) RegisterGetJobTool(mcpServer *server.MCPServer) {
 		"project_id",
 		"job_name",
 	)
+	toolOptions = append(toolOptions, outputSchema[domain.Job]())
 	getJobTool := mcp.NewTool("cloudru_get_job", toolOptions...)
 
 	mcpServer.AddTool(getJobTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -33,17 +34,11 @@ func (s *MCPServer) RegisterGetJobTool(mcpServer *server.MCPServer) {
 		}
 
 		// Call the service
-		job, err := s.jobsService.GetJob(projectID, jobName)
+		job, err := s.jobsService.GetJob(ctx, projectID, jobName)
 		if err != nil {
 			return mcp.NewToolResultError(err.Error()), nil
 		}
 
-		// Convert to JSON for output
-		result, err := json.MarshalIndent(job, "", "  ")
-		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("Failed to format result: %v", err)), nil
-		}
-
-		return mcp.NewToolResultText(string(result)), nil
+		return newToolResultJSON(job, ""), nil
 	})
 }
@@ -2,10 +2,8 @@ package handlers
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"strconv"
-	"strings"
 
 	"github.com/Nick1994209/cloudru-containerapps-mcp/internal/domain"
 	"github.com/mark3labs/mcp-go/mcp"
@@ -22,14 +20,22 @@ func (s *MCPServer) RegisterPatchJobTool(mcpServer *server.MCPServer) {
 		"job_image",
 		"job_privileged",
 		"job_cpu",
+		"job_memory",
 		"job_description",
 		"job_environment_variables",
+		"env_set",
+		"env_unset",
+		"env_rename",
 		"job_command",
 		"job_args",
 		"job_retry_count",
 		"job_execution_timeout",
 		"job_run_immediately",
+		"wait",
+		"wait_timeout",
+		"dry_run",
 	)
+	toolOptions = append(toolOptions, outputSchema[operationOutput]())
 	patchJobTool := mcp.NewTool("cloudru_patch_job", toolOptions...)
 
 	mcpServer.AddTool(patchJobTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -63,6 +69,9 @@ func (s *MCPServer) RegisterPatchJobTool(mcpServer *server.MCPServer) {
 		// Get CPU
 		cpu, _ := s.getMCPFieldValue("job_cpu", request)
 
+		// Get memory
+		memory, _ := s.getMCPFieldValue("job_memory", request)
+
 		// Get description
 		description, _ := s.getMCPFieldValue("job_description", request)
 
@@ -70,27 +79,15 @@ func (s *MCPServer) RegisterPatchJobTool(mcpServer *server.MCPServer) {
 		environmentVariables, _ := s.getMCPFieldValue("job_environment_variables", request)
 
 		// Get command
-		commandStr, _ := s.getMCPFieldValue("job_command", request)
-		var command []string
-		if commandStr != "" {
-			// Split by comma
-			command = strings.Split(commandStr, ",")
-			// Trim spaces from each command
-			for i, cmd := range command {
-				command[i] = strings.TrimSpace(cmd)
-			}
+		command, err := s.getMCPListFieldValue("job_command", request)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
 		}
 
 		// Get args
-		argsStr, _ := s.getMCPFieldValue("job_args", request)
-		var args []string
-		if argsStr != "" {
-			// Split by comma
-			args = strings.Split(argsStr, ",")
-			// Trim spaces from each arg
-			for i, arg := range args {
-				args[i] = strings.TrimSpace(arg)
-			}
+		args, err := s.getMCPListFieldValue("job_args", request)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
 		}
 
 		// Get retry count
@@ -147,6 +144,12 @@ func (s *MCPServer) RegisterPatchJobTool(mcpServer *server.MCPServer) {
 				}
 				return nil
 			}(),
+			JobMemory: func() *string {
+				if checkRequestHasKey(request, "job_memory") {
+					return &memory
+				}
+				return nil
+			}(),
 			JobDescription: func() *string {
 				if checkRequestHasKey(request, "job_description") {
 					return &description
@@ -191,18 +194,33 @@ func (s *MCPServer) RegisterPatchJobTool(mcpServer *server.MCPServer) {
 			}(),
 		}
 
+		// Get incremental environment variable changes
+		patchRequest.JobEnvironmentVariableChanges, err = s.getEnvironmentVariableChanges(request)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		// Record the request instead of sending it if dry_run=true
+		ctx, dryRun, err := s.getDryRunContext(ctx, request)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
 		// Call the service
-		operation, err := s.jobsService.PatchJob(projectID, jobName, patchRequest)
+		operation, err := s.jobsService.PatchJob(ctx, projectID, jobName, patchRequest)
+		if result, ok := dryRunToolResult(dryRun, err, dryRunOperationOutput); ok {
+			return result, nil
+		}
 		if err != nil {
 			return mcp.NewToolResultError(err.Error()), nil
 		}
 
-		// Convert to JSON for output
-		result, err := json.MarshalIndent(operation, "", "  ")
+		// Wait for the operation to complete if requested
+		operation, err = s.waitOperationIfRequested(ctx, request, operation)
 		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("Failed to format result: %v", err)), nil
+			return mcp.NewToolResultError(operationErrorText(operation, err)), nil
 		}
 
-		return mcp.NewToolResultText(fmt.Sprintf("Successfully patched Job: %s\n%s", jobName, string(result))), nil
+		return newToolResultJSON(operation, fmt.Sprintf("Successfully patched Job: %s", jobName)), nil
 	})
 }
@@ -2,8 +2,8 @@ package handlers
 
 import (
 	"context"
-	"encoding/json"
-	"fmt"
+
+	"github.com/Nick1994209/cloudru-containerapps-mcp/internal/domain"
 
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
@@ -13,11 +13,18 @@ import (
 func (s *MCPServer) RegisterGetListJobsTool(mcpServer *server.MCPServer) {
 	// Prepare tool options including description and fields
 	toolOptions := s.getMCPFieldsOptions(
-		"Get paginated list of jobs from Cloud.ru. Project ID can be set via PROJECT_ID environment variable and obtained from console.cloud.ru",
+		"Get paginated list of jobs from Cloud.ru. By default returns a compact summary table, use view=full or cloudru_get_job for all fields. Pass nextPageToken from the response as page_token to get the next page. Project ID can be set via PROJECT_ID environment variable and obtained from console.cloud.ru",
 		"project_id",
 		"page_size",
+		"page_token",
+		"filter",
+		"order_by",
+		"fetch_all",
+		"view",
+		"limit",
 	)
 
+	toolOptions = append(toolOptions, listViewOutputSchema[jobSummary, domain.Job]())
 	getListJobsTool := mcp.NewTool("cloudru_jobs_list", toolOptions...)
 
 	mcpServer.AddTool(getListJobsTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -27,25 +34,31 @@ func (s *MCPServer) RegisterGetListJobsTool(mcpServer *server.MCPServer) {
 			return mcp.NewToolResultError(err.Error()), nil
 		}
 
-		// Get optional parameters
-		pageSize, _ := s.getMCPFieldValue("page_size", request)
+		// Get pagination, filtering and sorting parameters
+		listOptions, err := s.getListOptions(request)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
 
-		// Log the parameters for debugging
-		fmt.Printf("GetListJobs called with projectID: %s, pageSize: %s\n",
-			projectID, pageSize)
+		// Get output view and record limit
+		view, err := s.getListView(request)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		listOptions = view.pageOptions(listOptions)
 
 		// Call the service
-		jobs, err := s.jobsService.GetListJobs(projectID, pageSize)
+		jobs, err := s.jobsService.GetListJobs(ctx, projectID, listOptions)
 		if err != nil {
 			return mcp.NewToolResultError(err.Error()), nil
 		}
 
-		// Convert to JSON for output
-		result, err := json.MarshalIndent(jobs, "", "  ")
-		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("Failed to format result: %v", err)), nil
+		// Compact rows and table for the summary view
+		if view.view == viewSummary {
+			output := newListOutput(summarizeJobs(jobs.Data), jobs.NextPageToken, view.limit)
+			return mcp.NewToolResultStructured(output, jobsSummary(jobs, view.limit)), nil
 		}
 
-		return mcp.NewToolResultText(string(result)), nil
+		return newToolResultJSON(newListOutput(jobs.Data, jobs.NextPageToken, view.limit), ""), nil
 	})
 }
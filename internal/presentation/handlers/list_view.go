@@ -0,0 +1,163 @@
+package handlers
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"text/tabwriter"
+
+	"github.com/Nick1994209/cloudru-containerapps-mcp/internal/domain"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// List tool output views
+const (
+	viewSummary = "summary"
+	viewFull    = "full"
+)
+
+// listView holds the output view and the record limit of a list tool
+type listView struct {
+	view  string
+	limit int
+}
+
+// getListView gets the view and limit parameters of list tools
+func (s *MCPServer) getListView(request mcp.CallToolRequest) (listView, error) {
+	view, err := s.getMCPFieldValue("view", request)
+	if err != nil {
+		return listView{}, err
+	}
+	if view != viewSummary && view != viewFull {
+		return listView{}, fmt.Errorf("field view must be '%s' or '%s', got: %s", viewSummary, viewFull, view)
+	}
+
+	limitStr, err := s.getMCPFieldValue("limit", request)
+	if err != nil {
+		return listView{}, err
+	}
+	limit, err := strconv.Atoi(limitStr)
+	if err != nil || limit <= 0 {
+		return listView{}, fmt.Errorf("field limit must be a positive number, got: %s", limitStr)
+	}
+
+	return listView{view: view, limit: limit}, nil
+}
+
+// pageOptions caps the page size at the record limit, so the next page token does not skip records cut by the limit.
+// Fetch-all mode gets every page anyway and keeps the page size
+func (v listView) pageOptions(options domain.ListOptions) domain.ListOptions {
+	if options.FetchAll {
+		return options
+	}
+	if pageSize, err := strconv.Atoi(options.PageSize); options.PageSize == "" || (err == nil && pageSize > v.limit) {
+		options.PageSize = strconv.Itoa(v.limit)
+	}
+	return options
+}
+
+// formatSummaryTable formats rows as an aligned text table limited to the record limit
+func formatSummaryTable(header []string, rows [][]string, nextPageToken string, limit int) string {
+	var buffer bytes.Buffer
+	writer := tabwriter.NewWriter(&buffer, 0, 0, 2, ' ', 0)
+
+	writeRow := func(row []string) {
+		for i, cell := range row {
+			if cell == "" {
+				cell = "-"
+			}
+			if i > 0 {
+				fmt.Fprint(writer, "\t")
+			}
+			fmt.Fprint(writer, cell)
+		}
+		fmt.Fprintln(writer)
+	}
+
+	writeRow(header)
+	for i, row := range rows {
+		if i >= limit {
+			break
+		}
+		writeRow(row)
+	}
+	writer.Flush()
+
+	if len(rows) > limit {
+		fmt.Fprintf(&buffer, "\nShowing %d of %d records, increase limit to see more.\n", limit, len(rows))
+	}
+	if nextPageToken != "" {
+		fmt.Fprintf(&buffer, "\nMore records are available, pass page_token=%s to get the next page.\n", nextPageToken)
+	}
+	return buffer.String()
+}
+
+// containerAppSummary is a Container App in the summary view
+type containerAppSummary struct {
+	Name      string `json:"name"`
+	Status    string `json:"status"`
+	Image     string `json:"image"`
+	PublicURI string `json:"publicUri"`
+	Scaling   string `json:"scaling"`
+	UpdatedAt string `json:"updatedAt"`
+}
+
+// jobSummary is a Job in the summary view
+type jobSummary struct {
+	Name      string `json:"name"`
+	Status    string `json:"status"`
+	Image     string `json:"image"`
+	UpdatedAt string `json:"updatedAt"`
+}
+
+// summarizeContainerApps converts Container Apps to summary view rows
+func summarizeContainerApps(apps []domain.ContainerApp) []containerAppSummary {
+	summaries := make([]containerAppSummary, 0, len(apps))
+	for _, app := range apps {
+		image := ""
+		if len(app.Template.Containers) > 0 {
+			image = app.Template.Containers[0].Image
+		}
+		summaries = append(summaries, containerAppSummary{
+			Name:      app.Name,
+			Status:    app.Status,
+			Image:     image,
+			PublicURI: app.Configuration.Ingress.PublicUri,
+			Scaling:   fmt.Sprintf("%d-%d", app.Template.Scaling.MinInstanceCount, app.Template.Scaling.MaxInstanceCount),
+			UpdatedAt: app.UpdatedAt,
+		})
+	}
+	return summaries
+}
+
+// summarizeJobs converts Jobs to summary view rows
+func summarizeJobs(jobs []domain.Job) []jobSummary {
+	summaries := make([]jobSummary, 0, len(jobs))
+	for _, job := range jobs {
+		image := ""
+		if len(job.Template.Containers) > 0 {
+			image = job.Template.Containers[0].Image
+		}
+		summaries = append(summaries, jobSummary{Name: job.Name, Status: job.Status, Image: image, UpdatedAt: job.UpdatedAt})
+	}
+	return summaries
+}
+
+// containerAppsSummary formats Container Apps as a compact table
+func containerAppsSummary(page *domain.ContainerAppsPage, limit int) string {
+	rows := make([][]string, 0, len(page.Data))
+	for _, app := range summarizeContainerApps(page.Data) {
+		rows = append(rows, []string{app.Name, app.Status, app.Image, app.PublicURI, app.Scaling, app.UpdatedAt})
+	}
+	return formatSummaryTable([]string{"NAME", "STATUS", "IMAGE", "PUBLIC_URI", "SCALING", "UPDATED_AT"}, rows, page.NextPageToken, limit)
+}
+
+// jobsSummary formats Jobs as a compact table
+func jobsSummary(page *domain.JobsPage, limit int) string {
+	rows := make([][]string, 0, len(page.Data))
+	for _, job := range summarizeJobs(page.Data) {
+		rows = append(rows, []string{job.Name, job.Status, job.Image, job.UpdatedAt})
+	}
+	return formatSummaryTable([]string{"NAME", "STATUS", "IMAGE", "UPDATED_AT"}, rows, page.NextPageToken, limit)
+}
@@ -0,0 +1,113 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/Nick1994209/cloudru-containerapps-mcp/internal/domain"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContainerAppsSummary(t *testing.T) {
+	page := &domain.ContainerAppsPage{NextPageToken: "next"}
+	for _, name := range []string{"app-1", "app-2", "app-3"} {
+		var app domain.ContainerApp
+		app.Name = name
+		app.Status = "RUNNING"
+		app.Configuration.Ingress.PublicUri = "https://" + name + ".example.com"
+		app.Template.Scaling.MinInstanceCount = 0
+		app.Template.Scaling.MaxInstanceCount = 2
+		page.Data = append(page.Data, app)
+	}
+
+	summary := containerAppsSummary(page, 2)
+	lines := strings.Split(summary, "\n")
+
+	assert.Regexp(t, `^NAME\s+STATUS\s+IMAGE\s+PUBLIC_URI\s+SCALING\s+UPDATED_AT$`, lines[0])
+	assert.Regexp(t, `^app-1\s+RUNNING\s+-\s+https://app-1.example.com\s+0-2\s+-$`, lines[1])
+	assert.Contains(t, summary, "app-2")
+	assert.NotContains(t, summary, "app-3")
+	assert.Contains(t, summary, "Showing 2 of 3 records")
+	assert.Contains(t, summary, "page_token=next")
+}
+
+func TestNewListOutput(t *testing.T) {
+	output := newListOutput([]string{"a", "b", "c"}, "next", 2)
+
+	assert.Equal(t, []string{"a", "b"}, output.Data)
+	assert.Equal(t, 3, output.Total)
+	assert.True(t, output.Truncated)
+	assert.Equal(t, "next", output.NextPageToken)
+
+	empty := newListOutput[string](nil, "", 10)
+	assert.Equal(t, []string{}, empty.Data)
+	assert.False(t, empty.Truncated)
+}
+
+func TestListViewOutputSchema(t *testing.T) {
+	tool := mcp.NewTool("test_tool", listViewOutputSchema[containerAppSummary, domain.ContainerApp]())
+
+	var schema struct {
+		Properties struct {
+			Data struct {
+				Items struct {
+					AnyOf []struct {
+						Properties map[string]interface{} `json:"properties"`
+					} `json:"anyOf"`
+				} `json:"items"`
+			} `json:"data"`
+		} `json:"properties"`
+	}
+	require.NoError(t, json.Unmarshal(tool.RawOutputSchema, &schema))
+
+	require.Len(t, schema.Properties.Data.Items.AnyOf, 2)
+	assert.Contains(t, schema.Properties.Data.Items.AnyOf[0].Properties, "publicUri")
+	assert.NotContains(t, schema.Properties.Data.Items.AnyOf[0].Properties, "template")
+	assert.Contains(t, schema.Properties.Data.Items.AnyOf[1].Properties, "template")
+}
+
+func TestGetListContainerAppsTool_SummaryView(t *testing.T) {
+	t.Setenv("CLOUDRU_KEY_ID", "key-id")
+	t.Setenv("CLOUDRU_KEY_SECRET", "key-secret")
+
+	var app domain.ContainerApp
+	require.NoError(t, json.Unmarshal([]byte(`{"name": "app", "status": "RUNNING", "template": {"containers": [{"image": "nginx"}]}}`), &app))
+	s := NewMCPServer(nil, nil, &listContainerAppsService{page: &domain.ContainerAppsPage{Data: []domain.ContainerApp{app}}}, nil, nil, nil, nil)
+	mcpServer := server.NewMCPServer("test", "0.0.0", server.WithToolCapabilities(false))
+	s.RegisterGetListContainerAppsTool(mcpServer)
+
+	request := mcp.CallToolRequest{}
+	request.Params.Arguments = map[string]any{"project_id": "project"}
+	result, err := mcpServer.GetTool("cloudru_get_list_containerapps").Handler(context.Background(), request)
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	output, ok := result.StructuredContent.(listOutput[containerAppSummary])
+	require.True(t, ok)
+	assert.Equal(t, []containerAppSummary{{Name: "app", Status: "RUNNING", Image: "nginx", Scaling: "0-0"}}, output.Data)
+}
+
+// listContainerAppsService returns a fixed page of Container Apps
+type listContainerAppsService struct {
+	domain.ContainerAppsService
+	page *domain.ContainerAppsPage
+}
+
+func (f *listContainerAppsService) GetListContainerApps(ctx context.Context, projectID string, options domain.ListOptions) (*domain.ContainerAppsPage, error) {
+	return f.page, nil
+}
+
+func TestListViewPageOptions(t *testing.T) {
+	view := listView{view: viewSummary, limit: 50}
+
+	assert.Equal(t, "50", view.pageOptions(domain.ListOptions{PageSize: "100"}).PageSize)
+	assert.Equal(t, "50", view.pageOptions(domain.ListOptions{}).PageSize)
+	assert.Equal(t, "20", view.pageOptions(domain.ListOptions{PageSize: "20"}).PageSize)
+	// Fetch-all mode gets every page, so the page size is kept
+	assert.Equal(t, "100", view.pageOptions(domain.ListOptions{PageSize: "100", FetchAll: true}).PageSize)
+}
@@ -0,0 +1,187 @@
+package handlers
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/Nick1994209/cloudru-containerapps-mcp/internal/domain"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// Log tool output formats
+const (
+	logFormatJSON = "json"
+	logFormatText = "text"
+)
+
+// logsTextOutput is the structured output of log tools in the text format, the entries are only in the text content
+type logsTextOutput struct {
+	Total     int  `json:"total"`
+	Truncated bool `json:"truncated,omitempty"`
+	Partial   bool `json:"partial,omitempty"`
+}
+
+// logsTextResult returns the rendered log text with only the counters as structured content,
+// so clients do not receive every entry twice
+func logsTextResult(text string, output logsTextOutput) *mcp.CallToolResult {
+	return mcp.NewToolResultStructured(output, text)
+}
+
+// logsTextOutputOf returns the counters of application logs
+func logsTextOutputOf(logs *domain.ContainerAppLogs) logsTextOutput {
+	return logsTextOutput{Total: logs.Total, Truncated: logs.Truncated, Partial: logs.Partial}
+}
+
+// partialLogsNote explains that the API did not return entries of the whole requested window, empty if it did
+func partialLogsNote(logs *domain.ContainerAppLogs) string {
+	if !logs.Partial {
+		return ""
+	}
+	return fmt.Sprintf("The API returned entries since %s only, older entries of the requested window are not available. Filters are applied to the entries returned by the API.", logs.AvailableSince)
+}
+
+// getLogOptions gets the filter and limit parameters of log tools
+func (s *MCPServer) getLogOptions(request mcp.CallToolRequest) (domain.LogOptions, error) {
+	var options domain.LogOptions
+	fields := map[string]*string{
+		"since":          &options.Since,
+		"until":          &options.Until,
+		"level":          &options.Level,
+		"pod_name":       &options.PodName,
+		"version_id":     &options.VersionID,
+		"container_name": &options.ContainerName,
+		"search":         &options.Text,
+	}
+	for field, value := range fields {
+		fieldValue, err := s.getMCPFieldValue(field, request)
+		if err != nil {
+			return domain.LogOptions{}, err
+		}
+		*value = fieldValue
+	}
+
+	maxLines, err := s.getMaxLines(request)
+	if err != nil {
+		return domain.LogOptions{}, err
+	}
+	options.MaxLines = maxLines
+
+	return options, nil
+}
+
+// getLogFormat gets the output format of log tools
+func (s *MCPServer) getLogFormat(request mcp.CallToolRequest) (string, error) {
+	format, err := s.getMCPFieldValue("log_format", request)
+	if err != nil {
+		return "", err
+	}
+	if format != logFormatJSON && format != logFormatText {
+		return "", fmt.Errorf("field log_format must be '%s' or '%s', got: %s", logFormatJSON, logFormatText, format)
+	}
+	return format, nil
+}
+
+// formatLogsText formats log entries as <timestamp> <level> <pod>: <message> lines
+func formatLogsText(logs *domain.ContainerAppLogs) string {
+	var builder strings.Builder
+	if len(logs.Data) == 0 {
+		builder.WriteString("No log entries match the filters.\n")
+	}
+	for _, entry := range logs.Data {
+		builder.WriteString(formatLogLine(entry))
+	}
+	if logs.Truncated {
+		fmt.Fprintf(&builder, "\nShowing the latest %d of %d matching entries, increase max_lines or narrow the filters to see more.\n", len(logs.Data), logs.Total)
+	}
+	if note := partialLogsNote(logs); note != "" {
+		builder.WriteString("\n" + note + "\n")
+	}
+	return builder.String()
+}
+
+// formatLogLine formats a log entry as a <timestamp> <level> <pod>: <message> line
+func formatLogLine(entry domain.ContainerAppLogEntry) string {
+	return fmt.Sprintf("%s %s %s: %s\n", orDash(entry.Timestamp), orDash(entry.Level), orDash(entry.PodName), strings.TrimRight(entry.Message, "\n"))
+}
+
+// getSystemLogOptions gets the filter and limit parameters of the system logs tool
+func (s *MCPServer) getSystemLogOptions(request mcp.CallToolRequest) (domain.SystemLogOptions, error) {
+	var options domain.SystemLogOptions
+	fields := map[string]*string{
+		"event_type":    &options.EventType,
+		"component":     &options.Component,
+		"revision_name": &options.RevisionName,
+	}
+	for field, value := range fields {
+		fieldValue, err := s.getMCPFieldValue(field, request)
+		if err != nil {
+			return domain.SystemLogOptions{}, err
+		}
+		*value = fieldValue
+	}
+
+	maxLines, err := s.getMaxLines(request)
+	if err != nil {
+		return domain.SystemLogOptions{}, err
+	}
+	options.MaxLines = maxLines
+
+	return options, nil
+}
+
+// getMaxLines gets the max_lines parameter of log tools
+func (s *MCPServer) getMaxLines(request mcp.CallToolRequest) (int, error) {
+	maxLinesStr, err := s.getMCPFieldValue("max_lines", request)
+	if err != nil {
+		return 0, err
+	}
+	maxLines, err := strconv.Atoi(maxLinesStr)
+	if err != nil || maxLines <= 0 {
+		return 0, fmt.Errorf("field max_lines must be a positive number, got: %s", maxLinesStr)
+	}
+	return maxLines, nil
+}
+
+// systemLogsDiagnosis summarises the findings of system logs in a few lines
+func systemLogsDiagnosis(logs *domain.ContainerAppSystemLogs) string {
+	if len(logs.Findings) == 0 {
+		return fmt.Sprintf("No scheduling, image pull or OOM failures found in %d system events.", logs.Total)
+	}
+
+	var builder strings.Builder
+	fmt.Fprintf(&builder, "Found %d failure type(s) in %d system events:", len(logs.Findings), logs.Total)
+	for _, finding := range logs.Findings {
+		fmt.Fprintf(&builder, "\n- %s: %d event(s), latest %s", finding.Category, finding.Count, orDash(finding.Reason))
+		if finding.RevisionName != "" {
+			fmt.Fprintf(&builder, " in revision %s", finding.RevisionName)
+		}
+		fmt.Fprintf(&builder, ": %s. %s", strings.TrimRight(finding.Message, ".\n"), finding.Hint)
+	}
+	return builder.String()
+}
+
+// formatSystemLogsText formats the diagnosis and system events as <timestamp> <event type> <component> <revision> <reason>: <message> lines
+func formatSystemLogsText(logs *domain.ContainerAppSystemLogs) string {
+	var builder strings.Builder
+	builder.WriteString(systemLogsDiagnosis(logs) + "\n")
+	if len(logs.Data) > 0 {
+		builder.WriteString("\n")
+	}
+	for _, entry := range logs.Data {
+		fmt.Fprintf(&builder, "%s %s %s %s %s: %s\n", orDash(entry.Timestamp), orDash(entry.EventType), orDash(entry.Component), orDash(entry.RevisionName), orDash(entry.Reason), strings.TrimRight(entry.Message, "\n"))
+	}
+	if logs.Truncated {
+		fmt.Fprintf(&builder, "\nShowing the latest %d of %d matching events, increase max_lines or narrow the filters to see more.\n", len(logs.Data), logs.Total)
+	}
+	return builder.String()
+}
+
+// orDash replaces an empty value with a dash, so text columns stay aligned
+func orDash(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
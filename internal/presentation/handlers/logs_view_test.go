@@ -0,0 +1,41 @@
+package handlers
+
+import (
+	"testing"
+
+	"github.com/Nick1994209/cloudru-containerapps-mcp/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatLogsText(t *testing.T) {
+	logs := &domain.ContainerAppLogs{
+		Data: []domain.ContainerAppLogEntry{
+			{Timestamp: "2024-05-01T11:58:00Z", Level: "error", PodName: "app-v2-abc", Message: "panic: connection refused\n"},
+			{Timestamp: "2024-05-01T11:59:00Z", Message: "restarting"},
+		},
+		Total:     5,
+		Truncated: true,
+	}
+
+	assert.Equal(t, "2024-05-01T11:58:00Z error app-v2-abc: panic: connection refused\n"+
+		"2024-05-01T11:59:00Z - -: restarting\n"+
+		"\nShowing the latest 2 of 5 matching entries, increase max_lines or narrow the filters to see more.\n",
+		formatLogsText(logs))
+
+	assert.Equal(t, "No log entries match the filters.\n", formatLogsText(&domain.ContainerAppLogs{}))
+	assert.Contains(t, formatLogsText(&domain.ContainerAppLogs{Partial: true, AvailableSince: "2024-05-01T11:00:00Z"}),
+		"The API returned entries since 2024-05-01T11:00:00Z only")
+}
+
+func TestSystemLogsDiagnosis(t *testing.T) {
+	logs := &domain.ContainerAppSystemLogs{
+		Total: 4,
+		Findings: []domain.SystemLogFinding{
+			{Category: domain.SystemLogFindingOOM, Count: 2, Reason: "OOMKilled", Message: "Container app was OOMKilled.", RevisionName: "app-v2", Hint: "Increase memory"},
+		},
+	}
+
+	assert.Equal(t, "Found 1 failure type(s) in 4 system events:\n- oom: 2 event(s), latest OOMKilled in revision app-v2: Container app was OOMKilled. Increase memory",
+		systemLogsDiagnosis(logs))
+	assert.Equal(t, "No scheduling, image pull or OOM failures found in 0 system events.\n", formatSystemLogsText(&domain.ContainerAppSystemLogs{}))
+}
@@ -0,0 +1,111 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Nick1994209/cloudru-containerapps-mcp/internal/domain"
+	"github.com/Nick1994209/cloudru-containerapps-mcp/internal/manifest"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// Apply tool actions
+const (
+	applyActionCreated   = "created"
+	applyActionPatched   = "patched"
+	applyActionUnchanged = "unchanged"
+)
+
+// RegisterApplyTool registers the manifest apply tool with the MCP server
+func (s *MCPServer) RegisterApplyTool(mcpServer *server.MCPServer) {
+	// Prepare tool options including description and fields
+	toolOptions := s.getMCPFieldsOptions(
+		"Create or patch a Container App or a Job in Cloud.ru to match a cloudru.yaml manifest. Fields which are not declared in the manifest are left as is. With dry_run=true return the create or patch request without calling the API. manifest_path must be a relative path inside the working directory of the server",
+		"manifest_path",
+		"project_id",
+		"wait",
+		"wait_timeout",
+		"dry_run",
+	)
+	toolOptions = append(toolOptions, outputSchema[manifestApplyOutput]())
+	applyTool := mcp.NewTool("cloudru_apply", toolOptions...)
+	// The project can be declared in the manifest
+	optionalFields(&applyTool, "project_id")
+
+	mcpServer.AddTool(applyTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		// Load the manifest
+		m, projectID, err := s.loadManifest(request)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		// Compare with the live resource
+		exists, changes, err := s.diffManifest(ctx, m, projectID)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		output := manifestApplyOutput{
+			Kind:      m.Kind,
+			Name:      m.Name,
+			ProjectID: projectID,
+			Action:    applyActionUnchanged,
+			Changes:   changes,
+		}
+		if exists && len(changes) == 0 {
+			return newToolResultJSON(output, fmt.Sprintf("%s %s is up to date with the manifest", m.Kind, m.Name)), nil
+		}
+
+		// Record the request instead of sending it if dry_run=true
+		ctx, dryRun, err := s.getDryRunContext(ctx, request)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		// Create or patch the resource
+		var operation *domain.Operation
+		output.Action, operation, err = s.applyManifest(ctx, m, projectID, exists)
+		if result, ok := dryRunToolResult(dryRun, err, func(dryRunOutput *dryRunOutput) interface{} {
+			output.DryRun = dryRunOutput
+			return output
+		}); ok {
+			return result, nil
+		}
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		// Wait for the operation to complete if requested
+		operation, err = s.waitOperationIfRequested(ctx, request, operation)
+		if err != nil {
+			return mcp.NewToolResultError(operationErrorText(operation, err)), nil
+		}
+		output.Operation = operation
+
+		return newToolResultJSON(output, fmt.Sprintf("Successfully %s %s %s", output.Action, m.Kind, m.Name)), nil
+	})
+}
+
+// applyManifest creates the Container App or Job from the manifest or patches the declared fields of the existing one
+func (s *MCPServer) applyManifest(ctx context.Context, m *manifest.Manifest, projectID string, exists bool) (string, *domain.Operation, error) {
+	if m.Kind == manifest.KindJob {
+		if exists {
+			operation, err := s.jobsService.PatchJob(ctx, projectID, m.Name, m.PatchJobRequest(projectID))
+			return applyActionPatched, operation, err
+		}
+		operation, err := s.jobsService.CreateJob(ctx, m.CreateJobRequest(projectID))
+		return applyActionCreated, operation, err
+	}
+
+	if exists {
+		operation, err := s.containerAppsService.PatchContainerApp(ctx, projectID, m.Name, m.PatchContainerAppRequest(projectID))
+		return applyActionPatched, operation, err
+	}
+	createRequest, err := m.CreateContainerAppRequest(projectID)
+	if err != nil {
+		return "", nil, err
+	}
+	operation, err := s.containerAppsService.CreateContainerApp(ctx, createRequest)
+	return applyActionCreated, operation, err
+}
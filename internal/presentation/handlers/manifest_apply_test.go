@@ -0,0 +1,105 @@
+package handlers
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// chdirTemp changes the working directory to a temporary directory for the test
+func chdirTemp(t *testing.T) {
+	dir, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(t.TempDir()))
+	t.Cleanup(func() { _ = os.Chdir(dir) })
+}
+
+func callApplyTool(t *testing.T, containerApps *deployContainerAppsService, manifestYAML string, arguments ...map[string]any) *mcp.CallToolResult {
+	t.Setenv("CLOUDRU_KEY_ID", "key-id")
+	t.Setenv("CLOUDRU_KEY_SECRET", "key-secret")
+
+	chdirTemp(t)
+	manifestPath := "cloudru.yaml"
+	require.NoError(t, os.WriteFile(manifestPath, []byte(manifestYAML), 0o600))
+
+	s := NewMCPServer(nil, nil, containerApps, nil, nil, &doneOperationsService{}, nil)
+	mcpServer := server.NewMCPServer("test", "0.0.0", server.WithToolCapabilities(false))
+	s.RegisterApplyTool(mcpServer)
+
+	tool := mcpServer.GetTool("cloudru_apply")
+	require.NotNil(t, tool)
+
+	request := mcp.CallToolRequest{}
+	request.Params.Name = "cloudru_apply"
+	request.Params.Arguments = map[string]any{"manifest_path": manifestPath}
+	for _, extra := range arguments {
+		for key, value := range extra {
+			request.Params.Arguments.(map[string]any)[key] = value
+		}
+	}
+	result, err := tool.Handler(context.Background(), request)
+	require.NoError(t, err)
+	return result
+}
+
+func TestApplyTool_CreatesContainerAppFromManifest(t *testing.T) {
+	containerApps := &deployContainerAppsService{}
+
+	result := callApplyTool(t, containerApps, "name: my-app\nprojectId: project-1\nimage: app:v1\nport: 8080\n")
+
+	require.False(t, result.IsError, "%v", result.Content)
+	require.NotNil(t, containerApps.created)
+	assert.Equal(t, "project-1", containerApps.created.ProjectID)
+	assert.Equal(t, "app:v1", containerApps.created.ContainerAppImage)
+
+	output, ok := result.StructuredContent.(manifestApplyOutput)
+	require.True(t, ok)
+	assert.Equal(t, applyActionCreated, output.Action)
+}
+
+func TestApplyTool_PatchesOnlyChangedContainerApp(t *testing.T) {
+	containerApps := &deployContainerAppsService{exists: true}
+
+	result := callApplyTool(t, containerApps, "name: my-app\nprojectId: project-1\nimage: app:v2\n")
+
+	require.False(t, result.IsError, "%v", result.Content)
+	require.NotNil(t, containerApps.patched)
+	assert.Equal(t, "app:v2", *containerApps.patched.ContainerAppImage)
+	assert.Nil(t, containerApps.patched.ContainerAppPort)
+
+	output, ok := result.StructuredContent.(manifestApplyOutput)
+	require.True(t, ok)
+	assert.Equal(t, applyActionPatched, output.Action)
+	require.Len(t, output.Changes, 1)
+	assert.Equal(t, "image", output.Changes[0].Field)
+}
+
+func TestApplyTool_DryRunDoesNotPatch(t *testing.T) {
+	containerApps := &deployContainerAppsService{exists: true}
+
+	result := callApplyTool(t, containerApps, "name: my-app\nprojectId: project-1\nimage: app:v2\n", map[string]any{"dry_run": "true"})
+
+	require.False(t, result.IsError, "%v", result.Content)
+	assert.Nil(t, containerApps.patched)
+
+	output, ok := result.StructuredContent.(manifestApplyOutput)
+	require.True(t, ok)
+	assert.Equal(t, applyActionPatched, output.Action)
+	require.NotNil(t, output.DryRun)
+	assert.Equal(t, "PATCH", output.DryRun.Method)
+	assert.Nil(t, output.Operation)
+}
+
+func TestApplyTool_RejectsManifestPathOutsideWorkingDirectory(t *testing.T) {
+	for _, manifestPath := range []string{"/etc/passwd", "../cloudru.yaml", "manifests/../../cloudru.yaml"} {
+		result := callApplyTool(t, &deployContainerAppsService{}, "name: my-app\n", map[string]any{"manifest_path": manifestPath})
+
+		require.True(t, result.IsError, manifestPath)
+		assert.Contains(t, result.Content[0].(mcp.TextContent).Text, "must be a relative path inside the working directory", manifestPath)
+	}
+}
@@ -0,0 +1,100 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+
+	"github.com/Nick1994209/cloudru-containerapps-mcp/internal/domain"
+	"github.com/Nick1994209/cloudru-containerapps-mcp/internal/manifest"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// RegisterDiffTool registers the manifest diff tool with the MCP server
+func (s *MCPServer) RegisterDiffTool(mcpServer *server.MCPServer) {
+	// Prepare tool options including description and fields
+	toolOptions := s.getMCPFieldsOptions(
+		"Show field-level differences between a cloudru.yaml manifest and the live Container App or Job in Cloud.ru. Only fields declared in the manifest are compared. manifest_path must be a relative path inside the working directory of the server",
+		"manifest_path",
+		"project_id",
+	)
+	toolOptions = append(toolOptions, outputSchema[manifestDiffOutput]())
+	diffTool := mcp.NewTool("cloudru_diff", toolOptions...)
+	// The project can be declared in the manifest
+	optionalFields(&diffTool, "project_id")
+
+	mcpServer.AddTool(diffTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		// Load the manifest
+		m, projectID, err := s.loadManifest(request)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		// Compare with the live resource
+		exists, changes, err := s.diffManifest(ctx, m, projectID)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		output := manifestDiffOutput{
+			Kind:      m.Kind,
+			Name:      m.Name,
+			ProjectID: projectID,
+			Exists:    exists,
+			Changes:   changes,
+		}
+		message := fmt.Sprintf("%s %s is up to date with the manifest", m.Kind, m.Name)
+		if !exists {
+			message = fmt.Sprintf("%s %s does not exist and will be created", m.Kind, m.Name)
+		} else if len(changes) > 0 {
+			message = fmt.Sprintf("%s %s differs from the manifest in %d fields", m.Kind, m.Name, len(changes))
+		}
+		return newToolResultJSON(output, message), nil
+	})
+}
+
+// loadManifest loads the manifest from manifest_path inside the working directory and resolves the project ID:
+// the project_id field takes precedence over the manifest and CLOUDRU_PROJECT_ID
+func (s *MCPServer) loadManifest(request mcp.CallToolRequest) (*manifest.Manifest, string, error) {
+	manifestPath, err := s.getMCPFieldValue("manifest_path", request)
+	if err != nil {
+		return nil, "", err
+	}
+	// Clients of the sse and http transports must not read other files of the server
+	if !filepath.IsLocal(manifestPath) {
+		return nil, "", fmt.Errorf("field manifest_path must be a relative path inside the working directory of the server, got: %s", manifestPath)
+	}
+
+	m, err := manifest.Load(manifestPath)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if !checkRequestHasKey(request, "project_id") && m.ProjectID != "" {
+		return m, m.ProjectID, nil
+	}
+	projectID, err := s.getMCPFieldValue("project_id", request)
+	if err != nil {
+		return nil, "", err
+	}
+	return m, projectID, nil
+}
+
+// diffManifest compares the manifest with the live Container App or Job, a missing resource is not an error
+func (s *MCPServer) diffManifest(ctx context.Context, m *manifest.Manifest, projectID string) (bool, []manifest.Change, error) {
+	if m.Kind == manifest.KindJob {
+		job, err := s.jobsService.GetJob(ctx, projectID, m.Name)
+		if err != nil && !errors.Is(err, domain.ErrNotFound) {
+			return false, nil, err
+		}
+		return job != nil, m.DiffJob(job), nil
+	}
+
+	containerApp, err := s.containerAppsService.GetContainerApp(ctx, projectID, m.Name)
+	if err != nil && !errors.Is(err, domain.ErrNotFound) {
+		return false, nil, err
+	}
+	return containerApp != nil, m.DiffContainerApp(containerApp), nil
+}
@@ -0,0 +1,114 @@
+package handlers
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+
+	"github.com/Nick1994209/cloudru-containerapps-mcp/internal/domain"
+)
+
+// secretMask replaces values of secret environment variables in tool outputs
+const secretMask = domain.EnvironmentVariableMask
+
+// minEmbeddedSecretLength is the shortest secret value which is masked inside longer strings (manifests, diffs, messages),
+// shorter values are masked only where they are the whole value, so unrelated text is not mangled
+const minEmbeddedSecretLength = 6
+
+// maskSecrets masks values of environment variables with the secret type at any depth of data.
+// The found values are also masked inside other strings, e.g. exported manifests and diffs, and are returned to mask the text output.
+// data is returned as is when it has no secret variables
+func maskSecrets(data interface{}) (interface{}, []string) {
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		return data, nil
+	}
+	var object interface{}
+	if err := json.Unmarshal(encoded, &object); err != nil {
+		return data, nil
+	}
+
+	secrets := secretValues(object)
+	if len(secrets) == 0 {
+		return data, nil
+	}
+	return maskValue(object, secrets), secrets
+}
+
+// secretValuesOf returns values of secret environment variables of a raw JSON object
+func secretValuesOf(raw []byte) []string {
+	var object interface{}
+	if len(raw) == 0 || json.Unmarshal(raw, &object) != nil {
+		return nil
+	}
+	return secretValues(object)
+}
+
+// secretValues returns values of {"name", "value", "type": "secret"} objects, longest first, so longer values are masked before their parts
+func secretValues(object interface{}) []string {
+	secrets := collectSecretValues(object, nil)
+	sort.Slice(secrets, func(i, j int) bool { return len(secrets[i]) > len(secrets[j]) })
+	return secrets
+}
+
+func collectSecretValues(value interface{}, secrets []string) []string {
+	switch typed := value.(type) {
+	case map[string]interface{}:
+		if isSecretVariable(typed) {
+			if secret, _ := typed["value"].(string); secret != "" && secret != secretMask {
+				secrets = append(secrets, secret)
+			}
+		}
+		for _, item := range typed {
+			secrets = collectSecretValues(item, secrets)
+		}
+	case []interface{}:
+		for _, item := range typed {
+			secrets = collectSecretValues(item, secrets)
+		}
+	}
+	return secrets
+}
+
+func isSecretVariable(object map[string]interface{}) bool {
+	variableType, _ := object["type"].(string)
+	_, hasValue := object["value"]
+	return variableType == domain.EnvironmentVariableTypeSecret && hasValue
+}
+
+// maskValue replaces secret values in a generic JSON value
+func maskValue(value interface{}, secrets []string) interface{} {
+	switch typed := value.(type) {
+	case map[string]interface{}:
+		if isSecretVariable(typed) {
+			typed["value"] = secretMask
+		}
+		for key, item := range typed {
+			typed[key] = maskValue(item, secrets)
+		}
+	case []interface{}:
+		for i, item := range typed {
+			typed[i] = maskValue(item, secrets)
+		}
+	case string:
+		return maskText(typed, secrets)
+	}
+	return value
+}
+
+// maskText replaces secret values in a text. Short values are replaced only as the whole text or as a quoted JSON string
+func maskText(text string, secrets []string) string {
+	for _, secret := range secrets {
+		if text == secret {
+			return secretMask
+		}
+		if len(secret) >= minEmbeddedSecretLength {
+			text = strings.ReplaceAll(text, secret, secretMask)
+			continue
+		}
+		if quoted, err := json.Marshal(secret); err == nil {
+			text = strings.ReplaceAll(text, string(quoted), `"`+secretMask+`"`)
+		}
+	}
+	return text
+}
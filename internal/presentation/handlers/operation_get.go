@@ -0,0 +1,37 @@
+package handlers
+
+import (
+	"context"
+
+	"github.com/Nick1994209/cloudru-containerapps-mcp/internal/domain"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// RegisterGetOperationTool registers the get operation tool with the MCP server
+func (s *MCPServer) RegisterGetOperationTool(mcpServer *server.MCPServer) {
+	// Prepare tool options including description and fields
+	toolOptions := s.getMCPFieldsOptions(
+		"Get the current state of a long-running operation in Cloud.ru (returned by create, patch, delete, start and stop functions)",
+		"operation_id",
+	)
+	toolOptions = append(toolOptions, outputSchema[domain.Operation]())
+	getOperationTool := mcp.NewTool("cloudru_get_operation", toolOptions...)
+
+	mcpServer.AddTool(getOperationTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		// Get operation ID
+		operationID, err := s.getMCPFieldValue("operation_id", request)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		// Call the service
+		operation, err := s.operationsService.GetOperation(ctx, operationID)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		return newToolResultJSON(operation, ""), nil
+	})
+}
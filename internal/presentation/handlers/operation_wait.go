@@ -0,0 +1,111 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/Nick1994209/cloudru-containerapps-mcp/internal/domain"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// RegisterWaitOperationTool registers the wait operation tool with the MCP server
+func (s *MCPServer) RegisterWaitOperationTool(mcpServer *server.MCPServer) {
+	// Prepare tool options including description and fields
+	toolOptions := s.getMCPFieldsOptions(
+		"Wait until a long-running operation in Cloud.ru is done and return its final state or error",
+		"operation_id",
+		"wait_timeout",
+	)
+	toolOptions = append(toolOptions, outputSchema[domain.Operation]())
+	waitOperationTool := mcp.NewTool("cloudru_wait_operation", toolOptions...)
+
+	mcpServer.AddTool(waitOperationTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		// Get operation ID
+		operationID, err := s.getMCPFieldValue("operation_id", request)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		// Get wait timeout
+		timeout, err := s.getMCPDurationFieldValue("wait_timeout", request)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		// Call the service
+		operation, err := s.operationsService.WaitOperation(ctx, operationID, timeout)
+		if err != nil {
+			return mcp.NewToolResultError(operationErrorText(operation, err)), nil
+		}
+
+		return newToolResultJSON(operation, ""), nil
+	})
+}
+
+// waitOperationIfRequested waits for the operation to complete when the request has wait=true,
+// otherwise the operation is returned as is
+func (s *MCPServer) waitOperationIfRequested(ctx context.Context, request mcp.CallToolRequest, operation *domain.Operation) (*domain.Operation, error) {
+	wait, err := s.getMCPBooleanFieldValue("wait", request)
+	if err != nil {
+		return nil, err
+	}
+	if !wait || operation == nil {
+		return operation, nil
+	}
+
+	timeout, err := s.getMCPDurationFieldValue("wait_timeout", request)
+	if err != nil {
+		return operation, err
+	}
+
+	return s.waitOperation(ctx, operation, timeout)
+}
+
+// waitOperation waits for the operation to complete, a failed operation is returned together with an error
+func (s *MCPServer) waitOperation(ctx context.Context, operation *domain.Operation, timeout time.Duration) (*domain.Operation, error) {
+	if operation.Done {
+		if operation.Error != nil {
+			return operation, fmt.Errorf("operation %s failed: %s", operation.ID, operation.Error.Message)
+		}
+		return operation, nil
+	}
+	if operation.ID == "" {
+		return operation, fmt.Errorf("cannot wait for the operation: API response has no operation id")
+	}
+
+	finalOperation, err := s.operationsService.WaitOperation(ctx, operation.ID, timeout)
+	if finalOperation == nil {
+		finalOperation = operation
+	}
+	return finalOperation, err
+}
+
+// operationErrorText formats a failed or unfinished operation together with the error
+func operationErrorText(operation *domain.Operation, err error) string {
+	if operation == nil {
+		return err.Error()
+	}
+	result, marshalErr := json.MarshalIndent(operation, "", "  ")
+	if marshalErr != nil {
+		return err.Error()
+	}
+	return fmt.Sprintf("%s\nLast known operation state:\n%s", err.Error(), string(result))
+}
+
+// getMCPDurationFieldValue gets a field value as a Go duration, e.g. 90s or 10m
+func (s *MCPServer) getMCPDurationFieldValue(field string, request mcp.CallToolRequest) (time.Duration, error) {
+	fieldValueStr, err := s.getMCPFieldValue(field, request)
+	if err != nil {
+		return 0, err
+	}
+
+	duration, err := time.ParseDuration(fieldValueStr)
+	if err != nil || duration <= 0 {
+		return 0, fmt.Errorf("field %s must be a positive duration like 90s or 10m, got: %s", field, fieldValueStr)
+	}
+	return duration, nil
+}
@@ -0,0 +1,239 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/Nick1994209/cloudru-containerapps-mcp/internal/domain"
+	"github.com/Nick1994209/cloudru-containerapps-mcp/internal/manifest"
+
+	"github.com/invopop/jsonschema"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// descriptionOutput is the structured output of the description tool
+type descriptionOutput struct {
+	Description string `json:"description"`
+}
+
+// dockerLoginOutput is the structured output of the docker login tool
+type dockerLoginOutput struct {
+	Registry string `json:"registry"`
+}
+
+// dockerBuildAndPushOutput is the structured output of the docker build and push tool.
+// Image is set when the image was pushed, commands are set when show_commands is true
+type dockerBuildAndPushOutput struct {
+	Image        string `json:"image,omitempty"`
+	BuildCommand string `json:"buildCommand,omitempty"`
+	PushCommand  string `json:"pushCommand,omitempty"`
+}
+
+// deployOutput is the structured output of the deploy tool, DryRun is set instead of the status fields in dry-run mode
+type deployOutput struct {
+	Image     string            `json:"image"`
+	Action    string            `json:"action"`
+	Status    string            `json:"status"`
+	PublicURI string            `json:"publicUri,omitempty"`
+	Operation *domain.Operation `json:"operation,omitempty"`
+	DryRun    *deployPlanOutput `json:"dryRun,omitempty"`
+}
+
+// deployPlanOutput describes the steps the deploy tool would run in dry-run mode, Create is set when the Container App does not exist
+type deployPlanOutput struct {
+	BuildCommand string                            `json:"buildCommand"`
+	PushCommand  string                            `json:"pushCommand"`
+	Create       *domain.CreateContainerAppRequest `json:"create,omitempty"`
+}
+
+// manifestDiffOutput is the structured output of the manifest diff tool
+type manifestDiffOutput struct {
+	Kind      string            `json:"kind"`
+	Name      string            `json:"name"`
+	ProjectID string            `json:"projectId"`
+	Exists    bool              `json:"exists"`
+	Changes   []manifest.Change `json:"changes"`
+}
+
+// manifestApplyOutput is the structured output of the manifest apply tool, DryRun is set instead of the operation in dry-run mode
+type manifestApplyOutput struct {
+	Kind      string            `json:"kind"`
+	Name      string            `json:"name"`
+	ProjectID string            `json:"projectId"`
+	Action    string            `json:"action"`
+	Changes   []manifest.Change `json:"changes"`
+	Operation *domain.Operation `json:"operation,omitempty"`
+	DryRun    *dryRunOutput     `json:"dryRun,omitempty"`
+}
+
+// exportOutput is the structured output of the export tools.
+// Manifest is set for the manifest format and ToolCall for the tool_call format, Object is the raw object without server-managed fields
+type exportOutput struct {
+	Kind     string          `json:"kind"`
+	Name     string          `json:"name"`
+	Format   string          `json:"format"`
+	Manifest string          `json:"manifest,omitempty"`
+	ToolCall *exportToolCall `json:"toolCall,omitempty"`
+	Object   json.RawMessage `json:"object"`
+	Warnings []string        `json:"warnings,omitempty"`
+}
+
+// exportToolCall is a create tool call which recreates the exported resource
+type exportToolCall struct {
+	Name      string            `json:"name"`
+	Arguments map[string]string `json:"arguments"`
+}
+
+// dockerRegistriesOutput is the structured output of the list docker registries tool
+type dockerRegistriesOutput struct {
+	Data []domain.DockerRegistry `json:"data"`
+}
+
+// secretsOutput is the structured output of the list secrets tool
+type secretsOutput struct {
+	Data []domain.Secret `json:"data"`
+}
+
+// listOutput is the structured output of paginated list tools
+type listOutput[T any] struct {
+	Data          []T    `json:"data"`
+	Total         int    `json:"total"`
+	Truncated     bool   `json:"truncated,omitempty"`
+	NextPageToken string `json:"nextPageToken,omitempty"`
+}
+
+// newListOutput creates a listOutput with at most limit records
+func newListOutput[T any](data []T, nextPageToken string, limit int) listOutput[T] {
+	output := listOutput[T]{
+		Data:          data,
+		Total:         len(data),
+		NextPageToken: nextPageToken,
+	}
+	if limit > 0 && len(data) > limit {
+		output.Data = data[:limit]
+		output.Truncated = true
+	}
+	if output.Data == nil {
+		output.Data = []T{}
+	}
+	return output
+}
+
+// outputSchema declares the JSON schema of T as the tool output schema.
+// Arrays and nested objects are nullable, because nil slices and pointers are marshalled as null
+func outputSchema[T any]() mcp.ToolOption {
+	schemaMap, err := reflectOutputSchema[T]()
+	if err != nil {
+		return func(*mcp.Tool) {}
+	}
+	return rawOutputSchema(schemaMap)
+}
+
+// listViewOutputSchema declares the output schema of list tools with summary and full views:
+// records are summary rows S in the summary view and full objects F in the full view
+func listViewOutputSchema[S any, F any]() mcp.ToolOption {
+	summarySchema, err := reflectOutputSchema[listOutput[S]]()
+	if err != nil {
+		return func(*mcp.Tool) {}
+	}
+	fullSchema, err := reflectOutputSchema[listOutput[F]]()
+	if err != nil {
+		return func(*mcp.Tool) {}
+	}
+
+	summaryData, summaryOK := schemaProperty(summarySchema, "data")
+	fullData, fullOK := schemaProperty(fullSchema, "data")
+	if !summaryOK || !fullOK {
+		return func(*mcp.Tool) {}
+	}
+	summaryData["items"] = map[string]interface{}{
+		"anyOf": []interface{}{summaryData["items"], fullData["items"]},
+	}
+	return rawOutputSchema(summarySchema)
+}
+
+// schemaProperty returns the schema of an object property
+func schemaProperty(schema map[string]interface{}, name string) (map[string]interface{}, bool) {
+	properties, ok := schema["properties"].(map[string]interface{})
+	if !ok {
+		return nil, false
+	}
+	property, ok := properties[name].(map[string]interface{})
+	return property, ok
+}
+
+// reflectOutputSchema reflects the JSON schema of T as a generic map
+func reflectOutputSchema[T any]() (map[string]interface{}, error) {
+	reflector := jsonschema.Reflector{
+		// Fields are optional, because dry-run outputs carry only the dryRun field
+		RequiredFromJSONSchemaTags: true,
+		DoNotReference:             true,
+		Anonymous:                  true,
+		AllowAdditionalProperties:  true,
+	}
+	var zero T
+	schema := reflector.Reflect(zero)
+	schema.Version = ""
+
+	rawSchema, err := json.Marshal(schema)
+	if err != nil {
+		return nil, err
+	}
+	var schemaMap map[string]interface{}
+	if err := json.Unmarshal(rawSchema, &schemaMap); err != nil {
+		return nil, err
+	}
+
+	// Structured content must be an object according to the MCP specification
+	schemaMap["type"] = "object"
+	if properties, ok := schemaMap["properties"].(map[string]interface{}); ok {
+		for _, property := range properties {
+			makeSchemaNullable(property)
+		}
+	}
+	return schemaMap, nil
+}
+
+// rawOutputSchema declares a generic schema map as the tool output schema
+func rawOutputSchema(schemaMap map[string]interface{}) mcp.ToolOption {
+	rawSchema, err := json.Marshal(schemaMap)
+	if err != nil {
+		return func(*mcp.Tool) {}
+	}
+	return mcp.WithRawOutputSchema(rawSchema)
+}
+
+// makeSchemaNullable allows null for array and object schemas recursively
+func makeSchemaNullable(schema interface{}) {
+	schemaMap, ok := schema.(map[string]interface{})
+	if !ok {
+		return
+	}
+
+	if schemaType, ok := schemaMap["type"].(string); ok && (schemaType == "array" || schemaType == "object") {
+		schemaMap["type"] = []interface{}{schemaType, "null"}
+	}
+	if properties, ok := schemaMap["properties"].(map[string]interface{}); ok {
+		for _, property := range properties {
+			makeSchemaNullable(property)
+		}
+	}
+	makeSchemaNullable(schemaMap["items"])
+}
+
+// newToolResultJSON returns data as structured content. The text fallback for clients without
+// structured output support is the indented JSON, prefixed with message if it is not empty.
+// Values of secret environment variables are masked in both
+func newToolResultJSON(data interface{}, message string) *mcp.CallToolResult {
+	data, secrets := maskSecrets(data)
+	result, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to format result: %v", err))
+	}
+
+	text := string(result)
+	if message != "" {
+		text = maskText(message, secrets) + "\n" + text
+	}
+	return mcp.NewToolResultStructured(data, text)
+}
@@ -0,0 +1,72 @@
+package handlers
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/Nick1994209/cloudru-containerapps-mcp/internal/domain"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOutputSchema(t *testing.T) {
+	tool := mcp.NewTool("test_tool", outputSchema[listOutput[domain.ContainerApp]]())
+
+	var schema struct {
+		Type       string `json:"type"`
+		Properties map[string]struct {
+			Type  interface{} `json:"type"`
+			Items struct {
+				Properties map[string]struct {
+					Type interface{} `json:"type"`
+				} `json:"properties"`
+			} `json:"items"`
+		} `json:"properties"`
+	}
+	require.NoError(t, json.Unmarshal(tool.RawOutputSchema, &schema))
+
+	assert.Equal(t, "object", schema.Type)
+	assert.Equal(t, []interface{}{"array", "null"}, schema.Properties["data"].Type)
+	assert.Equal(t, "integer", schema.Properties["total"].Type)
+	assert.Equal(t, "string", schema.Properties["data"].Items.Properties["name"].Type)
+	assert.Equal(t, []interface{}{"object", "null"}, schema.Properties["data"].Items.Properties["template"].Type)
+}
+
+func TestNewToolResultJSON(t *testing.T) {
+	operation := &domain.Operation{ID: "op-1", Done: true}
+
+	result := newToolResultJSON(operation, "Successfully deleted Container App: app")
+
+	assert.False(t, result.IsError)
+	assert.Equal(t, operation, result.StructuredContent)
+	require.Len(t, result.Content, 1)
+	text, ok := result.Content[0].(mcp.TextContent)
+	require.True(t, ok)
+	assert.Contains(t, text.Text, "Successfully deleted Container App: app\n{")
+	assert.Contains(t, text.Text, `"id": "op-1"`)
+}
+
+func TestNewToolResultJSON_MasksSecrets(t *testing.T) {
+	var app domain.ContainerApp
+	require.NoError(t, json.Unmarshal([]byte(`{"name": "app", "template": {"containers": [{"name": "app", "env": [
+		{"name": "PASSWORD", "value": "secret-password-ref", "type": "secret"},
+		{"name": "LOG_LEVEL", "value": "info"}
+	]}]}}`), &app))
+
+	// Values found in secret variables are also masked in manifests and messages
+	object, err := json.Marshal(app)
+	require.NoError(t, err)
+	result := newToolResultJSON(exportOutput{Manifest: "env:\n  PASSWORD: {value: secret-password-ref, type: secret}\n", Object: object}, "Exported secret-password-ref")
+	assert.NotContains(t, result.Content[0].(mcp.TextContent).Text, "secret-password-ref")
+
+	result = newToolResultJSON(app, "Got Container App app")
+	text := result.Content[0].(mcp.TextContent).Text
+	assert.NotContains(t, text, "secret-password-ref")
+	assert.Contains(t, text, `"value": "***"`)
+	assert.Contains(t, text, `"value": "info"`)
+
+	structured, err := json.Marshal(result.StructuredContent)
+	require.NoError(t, err)
+	assert.NotContains(t, string(structured), "secret-password-ref")
+}
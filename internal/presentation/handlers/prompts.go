@@ -0,0 +1,181 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// RegisterPrompts registers prompts for common deployment workflows with the MCP server
+func (s *MCPServer) RegisterPrompts(mcpServer *server.MCPServer) {
+	s.registerDeployCurrentDirectoryPrompt(mcpServer)
+	s.registerDiagnoseContainerAppPrompt(mcpServer)
+	s.registerScheduleJobPrompt(mcpServer)
+	s.registerRollbackContainerAppImagePrompt(mcpServer)
+}
+
+// registerDeployCurrentDirectoryPrompt registers the prompt to build the current directory and deploy it as a Container App
+func (s *MCPServer) registerDeployCurrentDirectoryPrompt(mcpServer *server.MCPServer) {
+	fields := []string{"project_id", "containerapp_name", "registry_name", "repository_name", "image_version"}
+	prompt := mcp.NewPrompt("cloudru_deploy_current_directory", s.getMCPPromptOptions(
+		fmt.Sprintf("Build the current directory (%s) into a Docker image, push it and deploy it as a Container App", s.cfg.CurrentDir),
+		fields...,
+	)...)
+
+	mcpServer.AddPrompt(prompt, func(ctx context.Context, request mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+		args, err := s.getMCPPromptArguments(request, append(fields, "dockerfile_path", "dockerfile_target", "dockerfile_folder")...)
+		if err != nil {
+			return nil, err
+		}
+		image := fmt.Sprintf("%s.%s/%s:%s", args["registry_name"], s.cfg.RegistryDomain, args["repository_name"], args["image_version"])
+
+		text := fmt.Sprintf(`Deploy the project in the current directory (%s) to Cloud.ru Container Apps.
+
+1. Check whether Container App %s exists in project %s with cloudru_get_containerapp. If it does not, take the port from the EXPOSE instruction of the Dockerfile (ask me if there is none).
+2. Deploy with cloudru_deploy: project_id=%s, containerapp_name=%s, registry_name=%s, repository_name=%s, image_version=%s, dockerfile_path=%s, dockerfile_target=%s, dockerfile_folder=%s and containerapp_port for a new Container App. The image %s is built, pushed and deployed pinned by digest. If the push is rejected as unauthorized, run cloudru_docker_login and retry.
+3. Report the public URI and the status of the Container App. If the deploy failed, read cloudru_get_containerapp_logs and explain the cause.`,
+			s.cfg.CurrentDir,
+			args["containerapp_name"], args["project_id"],
+			args["project_id"], args["containerapp_name"], args["registry_name"], args["repository_name"], args["image_version"], args["dockerfile_path"], args["dockerfile_target"], args["dockerfile_folder"],
+			image,
+		)
+		return newPromptResult("Deploy the current directory to Cloud.ru Container Apps", text), nil
+	})
+}
+
+// registerDiagnoseContainerAppPrompt registers the prompt to find out why a Container App is failing
+func (s *MCPServer) registerDiagnoseContainerAppPrompt(mcpServer *server.MCPServer) {
+	fields := []string{"project_id", "containerapp_name"}
+	prompt := mcp.NewPrompt("cloudru_diagnose_containerapp", s.getMCPPromptOptions(
+		"Find out why a Container App is failing and propose a fix",
+		fields...,
+	)...)
+
+	mcpServer.AddPrompt(prompt, func(ctx context.Context, request mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+		args, err := s.getMCPPromptArguments(request, fields...)
+		if err != nil {
+			return nil, err
+		}
+
+		text := fmt.Sprintf(`Container App %s in project %s is failing. Find out why.
+
+1. Get the Container App with cloudru_get_containerapp and check its status, image, port, CPU and environment variables.
+2. If a change is still in progress or failed, check it with cloudru_get_operation.
+3. Read the recent logs with cloudru_get_containerapp_logs (e.g. since=1h log_format=text, then level=error or search for the failing component) and look for crashes, stack traces and port binding errors. If the logs are empty, check cloudru_get_containerapp_system_logs for scheduling failures, image pull errors and OOM kills.
+4. Make sure the image exists in the registry and the Container App port matches the port the application listens on.
+
+Summarize the root cause and propose a concrete fix as the tool call to run. Do not change anything until I confirm.`,
+			args["containerapp_name"], args["project_id"],
+		)
+		return newPromptResult("Diagnose a failing Container App", text), nil
+	})
+}
+
+// registerScheduleJobPrompt registers the prompt to set up a Job which runs regularly
+func (s *MCPServer) registerScheduleJobPrompt(mcpServer *server.MCPServer) {
+	fields := []string{"project_id", "job_name", "job_image", "job_schedule"}
+	prompt := mcp.NewPrompt("cloudru_schedule_job", s.getMCPPromptOptions(
+		"Create or update a Job and set up its regular runs",
+		fields...,
+	)...)
+
+	mcpServer.AddPrompt(prompt, func(ctx context.Context, request mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+		args, err := s.getMCPPromptArguments(request, fields...)
+		if err != nil {
+			return nil, err
+		}
+
+		text := fmt.Sprintf(`Set up Job %s in project %s with image %s to run %s.
+
+1. Check whether the Job exists with cloudru_get_job. Create it with cloudru_create_job (job_run_immediately=false, wait=true) or update its image with cloudru_patch_job (wait=true).
+2. Run it once with cloudru_execute_job and check the result with cloudru_job_executions_list. If the execution failed, read cloudru_get_job_execution and cloudru_get_job_execution_logs and explain the cause.
+3. Jobs are started on demand and this server has no tool to register a schedule. Propose how to call cloudru_execute_job or the Jobs API %s from an external scheduler, for example a CI pipeline cron.`,
+			args["job_name"], args["project_id"], args["job_image"], args["job_schedule"], args["job_schedule"],
+		)
+		return newPromptResult("Schedule a Job", text), nil
+	})
+}
+
+// registerRollbackContainerAppImagePrompt registers the prompt to deploy a previous image of a Container App
+func (s *MCPServer) registerRollbackContainerAppImagePrompt(mcpServer *server.MCPServer) {
+	fields := []string{"project_id", "containerapp_name", "rollback_image"}
+	prompt := mcp.NewPrompt("cloudru_rollback_containerapp_image", s.getMCPPromptOptions(
+		"Roll back a Container App to a previous image",
+		fields...,
+	)...)
+
+	mcpServer.AddPrompt(prompt, func(ctx context.Context, request mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+		args, err := s.getMCPPromptArguments(request, fields...)
+		if err != nil {
+			return nil, err
+		}
+
+		target := fmt.Sprintf("image %s", args["rollback_image"])
+		if args["rollback_image"] == "" {
+			target = "the image version deployed before the current one (list the repository tags, ask me to confirm if unsure)"
+		}
+
+		text := fmt.Sprintf(`Roll back Container App %s in project %s.
+
+1. Get the Container App with cloudru_get_containerapp and note the current image.
+2. Roll back to %s with cloudru_patch_containerapp: containerapp_image=<image>, wait=true. Change nothing else.
+3. Check the status with cloudru_get_containerapp and read cloudru_get_containerapp_logs to make sure the application started.
+4. Report the previous and the current image.`,
+			args["containerapp_name"], args["project_id"], target,
+		)
+		return newPromptResult("Roll back a Container App image", text), nil
+	})
+}
+
+// getMCPPromptOptions builds prompt options from the mapped fields, arguments with a configured value are optional
+func (s *MCPServer) getMCPPromptOptions(description string, fields ...string) []mcp.PromptOption {
+	result := []mcp.PromptOption{
+		mcp.WithPromptDescription(description),
+	}
+	for _, field := range fields {
+		fieldData := s.mappedFields[field]
+		description := fieldData.description
+		if fieldData.envValue != "" {
+			description = fmt.Sprintf("%s (default: %s)", fieldData.description, fieldData.envValue)
+		} else if fieldData.defaultValue != "" {
+			description = fmt.Sprintf("%s (default: %s)", fieldData.description, fieldData.defaultValue)
+		}
+		opts := []mcp.ArgumentOption{
+			mcp.ArgumentDescription(description),
+		}
+		if fieldData.required && fieldData.envValue == "" && fieldData.defaultValue == "" {
+			opts = append(opts, mcp.RequiredArgument())
+		}
+		result = append(result, mcp.WithArgument(field, opts...))
+	}
+	return result
+}
+
+// getMCPPromptArguments gets prompt argument values, falling back to the configured and default values
+func (s *MCPServer) getMCPPromptArguments(request mcp.GetPromptRequest, fields ...string) (map[string]string, error) {
+	result := make(map[string]string, len(fields))
+	for _, field := range fields {
+		fieldData := s.mappedFields[field]
+		value := request.Params.Arguments[field]
+		if value == "" {
+			value = fieldData.envValue
+		}
+		if value == "" {
+			value = fieldData.defaultValue
+		}
+		if value == "" && fieldData.required {
+			return nil, fmt.Errorf("argument %s is empty: %s", field, fieldData.description)
+		}
+		result[field] = value
+	}
+	return result, nil
+}
+
+// newPromptResult returns a prompt with a single user message
+func newPromptResult(description string, text string) *mcp.GetPromptResult {
+	return mcp.NewGetPromptResult(description, []mcp.PromptMessage{
+		mcp.NewPromptMessage(mcp.RoleUser, mcp.NewTextContent(text)),
+	})
+}
@@ -0,0 +1,78 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newPromptsTestServer(t *testing.T) *server.MCPServer {
+	t.Setenv("CLOUDRU_KEY_ID", "key-id")
+	t.Setenv("CLOUDRU_KEY_SECRET", "key-secret")
+	t.Setenv("CLOUDRU_PROJECT_ID", "project-1")
+	t.Setenv("CLOUDRU_CONTAINERAPP_NAME", "my-app")
+	t.Setenv("CLOUDRU_REGISTRY_NAME", "my-registry")
+
+	s := NewMCPServer(nil, nil, nil, nil, nil, nil, nil)
+	mcpServer := server.NewMCPServer("test", "0.0.0", server.WithPromptCapabilities(false))
+	s.RegisterPrompts(mcpServer)
+	return mcpServer
+}
+
+// getPrompt gets a prompt and returns the first message text or the JSON-RPC error
+func getPrompt(t *testing.T, mcpServer *server.MCPServer, name string, arguments map[string]string) (string, *mcp.JSONRPCError) {
+	message, err := json.Marshal(map[string]interface{}{
+		"jsonrpc": "2.0",
+		"id":      1,
+		"method":  "prompts/get",
+		"params":  map[string]interface{}{"name": name, "arguments": arguments},
+	})
+	require.NoError(t, err)
+
+	switch response := mcpServer.HandleMessage(context.Background(), message).(type) {
+	case mcp.JSONRPCResponse:
+		result, ok := response.Result.(mcp.GetPromptResult)
+		require.True(t, ok, "unexpected result %#v", response.Result)
+		require.Len(t, result.Messages, 1)
+		content, ok := result.Messages[0].Content.(mcp.TextContent)
+		require.True(t, ok)
+		return content.Text, nil
+	case mcp.JSONRPCError:
+		return "", &response
+	default:
+		t.Fatalf("unexpected response %#v", response)
+		return "", nil
+	}
+}
+
+func TestRegisterPrompts_UsesConfigDefaults(t *testing.T) {
+	text, rpcErr := getPrompt(t, newPromptsTestServer(t), "cloudru_deploy_current_directory", map[string]string{"image_version": "v1.2.3"})
+
+	require.Nil(t, rpcErr)
+	assert.Contains(t, text, "Container App my-app exists in project project-1")
+	assert.Contains(t, text, "registry_name=my-registry")
+	assert.Contains(t, text, "image_version=v1.2.3")
+}
+
+func TestRegisterPrompts_ArgumentsOverrideConfig(t *testing.T) {
+	text, rpcErr := getPrompt(t, newPromptsTestServer(t), "cloudru_rollback_containerapp_image", map[string]string{
+		"containerapp_name": "other-app",
+		"rollback_image":    "my-registry.cr.cloud.ru/app:v1",
+	})
+
+	require.Nil(t, rpcErr)
+	assert.Contains(t, text, "Roll back Container App other-app in project project-1")
+	assert.Contains(t, text, "image my-registry.cr.cloud.ru/app:v1")
+}
+
+func TestRegisterPrompts_RequiresArgumentsWithoutDefaults(t *testing.T) {
+	_, rpcErr := getPrompt(t, newPromptsTestServer(t), "cloudru_schedule_job", map[string]string{"job_name": "nightly"})
+
+	require.NotNil(t, rpcErr)
+	assert.Contains(t, rpcErr.Error.Message, "argument job_image is empty")
+}
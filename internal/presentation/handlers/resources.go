@@ -0,0 +1,157 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/Nick1994209/cloudru-containerapps-mcp/internal/domain"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// Resource URI templates, all resources are read-only JSON documents
+const (
+	containerAppsResourceTemplate = "cloudru://projects/{projectId}/containerapps"
+	containerAppResourceTemplate  = "cloudru://projects/{projectId}/containerapps/{name}"
+	jobsResourceTemplate          = "cloudru://projects/{projectId}/jobs"
+	jobResourceTemplate           = "cloudru://projects/{projectId}/jobs/{name}"
+	jobExecutionsResourceTemplate = "cloudru://projects/{projectId}/jobs/{name}/executions"
+	registriesResourceTemplate    = "cloudru://projects/{projectId}/registries"
+)
+
+// RegisterResources registers read-only resources and resource templates with the MCP server
+func (s *MCPServer) RegisterResources(mcpServer *server.MCPServer) {
+	mcpServer.AddResourceTemplate(
+		mcp.NewResourceTemplate(containerAppsResourceTemplate, "Container Apps",
+			mcp.WithTemplateDescription("List of Container Apps in a Cloud.ru project"),
+			mcp.WithTemplateMIMEType("application/json"),
+		),
+		func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+			containerApps, err := s.containerAppsService.GetListContainerApps(ctx, resourceArgument(request, "projectId"), domain.ListOptions{FetchAll: true})
+			if err != nil {
+				return nil, err
+			}
+			return jsonResourceContents(request, containerApps)
+		},
+	)
+
+	mcpServer.AddResourceTemplate(
+		mcp.NewResourceTemplate(containerAppResourceTemplate, "Container App",
+			mcp.WithTemplateDescription("Configuration and status of a Container App"),
+			mcp.WithTemplateMIMEType("application/json"),
+		),
+		func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+			containerApp, err := s.containerAppsService.GetContainerApp(ctx, resourceArgument(request, "projectId"), resourceArgument(request, "name"))
+			if err != nil {
+				return nil, err
+			}
+			return jsonResourceContents(request, containerApp)
+		},
+	)
+
+	mcpServer.AddResourceTemplate(
+		mcp.NewResourceTemplate(jobsResourceTemplate, "Jobs",
+			mcp.WithTemplateDescription("List of Jobs in a Cloud.ru project"),
+			mcp.WithTemplateMIMEType("application/json"),
+		),
+		func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+			jobs, err := s.jobsService.GetListJobs(ctx, resourceArgument(request, "projectId"), domain.ListOptions{FetchAll: true})
+			if err != nil {
+				return nil, err
+			}
+			return jsonResourceContents(request, jobs)
+		},
+	)
+
+	mcpServer.AddResourceTemplate(
+		mcp.NewResourceTemplate(jobResourceTemplate, "Job",
+			mcp.WithTemplateDescription("Configuration and status of a Job"),
+			mcp.WithTemplateMIMEType("application/json"),
+		),
+		func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+			job, err := s.jobsService.GetJob(ctx, resourceArgument(request, "projectId"), resourceArgument(request, "name"))
+			if err != nil {
+				return nil, err
+			}
+			return jsonResourceContents(request, job)
+		},
+	)
+
+	mcpServer.AddResourceTemplate(
+		mcp.NewResourceTemplate(jobExecutionsResourceTemplate, "Job executions",
+			mcp.WithTemplateDescription("Latest executions of a Job"),
+			mcp.WithTemplateMIMEType("application/json"),
+		),
+		func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+			executions, err := s.jobsService.GetListExecutions(ctx, resourceArgument(request, "projectId"), resourceArgument(request, "name"), domain.ListOptions{})
+			if err != nil {
+				return nil, err
+			}
+			return jsonResourceContents(request, executions)
+		},
+	)
+
+	mcpServer.AddResourceTemplate(
+		mcp.NewResourceTemplate(registriesResourceTemplate, "Docker registries",
+			mcp.WithTemplateDescription("List of Docker registries in a Cloud.ru project"),
+			mcp.WithTemplateMIMEType("application/json"),
+		),
+		func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+			registries, err := s.dockerRegistryService.GetListDockerRegistries(ctx, resourceArgument(request, "projectId"))
+			if err != nil {
+				return nil, err
+			}
+			return jsonResourceContents(request, dockerRegistriesOutput{Data: registries})
+		},
+	)
+
+	// The configured Container App is listed as a concrete resource, so clients can attach it without filling in the template
+	if s.cfg != nil && s.cfg.ProjectID != "" && s.cfg.ContainerAppName != "" {
+		uri := fmt.Sprintf("cloudru://projects/%s/containerapps/%s", s.cfg.ProjectID, s.cfg.ContainerAppName)
+		mcpServer.AddResource(
+			mcp.NewResource(uri, "Container App "+s.cfg.ContainerAppName,
+				mcp.WithResourceDescription("Configuration and status of the Container App configured via CLOUDRU_CONTAINERAPP_NAME"),
+				mcp.WithMIMEType("application/json"),
+			),
+			func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+				containerApp, err := s.containerAppsService.GetContainerApp(ctx, s.cfg.ProjectID, s.cfg.ContainerAppName)
+				if err != nil {
+					return nil, err
+				}
+				return jsonResourceContents(request, containerApp)
+			},
+		)
+	}
+}
+
+// resourceArgument gets a variable matched from the resource URI template
+func resourceArgument(request mcp.ReadResourceRequest, name string) string {
+	switch value := request.Params.Arguments[name].(type) {
+	case string:
+		return value
+	case []string:
+		if len(value) > 0 {
+			return value[0]
+		}
+	}
+	return ""
+}
+
+// jsonResourceContents returns data as an indented JSON resource, values of secret environment variables are masked
+func jsonResourceContents(request mcp.ReadResourceRequest, data interface{}) ([]mcp.ResourceContents, error) {
+	data, _ = maskSecrets(data)
+	result, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to format resource %s: %w", request.Params.URI, err)
+	}
+
+	return []mcp.ResourceContents{
+		mcp.TextResourceContents{
+			URI:      request.Params.URI,
+			MIMEType: "application/json",
+			Text:     string(result),
+		},
+	}, nil
+}
@@ -0,0 +1,111 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/Nick1994209/cloudru-containerapps-mcp/internal/domain"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeContainerAppsService returns a Container App with the requested name
+type fakeContainerAppsService struct {
+	domain.ContainerAppsService
+}
+
+func (f *fakeContainerAppsService) GetContainerApp(ctx context.Context, projectID string, containerAppName string) (*domain.ContainerApp, error) {
+	if containerAppName == "missing" {
+		return nil, errors.New("container app not found")
+	}
+	return &domain.ContainerApp{ProjectID: projectID, Name: containerAppName, Status: "RUNNING"}, nil
+}
+
+// fakeJobsService returns Jobs and executions with the requested names
+type fakeJobsService struct {
+	domain.JobsService
+}
+
+func (f *fakeJobsService) GetJob(ctx context.Context, projectID string, jobName string) (*domain.Job, error) {
+	return &domain.Job{ProjectID: projectID, Name: jobName}, nil
+}
+
+func (f *fakeJobsService) GetListExecutions(ctx context.Context, projectID string, jobName string, options domain.ListOptions) (*domain.JobExecutionsPage, error) {
+	return &domain.JobExecutionsPage{Data: []domain.JobExecution{{ExecutionName: jobName + "-1"}}}, nil
+}
+
+func newResourcesTestServer() *server.MCPServer {
+	s := &MCPServer{
+		containerAppsService: &fakeContainerAppsService{},
+		jobsService:          &fakeJobsService{},
+	}
+	mcpServer := server.NewMCPServer("test", "0.0.0", server.WithResourceCapabilities(false, false))
+	s.RegisterResources(mcpServer)
+	return mcpServer
+}
+
+// readResource reads uri and returns the resource text or the JSON-RPC error
+func readResource(t *testing.T, mcpServer *server.MCPServer, uri string) (string, *mcp.JSONRPCError) {
+	message, err := json.Marshal(map[string]interface{}{
+		"jsonrpc": "2.0",
+		"id":      1,
+		"method":  "resources/read",
+		"params":  map[string]interface{}{"uri": uri},
+	})
+	require.NoError(t, err)
+
+	switch response := mcpServer.HandleMessage(context.Background(), message).(type) {
+	case mcp.JSONRPCResponse:
+		result, ok := response.Result.(mcp.ReadResourceResult)
+		require.True(t, ok, "unexpected result %#v", response.Result)
+		require.Len(t, result.Contents, 1)
+		contents, ok := result.Contents[0].(mcp.TextResourceContents)
+		require.True(t, ok)
+		assert.Equal(t, uri, contents.URI)
+		assert.Equal(t, "application/json", contents.MIMEType)
+		return contents.Text, nil
+	case mcp.JSONRPCError:
+		return "", &response
+	default:
+		t.Fatalf("unexpected response %#v", response)
+		return "", nil
+	}
+}
+
+func TestRegisterResources_ReadsContainerApp(t *testing.T) {
+	text, rpcErr := readResource(t, newResourcesTestServer(), "cloudru://projects/project-1/containerapps/my-app")
+
+	require.Nil(t, rpcErr)
+	var containerApp domain.ContainerApp
+	require.NoError(t, json.Unmarshal([]byte(text), &containerApp))
+	assert.Equal(t, "project-1", containerApp.ProjectID)
+	assert.Equal(t, "my-app", containerApp.Name)
+}
+
+func TestRegisterResources_DistinguishesJobAndExecutions(t *testing.T) {
+	mcpServer := newResourcesTestServer()
+
+	text, rpcErr := readResource(t, mcpServer, "cloudru://projects/project-1/jobs/my-job")
+	require.Nil(t, rpcErr)
+	var job domain.Job
+	require.NoError(t, json.Unmarshal([]byte(text), &job))
+	assert.Equal(t, "my-job", job.Name)
+
+	text, rpcErr = readResource(t, mcpServer, "cloudru://projects/project-1/jobs/my-job/executions")
+	require.Nil(t, rpcErr)
+	var executions domain.JobExecutionsPage
+	require.NoError(t, json.Unmarshal([]byte(text), &executions))
+	require.Len(t, executions.Data, 1)
+	assert.Equal(t, "my-job-1", executions.Data[0].ExecutionName)
+}
+
+func TestRegisterResources_ReturnsServiceError(t *testing.T) {
+	_, rpcErr := readResource(t, newResourcesTestServer(), "cloudru://projects/project-1/containerapps/missing")
+
+	require.NotNil(t, rpcErr)
+	assert.Contains(t, rpcErr.Error.Message, "container app not found")
+}
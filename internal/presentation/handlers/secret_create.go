@@ -0,0 +1,64 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Nick1994209/cloudru-containerapps-mcp/internal/domain"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// RegisterCreateSecretTool registers the create secret tool with the MCP server
+func (s *MCPServer) RegisterCreateSecretTool(mcpServer *server.MCPServer) {
+	// Prepare tool options including description and fields
+	toolOptions := s.getMCPFieldsOptions(
+		"Create a Cloud.ru Secret Manager secret, e.g. for a database password, so it is not passed as a plain environment variable. The value is never returned, reference the returned secret ID in an environment variable with the secret type",
+		"project_id",
+		"secret_name",
+		"secret_value",
+		"secret_description",
+	)
+	toolOptions = append(toolOptions, outputSchema[domain.Secret]())
+	createSecretTool := mcp.NewTool("cloudru_create_secret", toolOptions...)
+
+	mcpServer.AddTool(createSecretTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		// Get project ID
+		projectID, err := s.getMCPFieldValue("project_id", request)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		// Get secret name
+		secretName, err := s.getMCPFieldValue("secret_name", request)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		// Get secret value
+		secretValue, err := s.getMCPFieldValue("secret_value", request)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		// Get secret description
+		secretDescription, err := s.getMCPFieldValue("secret_description", request)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		// Call the service
+		secret, err := s.secretsService.CreateSecret(ctx, domain.CreateSecretRequest{
+			ProjectID:   projectID,
+			Name:        secretName,
+			Description: secretDescription,
+			Value:       secretValue,
+		})
+		if err != nil {
+			return mcp.NewToolResultError(maskText(err.Error(), []string{secretValue})), nil
+		}
+
+		message := fmt.Sprintf(`Successfully created secret %s, reference it as {"value": "%s", "type": "secret"} in environment variables`, secretName, secret.ID)
+		return newToolResultJSON(secret, message), nil
+	})
+}
@@ -0,0 +1,35 @@
+package handlers
+
+import (
+	"context"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// RegisterGetListSecretsTool registers the get list secrets tool with the MCP server
+func (s *MCPServer) RegisterGetListSecretsTool(mcpServer *server.MCPServer) {
+	// Prepare tool options including description and fields
+	toolOptions := s.getMCPFieldsOptions(
+		"Get list of Cloud.ru Secret Manager secrets of a project. Secret values are not returned, use the secret ID as the value of an environment variable with the secret type",
+		"project_id",
+	)
+	toolOptions = append(toolOptions, outputSchema[secretsOutput]())
+	getListSecretsTool := mcp.NewTool("cloudru_get_list_secrets", toolOptions...)
+
+	mcpServer.AddTool(getListSecretsTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		// Get project ID
+		projectID, err := s.getMCPFieldValue("project_id", request)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		// Call the service
+		secrets, err := s.secretsService.GetListSecrets(ctx, projectID)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		return newToolResultJSON(secretsOutput{Data: secrets}, ""), nil
+	})
+}
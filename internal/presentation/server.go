@@ -12,9 +12,9 @@ type MCPServer struct {
 }
 
 // NewMCPServer creates a new MCP server with the required services
-func NewMCPServer(descriptionService domain.DescriptionService, dockerService domain.DockerService, containerAppsService domain.ContainerAppsService, dockerRegistryService domain.ArtifactRegistryService, jobsService domain.JobsService) *MCPServer {
+func NewMCPServer(descriptionService domain.DescriptionService, dockerService domain.DockerService, containerAppsService domain.ContainerAppsService, dockerRegistryService domain.ArtifactRegistryService, jobsService domain.JobsService, operationsService domain.OperationsService, secretsService domain.SecretsService) *MCPServer {
 	return &MCPServer{
-		MCPServer: handlers.NewMCPServer(descriptionService, dockerService, containerAppsService, dockerRegistryService, jobsService),
+		MCPServer: handlers.NewMCPServer(descriptionService, dockerService, containerAppsService, dockerRegistryService, jobsService, operationsService, secretsService),
 	}
 }
 
@@ -78,6 +78,11 @@ func (s *MCPServer) RegisterGetContainerAppLogsTool(mcpServer *server.MCPServer)
 	s.MCPServer.RegisterGetContainerAppLogsTool(mcpServer)
 }
 
+// RegisterTailContainerAppLogsTool registers the tail container app logs tool with the MCP server
+func (s *MCPServer) RegisterTailContainerAppLogsTool(mcpServer *server.MCPServer) {
+	s.MCPServer.RegisterTailContainerAppLogsTool(mcpServer)
+}
+
 // RegisterGetContainerAppSystemLogsTool registers the get container app system logs tool with the MCP server
 func (s *MCPServer) RegisterGetContainerAppSystemLogsTool(mcpServer *server.MCPServer) {
 	s.MCPServer.RegisterGetContainerAppSystemLogsTool(mcpServer)
@@ -93,6 +98,16 @@ func (s *MCPServer) RegisterCreateDockerRegistryTool(mcpServer *server.MCPServer
 	s.MCPServer.RegisterCreateDockerRegistryTool(mcpServer)
 }
 
+// RegisterGetListSecretsTool registers the get list secrets tool with the MCP server
+func (s *MCPServer) RegisterGetListSecretsTool(mcpServer *server.MCPServer) {
+	s.MCPServer.RegisterGetListSecretsTool(mcpServer)
+}
+
+// RegisterCreateSecretTool registers the create secret tool with the MCP server
+func (s *MCPServer) RegisterCreateSecretTool(mcpServer *server.MCPServer) {
+	s.MCPServer.RegisterCreateSecretTool(mcpServer)
+}
+
 // RegisterGetListJobsTool registers the get list jobs tool with the MCP server
 func (s *MCPServer) RegisterGetListJobsTool(mcpServer *server.MCPServer) {
 	s.MCPServer.RegisterGetListJobsTool(mcpServer)
@@ -113,7 +128,72 @@ func (s *MCPServer) RegisterGetListExecutionsTool(mcpServer *server.MCPServer) {
 	s.MCPServer.RegisterGetListExecutionsTool(mcpServer)
 }
 
+// RegisterGetJobExecutionTool registers the get job execution tool with the MCP server
+func (s *MCPServer) RegisterGetJobExecutionTool(mcpServer *server.MCPServer) {
+	s.MCPServer.RegisterGetJobExecutionTool(mcpServer)
+}
+
+// RegisterGetJobExecutionLogsTool registers the get job execution logs tool with the MCP server
+func (s *MCPServer) RegisterGetJobExecutionLogsTool(mcpServer *server.MCPServer) {
+	s.MCPServer.RegisterGetJobExecutionLogsTool(mcpServer)
+}
+
 // RegisterGetRegistryImagesTool registers the get registry images tool with the MCP server
 func (s *MCPServer) RegisterGetRegistryImagesTool(mcpServer *server.MCPServer) {
 	s.MCPServer.RegisterGetRegistryImagesTool(mcpServer)
 }
+
+// RegisterGetOperationTool registers the get operation tool with the MCP server
+func (s *MCPServer) RegisterGetOperationTool(mcpServer *server.MCPServer) {
+	s.MCPServer.RegisterGetOperationTool(mcpServer)
+}
+
+// RegisterWaitOperationTool registers the wait operation tool with the MCP server
+func (s *MCPServer) RegisterWaitOperationTool(mcpServer *server.MCPServer) {
+	s.MCPServer.RegisterWaitOperationTool(mcpServer)
+}
+
+// RegisterDeployTool registers the deploy tool with the MCP server
+func (s *MCPServer) RegisterDeployTool(mcpServer *server.MCPServer) {
+	s.MCPServer.RegisterDeployTool(mcpServer)
+}
+
+// RegisterApplyTool registers the manifest apply tool with the MCP server
+func (s *MCPServer) RegisterApplyTool(mcpServer *server.MCPServer) {
+	s.MCPServer.RegisterApplyTool(mcpServer)
+}
+
+// RegisterDiffTool registers the manifest diff tool with the MCP server
+func (s *MCPServer) RegisterDiffTool(mcpServer *server.MCPServer) {
+	s.MCPServer.RegisterDiffTool(mcpServer)
+}
+
+// RegisterExportContainerAppTool registers the export container app tool with the MCP server
+func (s *MCPServer) RegisterExportContainerAppTool(mcpServer *server.MCPServer) {
+	s.MCPServer.RegisterExportContainerAppTool(mcpServer)
+}
+
+// RegisterExportJobTool registers the export job tool with the MCP server
+func (s *MCPServer) RegisterExportJobTool(mcpServer *server.MCPServer) {
+	s.MCPServer.RegisterExportJobTool(mcpServer)
+}
+
+// RegisterCloneContainerAppTool registers the clone container app tool with the MCP server
+func (s *MCPServer) RegisterCloneContainerAppTool(mcpServer *server.MCPServer) {
+	s.MCPServer.RegisterCloneContainerAppTool(mcpServer)
+}
+
+// RegisterCloneJobTool registers the clone job tool with the MCP server
+func (s *MCPServer) RegisterCloneJobTool(mcpServer *server.MCPServer) {
+	s.MCPServer.RegisterCloneJobTool(mcpServer)
+}
+
+// RegisterResources registers read-only resources and resource templates with the MCP server
+func (s *MCPServer) RegisterResources(mcpServer *server.MCPServer) {
+	s.MCPServer.RegisterResources(mcpServer)
+}
+
+// RegisterPrompts registers prompts for common deployment workflows with the MCP server
+func (s *MCPServer) RegisterPrompts(mcpServer *server.MCPServer) {
+	s.MCPServer.RegisterPrompts(mcpServer)
+}
@@ -0,0 +1,81 @@
+package presentation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/Nick1994209/cloudru-containerapps-mcp/internal/config"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// shutdownTimeout is how long active HTTP sessions are given to finish after a stop signal
+const shutdownTimeout = 10 * time.Second
+
+// httpTransport is implemented by the SSE and streamable HTTP servers of mcp-go
+type httpTransport interface {
+	Start(addr string) error
+	Shutdown(ctx context.Context) error
+}
+
+// Serve exposes the MCP server with the configured transport and blocks until ctx is cancelled
+// or the transport fails
+func Serve(ctx context.Context, mcpServer *server.MCPServer, transportCfg config.TransportConfig) error {
+	switch transportCfg.Type {
+	case "", config.TransportStdio:
+		// Listen returns the cancellation error when ctx is cancelled by a stop signal
+		err := server.NewStdioServer(mcpServer).Listen(ctx, os.Stdin, os.Stdout)
+		if errors.Is(err, context.Canceled) {
+			return nil
+		}
+		return err
+	case config.TransportSSE:
+		var opts []server.SSEOption
+		if transportCfg.BasePath != "" {
+			opts = append(opts, server.WithStaticBasePath(transportCfg.BasePath))
+		}
+		return serveHTTP(ctx, server.NewSSEServer(mcpServer, opts...), transportCfg)
+	case config.TransportHTTP:
+		var opts []server.StreamableHTTPOption
+		if transportCfg.BasePath != "" {
+			opts = append(opts, server.WithEndpointPath(transportCfg.BasePath))
+		}
+		return serveHTTP(ctx, server.NewStreamableHTTPServer(mcpServer, opts...), transportCfg)
+	default:
+		return fmt.Errorf("unknown transport %q, expected one of: %s, %s, %s", transportCfg.Type, config.TransportStdio, config.TransportSSE, config.TransportHTTP)
+	}
+}
+
+// serveHTTP starts an HTTP based transport and shuts it down gracefully when ctx is cancelled
+func serveHTTP(ctx context.Context, transport httpTransport, transportCfg config.TransportConfig) error {
+	errCh := make(chan error, 1)
+	go func() {
+		log.Printf("Starting MCP %s server on %s (base path: %q)", transportCfg.Type, transportCfg.Address, transportCfg.BasePath)
+		errCh <- transport.Start(transportCfg.Address)
+	}()
+
+	select {
+	case err := <-errCh:
+		if errors.Is(err, http.ErrServerClosed) {
+			return nil
+		}
+		return err
+	case <-ctx.Done():
+	}
+
+	log.Printf("Shutting down MCP %s server", transportCfg.Type)
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := transport.Shutdown(shutdownCtx); err != nil {
+		return fmt.Errorf("failed to shutdown %s server: %w", transportCfg.Type, err)
+	}
+
+	if err := <-errCh; err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
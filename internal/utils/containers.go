@@ -0,0 +1,102 @@
+package utils
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/Nick1994209/cloudru-containerapps-mcp/internal/domain"
+)
+
+// containerInput is a container passed to the tools, env accepts any format of ParseEnvironmentVariables
+type containerInput struct {
+	Name    string          `json:"name"`
+	Image   string          `json:"image"`
+	CPU     string          `json:"cpu"`
+	Memory  string          `json:"memory"`
+	Port    int             `json:"port"`
+	Env     json.RawMessage `json:"env"`
+	Command []string        `json:"command"`
+	Args    []string        `json:"args"`
+
+	VolumeMounts []domain.VolumeMount `json:"volumeMounts"`
+}
+
+// ParseContainers parses a JSON array of containers:
+// [{"name": "proxy", "image": "nginx:1.27", "cpu": "0.1", "memory": "256Mi", "port": 8081, "env": {"A": "1"}, "command": ["nginx"], "args": [],
+// "volumeMounts": [{"name": "data", "mountPath": "/data", "readOnly": true}]}].
+// name and image are required, resources are validated and default to the smallest allowed size
+func ParseContainers(containers string) ([]domain.ContainerSpec, error) {
+	if strings.TrimSpace(containers) == "" {
+		return nil, nil
+	}
+
+	var inputs []containerInput
+	if err := decodeStrict(containers, &inputs); err != nil {
+		return nil, fmt.Errorf("failed to parse containers, expected a JSON array of objects: %w", err)
+	}
+
+	specs := make([]domain.ContainerSpec, 0, len(inputs))
+	seen := make(map[string]bool, len(inputs))
+	var errs []error
+	for i, input := range inputs {
+		spec, err := parseContainer(input)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("container %d: %w", i+1, err))
+			continue
+		}
+		if seen[spec.Name] {
+			errs = append(errs, fmt.Errorf("container %d: duplicate name %s", i+1, spec.Name))
+			continue
+		}
+		seen[spec.Name] = true
+		specs = append(specs, spec)
+	}
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+	return specs, nil
+}
+
+func parseContainer(input containerInput) (domain.ContainerSpec, error) {
+	spec := domain.ContainerSpec{
+		Name:    strings.TrimSpace(input.Name),
+		Image:   strings.TrimSpace(input.Image),
+		Port:    input.Port,
+		Command: input.Command,
+		Args:    input.Args,
+
+		VolumeMounts: input.VolumeMounts,
+	}
+	if spec.Name == "" {
+		return spec, errors.New("name is required")
+	}
+	if spec.Image == "" {
+		return spec, fmt.Errorf("%s: image is required", spec.Name)
+	}
+
+	if err := validateVolumeMounts(spec.VolumeMounts); err != nil {
+		return spec, fmt.Errorf("%s: %w", spec.Name, err)
+	}
+
+	cpu, memory, err := ParseResources(input.CPU, input.Memory)
+	if err != nil {
+		return spec, fmt.Errorf("%s: %w", spec.Name, err)
+	}
+	spec.CPU, spec.Memory = cpu, memory
+
+	if len(input.Env) > 0 && string(input.Env) != "null" {
+		// env is a JSON object or array, or a string in the legacy format
+		environmentVariables := string(input.Env)
+		var legacy string
+		if json.Unmarshal(input.Env, &legacy) == nil {
+			environmentVariables = legacy
+		}
+		spec.Env, err = ParseEnvironmentVariables(environmentVariables)
+		if err != nil {
+			return spec, fmt.Errorf("%s: %w", spec.Name, err)
+		}
+	}
+	return spec, nil
+}
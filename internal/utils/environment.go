@@ -1,52 +1,188 @@
 package utils
 
-import "strings"
-
-// ParseEnvironmentVariables parses environment variables from format <name>='<value>';<next_name>='value2'
-func ParseEnvironmentVariables(environmentVariables string) []map[string]interface{} {
-	var envVars []map[string]interface{}
-	if environmentVariables != "" {
-		// Split by semicolon
-		variables := strings.Split(environmentVariables, ";")
-		for _, variable := range variables {
-			// Split by first equals sign
-			parts := strings.SplitN(variable, "=", 2)
-			if len(parts) == 2 {
-				name := strings.TrimSpace(parts[0])
-				value := strings.TrimSpace(parts[1])
-				// Remove quotes if present
-				if strings.HasPrefix(value, "'") && strings.HasSuffix(value, "'") {
-					value = value[1 : len(value)-1]
-				}
-				envVars = append(envVars, map[string]interface{}{
-					"name":  name,
-					"value": value,
-				})
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/Nick1994209/cloudru-containerapps-mcp/internal/domain"
+)
+
+// ParseEnvironmentVariables parses environment variables from one of the formats:
+//   - JSON object: {"NAME": "value", "TOKEN": {"value": "<secret reference>", "type": "secret"}}
+//   - JSON array: [{"name": "NAME", "value": "value", "type": "plain"}]
+//   - <name>='<value>';<next_name>='value2', where a backslash escapes the next character
+//     and a quote closes the value only before ';' or the end, so quoted values may contain ';', '=' and quotes
+func ParseEnvironmentVariables(environmentVariables string) ([]domain.EnvironmentVariable, error) {
+	trimmed := strings.TrimSpace(environmentVariables)
+	var variables []domain.EnvironmentVariable
+	var err error
+	switch {
+	case trimmed == "":
+		return nil, nil
+	case strings.HasPrefix(trimmed, "{"):
+		variables, err = parseEnvironmentVariablesObject(trimmed)
+	case strings.HasPrefix(trimmed, "["):
+		if err := json.Unmarshal([]byte(trimmed), &variables); err != nil {
+			return nil, fmt.Errorf("invalid environment variables JSON array: %w", err)
+		}
+	default:
+		variables, err = parseEnvironmentVariablesString(trimmed)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	names := make(map[string]bool, len(variables))
+	for _, variable := range variables {
+		if variable.Name == "" {
+			return nil, errors.New("environment variable name is empty")
+		}
+		if names[variable.Name] {
+			return nil, fmt.Errorf("environment variable %s is declared twice", variable.Name)
+		}
+		names[variable.Name] = true
+		if variable.Type != "" && variable.Type != domain.EnvironmentVariableTypePlain && variable.Type != domain.EnvironmentVariableTypeSecret {
+			return nil, fmt.Errorf("environment variable %s: type must be '%s' or '%s', got: %s", variable.Name, domain.EnvironmentVariableTypePlain, domain.EnvironmentVariableTypeSecret, variable.Type)
+		}
+		if variable.Type == domain.EnvironmentVariableTypeSecret && (variable.Value == "" || variable.Value == domain.EnvironmentVariableMask) {
+			return nil, fmt.Errorf("environment variable %s: the value of a secret variable must be a Secret Manager secret reference, got: %q", variable.Name, variable.Value)
+		}
+	}
+	return variables, nil
+}
+
+// IsMaskedSecret reports whether value is the mask of a secret variable, as returned by tool outputs.
+// Such a value sent back without a type must keep the live secret reference instead of overwriting it
+func IsMaskedSecret(value string, variableType string) bool {
+	return value == domain.EnvironmentVariableMask && variableType == domain.EnvironmentVariableTypeSecret
+}
+
+// ResolveEnvironmentVariable applies the variable to the current variable with the same name, the zero value if there is none.
+// A variable without a type keeps the current type and a masked secret value keeps the current secret reference
+func ResolveEnvironmentVariable(variable domain.EnvironmentVariable, current domain.EnvironmentVariable) domain.EnvironmentVariable {
+	if variable.Type != "" {
+		return variable
+	}
+	if IsMaskedSecret(variable.Value, current.Type) {
+		variable.Value = current.Value
+	}
+	variable.Type = current.Type
+	return variable
+}
+
+// MergeEnvironmentVariable merges the variable into the raw current variable with the same name by ResolveEnvironmentVariable,
+// a new raw variable is returned if current is nil. Unknown fields of the current variable are kept
+func MergeEnvironmentVariable(current map[string]interface{}, variable domain.EnvironmentVariable) map[string]interface{} {
+	if current == nil {
+		current = map[string]interface{}{"name": variable.Name}
+	}
+	currentValue, _ := current["value"].(string)
+	currentType, _ := current["type"].(string)
+	variable = ResolveEnvironmentVariable(variable, domain.EnvironmentVariable{Name: variable.Name, Value: currentValue, Type: currentType})
+
+	current["value"] = variable.Value
+	if variable.Type != "" {
+		current["type"] = variable.Type
+	}
+	return current
+}
+
+// parseEnvironmentVariablesObject parses a JSON object of values or {"value", "type"} objects, variables are sorted by name
+func parseEnvironmentVariablesObject(environmentVariables string) ([]domain.EnvironmentVariable, error) {
+	var object map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(environmentVariables), &object); err != nil {
+		return nil, fmt.Errorf("invalid environment variables JSON object: %w", err)
+	}
+
+	names := make([]string, 0, len(object))
+	for name := range object {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	variables := make([]domain.EnvironmentVariable, 0, len(object))
+	for _, name := range names {
+		variable := domain.EnvironmentVariable{Name: name}
+		if err := json.Unmarshal(object[name], &variable.Value); err != nil {
+			var typed struct {
+				Value string `json:"value"`
+				Type  string `json:"type"`
 			}
+			if err := json.Unmarshal(object[name], &typed); err != nil {
+				return nil, fmt.Errorf("environment variable %s: value must be a string or {\"value\": ..., \"type\": ...}", name)
+			}
+			variable.Value, variable.Type = typed.Value, typed.Type
 		}
+		variables = append(variables, variable)
 	}
-	return envVars
+	return variables, nil
 }
 
-// ParseCPU maps CPU allocation to memory allocation
-func ParseCPU(cpu string) (string, string) {
-	var memory string
-	switch cpu {
-	case "0.1":
-		memory = "256Mi"
-	case "0.2":
-		memory = "512Mi"
-	case "0.3":
-		memory = "768Mi"
-	case "0.5":
-		memory = "1024Mi"
-	case "1":
-		memory = "4096Mi"
-	default:
-		// Default to 0.1 CPU and 256Mi memory for unknown values
-		cpu = "0.1"
-		memory = "256Mi"
+// parseEnvironmentVariablesString parses the <name>='<value>';<next_name>='value2' format
+func parseEnvironmentVariablesString(environmentVariables string) ([]domain.EnvironmentVariable, error) {
+	var variables []domain.EnvironmentVariable
+	rest := environmentVariables
+	for {
+		rest = strings.TrimLeft(rest, " \t\r\n;")
+		if rest == "" {
+			return variables, nil
+		}
+
+		separator := strings.IndexAny(rest, "=;")
+		if separator < 0 || rest[separator] != '=' {
+			return nil, fmt.Errorf("environment variable %s has no value, expected format <name>='<value>'", strings.TrimSpace(strings.SplitN(rest, ";", 2)[0]))
+		}
+		name := strings.TrimSpace(rest[:separator])
+
+		value, next, err := scanEnvironmentVariableValue(rest[separator+1:])
+		if err != nil {
+			return nil, fmt.Errorf("environment variable %s: %w", name, err)
+		}
+		variables = append(variables, domain.EnvironmentVariable{Name: name, Value: value})
+		rest = next
 	}
+}
+
+// scanEnvironmentVariableValue reads a quoted or unquoted value up to the next unescaped ';' and returns the rest
+func scanEnvironmentVariableValue(input string) (string, string, error) {
+	input = strings.TrimLeft(input, " \t")
+	var value strings.Builder
+
+	if input != "" && (input[0] == '\'' || input[0] == '"') {
+		quote := input[0]
+		for i := 1; i < len(input); i++ {
+			switch {
+			case input[i] == '\\' && i+1 < len(input):
+				i++
+				value.WriteByte(input[i])
+			case input[i] == quote && closesValue(input[i+1:]):
+				return value.String(), input[i+1:], nil
+			default:
+				value.WriteByte(input[i])
+			}
+		}
+		return "", "", fmt.Errorf("value has no closing %c", quote)
+	}
+
+	for i := 0; i < len(input); i++ {
+		switch {
+		case input[i] == '\\' && i+1 < len(input):
+			i++
+			value.WriteByte(input[i])
+		case input[i] == ';':
+			return strings.TrimSpace(value.String()), input[i:], nil
+		default:
+			value.WriteByte(input[i])
+		}
+	}
+	return strings.TrimSpace(value.String()), "", nil
+}
 
-	return cpu, memory
+// closesValue reports whether a quote followed by rest closes a quoted value
+func closesValue(rest string) bool {
+	rest = strings.TrimLeft(rest, " \t\r\n")
+	return rest == "" || rest[0] == ';'
 }
@@ -0,0 +1,93 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/Nick1994209/cloudru-containerapps-mcp/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseEnvironmentVariables(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected []domain.EnvironmentVariable
+	}{
+		{
+			name:  "string format",
+			input: "A='1'; B=2;C=''",
+			expected: []domain.EnvironmentVariable{
+				{Name: "A", Value: "1"}, {Name: "B", Value: "2"}, {Name: "C", Value: ""},
+			},
+		},
+		{
+			name:  "quoted values with separators and quotes",
+			input: `URL='postgres://db/app?a=1;b=2';NAME='it's';ESCAPED=a\;b\\`,
+			expected: []domain.EnvironmentVariable{
+				{Name: "URL", Value: "postgres://db/app?a=1;b=2"}, {Name: "NAME", Value: "it's"}, {Name: "ESCAPED", Value: `a;b\`},
+			},
+		},
+		{
+			name:  "JSON object",
+			input: `{"B": "2", "A": {"value": "db-password", "type": "secret"}}`,
+			expected: []domain.EnvironmentVariable{
+				{Name: "A", Value: "db-password", Type: domain.EnvironmentVariableTypeSecret}, {Name: "B", Value: "2"},
+			},
+		},
+		{
+			name:  "JSON array",
+			input: `[{"name": "A", "value": "a;b", "type": "plain"}]`,
+			expected: []domain.EnvironmentVariable{
+				{Name: "A", Value: "a;b", Type: domain.EnvironmentVariableTypePlain},
+			},
+		},
+		{
+			name:     "empty",
+			input:    " ",
+			expected: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			variables, err := ParseEnvironmentVariables(tt.input)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, variables)
+		})
+	}
+}
+
+func TestParseEnvironmentVariables_Errors(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{name: "no value", input: "A='1';B", expected: "environment variable B has no value"},
+		{name: "unclosed quote", input: "A='1", expected: "no closing '"},
+		{name: "duplicate", input: "A=1;A=2", expected: "declared twice"},
+		{name: "unknown type", input: `{"A": {"value": "1", "type": "file"}}`, expected: "type must be"},
+		{name: "invalid JSON", input: `{"A": 1}`, expected: "value must be a string"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseEnvironmentVariables(tt.input)
+			assert.ErrorContains(t, err, tt.expected)
+		})
+	}
+}
+
+func TestMergeEnvironmentVariable(t *testing.T) {
+	secret := map[string]interface{}{"name": "TOKEN", "value": "token-ref", "type": "secret", "description": "kept"}
+
+	// A masked secret value keeps the reference, a value without a type keeps the current type
+	assert.Equal(t, map[string]interface{}{"name": "TOKEN", "value": "token-ref", "type": "secret", "description": "kept"},
+		MergeEnvironmentVariable(secret, domain.EnvironmentVariable{Name: "TOKEN", Value: "***"}))
+	assert.Equal(t, map[string]interface{}{"name": "TOKEN", "value": "new-ref", "type": "secret", "description": "kept"},
+		MergeEnvironmentVariable(secret, domain.EnvironmentVariable{Name: "TOKEN", Value: "new-ref"}))
+	assert.Equal(t, map[string]interface{}{"name": "TOKEN", "value": "***", "type": "plain", "description": "kept"},
+		MergeEnvironmentVariable(secret, domain.EnvironmentVariable{Name: "TOKEN", Value: "***", Type: "plain"}))
+
+	assert.Equal(t, map[string]interface{}{"name": "NEW", "value": "1"},
+		MergeEnvironmentVariable(nil, domain.EnvironmentVariable{Name: "NEW", Value: "1"}))
+}
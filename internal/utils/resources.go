@@ -0,0 +1,98 @@
+package utils
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// resourceSize is a CPU allocation with the memory allocations Cloud.ru allows for it
+type resourceSize struct {
+	cpu           string
+	memory        []string
+	defaultMemory string
+}
+
+// resourceSizes are the allowed Cloud.ru CPU/memory combinations
+var resourceSizes = []resourceSize{
+	{cpu: "0.1", memory: []string{"128Mi", "256Mi", "512Mi"}, defaultMemory: "256Mi"},
+	{cpu: "0.2", memory: []string{"256Mi", "512Mi", "1024Mi"}, defaultMemory: "512Mi"},
+	{cpu: "0.3", memory: []string{"512Mi", "768Mi", "1024Mi"}, defaultMemory: "768Mi"},
+	{cpu: "0.5", memory: []string{"512Mi", "1024Mi", "2048Mi"}, defaultMemory: "1024Mi"},
+	{cpu: "1", memory: []string{"1024Mi", "2048Mi", "4096Mi"}, defaultMemory: "4096Mi"},
+	{cpu: "2", memory: []string{"2048Mi", "4096Mi", "8192Mi"}, defaultMemory: "8192Mi"},
+	{cpu: "4", memory: []string{"4096Mi", "8192Mi", "16384Mi"}, defaultMemory: "16384Mi"},
+}
+
+// DefaultCPU is used when neither CPU nor memory is passed
+const DefaultCPU = "0.1"
+
+// ParseResources validates a CPU/memory pair and returns it in the API format.
+// An empty CPU defaults to DefaultCPU and an empty memory to the default memory of the CPU.
+// Memory is accepted in Mi or Gi, a number without a unit is treated as Mi
+func ParseResources(cpu string, memory string) (string, string, error) {
+	cpu = strings.TrimSpace(cpu)
+	if cpu == "" {
+		cpu = DefaultCPU
+	}
+
+	size, err := findResourceSize(cpu)
+	if err != nil {
+		return "", "", err
+	}
+
+	if strings.TrimSpace(memory) == "" {
+		return size.cpu, size.defaultMemory, nil
+	}
+	normalizedMemory, err := NormalizeMemory(memory)
+	if err != nil {
+		return "", "", err
+	}
+	for _, allowed := range size.memory {
+		if allowed == normalizedMemory {
+			return size.cpu, normalizedMemory, nil
+		}
+	}
+	return "", "", fmt.Errorf("memory %s is not allowed with %s CPU, allowed values: %s", normalizedMemory, size.cpu, strings.Join(size.memory, ", "))
+}
+
+// findResourceSize finds the allowed size of a CPU value, "0.50" and "1.0" are accepted as "0.5" and "1"
+func findResourceSize(cpu string) (resourceSize, error) {
+	value, err := strconv.ParseFloat(cpu, 64)
+	if err == nil {
+		normalizedCPU := strconv.FormatFloat(value, 'f', -1, 64)
+		for _, size := range resourceSizes {
+			if size.cpu == normalizedCPU {
+				return size, nil
+			}
+		}
+	}
+
+	allowed := make([]string, 0, len(resourceSizes))
+	for _, size := range resourceSizes {
+		allowed = append(allowed, size.cpu)
+	}
+	return resourceSize{}, fmt.Errorf("invalid CPU %q, allowed values: %s", cpu, strings.Join(allowed, ", "))
+}
+
+// NormalizeMemory converts memory in Mi or Gi to Mi, e.g. "1Gi" to "1024Mi"
+func NormalizeMemory(memory string) (string, error) {
+	memory = strings.TrimSpace(memory)
+	number, multiplier := memory, 1.0
+	switch {
+	case strings.HasSuffix(memory, "Gi"):
+		number, multiplier = strings.TrimSuffix(memory, "Gi"), 1024
+	case strings.HasSuffix(memory, "Mi"):
+		number = strings.TrimSuffix(memory, "Mi")
+	}
+
+	value, err := strconv.ParseFloat(strings.TrimSpace(number), 64)
+	if err != nil || value <= 0 {
+		return "", fmt.Errorf("invalid memory %q, expected a value in Mi or Gi, e.g. 512Mi or 1Gi", memory)
+	}
+	mebibytes := value * multiplier
+	if mebibytes != float64(int64(mebibytes)) {
+		return "", fmt.Errorf("invalid memory %q, memory must be a whole number of Mi", memory)
+	}
+	return strconv.FormatInt(int64(mebibytes), 10) + "Mi", nil
+}
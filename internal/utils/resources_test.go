@@ -0,0 +1,55 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseResources(t *testing.T) {
+	tests := []struct {
+		name           string
+		cpu            string
+		memory         string
+		expectedCPU    string
+		expectedMemory string
+	}{
+		{name: "defaults", expectedCPU: "0.1", expectedMemory: "256Mi"},
+		{name: "default memory of cpu", cpu: "0.5", expectedCPU: "0.5", expectedMemory: "1024Mi"},
+		{name: "explicit memory", cpu: "0.5", memory: "2048Mi", expectedCPU: "0.5", expectedMemory: "2048Mi"},
+		{name: "memory in Gi", cpu: "2", memory: "4Gi", expectedCPU: "2", expectedMemory: "4096Mi"},
+		{name: "memory without unit", cpu: "0.2", memory: "256", expectedCPU: "0.2", expectedMemory: "256Mi"},
+		{name: "cpu with trailing zeros", cpu: "1.0", expectedCPU: "1", expectedMemory: "4096Mi"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cpu, memory, err := ParseResources(tt.cpu, tt.memory)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedCPU, cpu)
+			assert.Equal(t, tt.expectedMemory, memory)
+		})
+	}
+}
+
+func TestParseResources_Errors(t *testing.T) {
+	tests := []struct {
+		name     string
+		cpu      string
+		memory   string
+		expected string
+	}{
+		{name: "unknown cpu", cpu: "3", expected: `invalid CPU "3", allowed values: 0.1, 0.2, 0.3, 0.5, 1, 2, 4`},
+		{name: "not a number", cpu: "two", expected: `invalid CPU "two"`},
+		{name: "memory not allowed for cpu", cpu: "0.1", memory: "1Gi", expected: "memory 1024Mi is not allowed with 0.1 CPU, allowed values: 128Mi, 256Mi, 512Mi"},
+		{name: "invalid memory", cpu: "1", memory: "1GB", expected: `invalid memory "1GB"`},
+		{name: "fractional Mi", cpu: "1", memory: "0.5Mi", expected: "whole number of Mi"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := ParseResources(tt.cpu, tt.memory)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.expected)
+		})
+	}
+}
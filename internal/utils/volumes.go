@@ -0,0 +1,101 @@
+package utils
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/Nick1994209/cloudru-containerapps-mcp/internal/domain"
+)
+
+// Default location of Cloud.ru Object Storage buckets
+const (
+	DefaultVolumeRegion     = "ru-central-1"
+	DefaultVolumeEntrypoint = "https://s3.cloud.ru"
+)
+
+// ParseVolumes parses a JSON array of S3 bucket volumes:
+// [{"name": "data", "bucketName": "my-bucket", "tenantId": "...", "region": "ru-central-1", "entrypoint": "https://s3.cloud.ru", "readOnly": false}].
+// name, bucketName and tenantId are required, region and entrypoint default to Cloud.ru Object Storage
+func ParseVolumes(volumes string) ([]domain.Volume, error) {
+	if strings.TrimSpace(volumes) == "" {
+		return nil, nil
+	}
+
+	var parsed []domain.Volume
+	if err := decodeStrict(volumes, &parsed); err != nil {
+		return nil, fmt.Errorf("failed to parse volumes, expected a JSON array of objects: %w", err)
+	}
+
+	seen := make(map[string]bool, len(parsed))
+	var errs []error
+	for i := range parsed {
+		volume := &parsed[i]
+		volume.Name = strings.TrimSpace(volume.Name)
+		if volume.Region == "" {
+			volume.Region = DefaultVolumeRegion
+		}
+		if volume.Entrypoint == "" {
+			volume.Entrypoint = DefaultVolumeEntrypoint
+		}
+
+		switch {
+		case volume.Name == "":
+			errs = append(errs, fmt.Errorf("volume %d: name is required", i+1))
+		case seen[volume.Name]:
+			errs = append(errs, fmt.Errorf("volume %d: duplicate name %s", i+1, volume.Name))
+		case volume.BucketName == "":
+			errs = append(errs, fmt.Errorf("volume %s: bucketName is required", volume.Name))
+		case volume.TenantID == "":
+			errs = append(errs, fmt.Errorf("volume %s: tenantId is required", volume.Name))
+		}
+		seen[volume.Name] = true
+	}
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+	return parsed, nil
+}
+
+// ParseVolumeMounts parses a JSON array of volume mounts: [{"name": "data", "mountPath": "/data", "readOnly": true}]
+func ParseVolumeMounts(volumeMounts string) ([]domain.VolumeMount, error) {
+	if strings.TrimSpace(volumeMounts) == "" {
+		return nil, nil
+	}
+
+	var parsed []domain.VolumeMount
+	if err := decodeStrict(volumeMounts, &parsed); err != nil {
+		return nil, fmt.Errorf("failed to parse volume mounts, expected a JSON array of objects: %w", err)
+	}
+	if err := validateVolumeMounts(parsed); err != nil {
+		return nil, err
+	}
+	return parsed, nil
+}
+
+// validateVolumeMounts checks that mounts have a volume name and unique absolute mount paths
+func validateVolumeMounts(volumeMounts []domain.VolumeMount) error {
+	paths := make(map[string]bool, len(volumeMounts))
+	var errs []error
+	for i, mount := range volumeMounts {
+		switch {
+		case mount.Name == "":
+			errs = append(errs, fmt.Errorf("volume mount %d: name is required", i+1))
+		case !path.IsAbs(mount.MountPath):
+			errs = append(errs, fmt.Errorf("volume mount %s: mountPath must be an absolute path, got: %q", mount.Name, mount.MountPath))
+		case paths[path.Clean(mount.MountPath)]:
+			errs = append(errs, fmt.Errorf("volume mount %s: mountPath %s is used more than once", mount.Name, mount.MountPath))
+		}
+		paths[path.Clean(mount.MountPath)] = true
+	}
+	return errors.Join(errs...)
+}
+
+// decodeStrict decodes JSON rejecting unknown fields, so misspelled keys are reported
+func decodeStrict(data string, value interface{}) error {
+	decoder := json.NewDecoder(strings.NewReader(data))
+	decoder.DisallowUnknownFields()
+	return decoder.Decode(value)
+}